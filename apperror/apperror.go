@@ -1,19 +1,108 @@
 package apperror
 
+import "time"
+
 type AppError struct {
 	Code    int
 	Message string
+	Details any `json:",omitempty"` // machine-readable context for errors that carry it, e.g. SpendingLimitDetails
+}
+
+// Error lets an AppError be returned as an error, e.g. from a unit of work to reject the request.
+func (e AppError) Error() string {
+	return e.Message
+}
+
+func (e AppError) WithDetails(details any) AppError {
+	e.Details = details
+	return e
+}
+
+// SpendingLimitDetails tells the client which limit was hit, how much of it is left and when the
+// full allowance is available again (nil for the per-transaction limit).
+type SpendingLimitDetails struct {
+	Window    string
+	Limit     uint
+	Remaining uint
+	Currency  string
+	ResetsAt  *time.Time
 }
 
 var (
 	ErrWalletIdNotFound    = AppError{Code: 400, Message: "wallet id not found"}
 	ErrIncompatibleRequest = AppError{Code: 400, Message: "incompatible request"}
 
+	ErrUnauthorized = AppError{Code: 401, Message: "unauthorized"}
+	ErrForbidden    = AppError{Code: 403, Message: "forbidden"}
+
 	ErrUserNotFound                               = AppError{Code: 400, Message: "user not found"}
 	ErrWalletNotFound                             = AppError{Code: 400, Message: "wallet not found"}
 	ErrCounterpartyWalletNotFound                 = AppError{Code: 400, Message: "counterparty wallet not found"}
 	ErrCounterpartyWalletCannotBeSameAsUserWallet = AppError{Code: 400, Message: "counterparty wallet can not be same as user wallet"}
 	ErrInsufficientAmount                         = AppError{Code: 400, Message: "insufficient amount"}
+	ErrUnsupportedCurrency                        = AppError{Code: 400, Message: "unsupported currency"}
+	ErrInvalidTrxFilter                           = AppError{Code: 400, Message: "invalid transaction filter"}
+	ErrInvalidCursor                              = AppError{Code: 400, Message: "invalid cursor"}
+
+	ErrFxQuoteRequired    = AppError{Code: 400, Message: "transfer between different currencies requires a quote"}
+	ErrFxQuoteNotFound    = AppError{Code: 400, Message: "fx quote not found"}
+	ErrFxQuoteMismatch    = AppError{Code: 400, Message: "fx quote does not match the transfer"}
+	ErrFxQuoteExpired     = AppError{Code: 400, Message: "fx quote expired"}
+	ErrFxQuoteAlreadyUsed = AppError{Code: 409, Message: "fx quote already used"}
+	ErrFxRateNotAvailable = AppError{Code: 422, Message: "fx rate not available"}
+	ErrFxAmountTooSmall   = AppError{Code: 400, Message: "amount too small to convert"}
+
+	ErrHoldNotFound             = AppError{Code: 400, Message: "hold not found"}
+	ErrHoldNotActive            = AppError{Code: 409, Message: "hold already captured, released or expired"}
+	ErrHoldExpired              = AppError{Code: 409, Message: "hold expired"}
+	ErrHoldCaptureExceedsAmount = AppError{Code: 400, Message: "capture amount exceeds hold"}
+	ErrInvalidHoldExpiry        = AppError{Code: 400, Message: "invalid hold expiry"}
+
+	ErrTransferNotFound          = AppError{Code: 400, Message: "transfer not found"}
+	ErrTransferAlreadyReversed   = AppError{Code: 409, Message: "transfer already reversed"}
+	ErrReversalExceedsTransfer   = AppError{Code: 400, Message: "reversal amount exceeds what is left to reverse"}
+	ErrReversalInsufficientFunds = AppError{Code: 409, Message: "receiving wallet no longer has the funds to reverse"}
+
+	ErrScheduleNotFound      = AppError{Code: 400, Message: "schedule not found"}
+	ErrInvalidScheduleRule   = AppError{Code: 400, Message: "invalid schedule rule"}
+	ErrScheduleCrossCurrency = AppError{Code: 400, Message: "scheduled transfers between different currencies are not supported"}
+	ErrScheduleCancelled     = AppError{Code: 409, Message: "schedule cancelled"}
+
+	ErrWebhookSubscriptionNotFound = AppError{Code: 400, Message: "webhook subscription not found"}
+	ErrWebhookDeliveryNotFound     = AppError{Code: 400, Message: "webhook delivery not found"}
+	ErrInvalidWebhookUrl           = AppError{Code: 400, Message: "invalid webhook url"}
+	ErrInvalidWebhookEventType     = AppError{Code: 400, Message: "invalid webhook event type"}
+
+	ErrSpendingLimitExceeded = AppError{Code: 429, Message: "spending limit exceeded"}
+	ErrInvalidSpendingLimit  = AppError{Code: 400, Message: "invalid spending limit"}
+
+	ErrFeeRuleNotFound               = AppError{Code: 400, Message: "fee rule not found"}
+	ErrInvalidFeeRule                = AppError{Code: 400, Message: "invalid fee rule"}
+	ErrFeeRuleConflict               = AppError{Code: 409, Message: "an active fee rule already exists for this operation and currency"}
+	ErrFeeRevenueWalletNotConfigured = AppError{Code: 422, Message: "no fee revenue wallet configured for the currency"}
+
+	ErrWalletFrozen                     = AppError{Code: 409, Message: "wallet is frozen"}
+	ErrWalletClosed                     = AppError{Code: 409, Message: "wallet is closed"}
+	ErrCounterpartyWalletFrozen         = AppError{Code: 409, Message: "counterparty wallet is frozen"}
+	ErrCounterpartyWalletClosed         = AppError{Code: 409, Message: "counterparty wallet is closed"}
+	ErrInvalidWalletStatus              = AppError{Code: 400, Message: "invalid wallet status"}
+	ErrInvalidWalletStatusReason        = AppError{Code: 400, Message: "invalid wallet status reason code"}
+	ErrWalletStatusTransitionNotAllowed = AppError{Code: 409, Message: "wallet status transition not allowed"}
+	ErrWalletBalanceNotZero             = AppError{Code: 409, Message: "wallet balance must be zero or swept to another wallet"}
+	ErrWalletHasActiveHolds             = AppError{Code: 409, Message: "wallet has active holds"}
+	ErrInvalidSweepWallet               = AppError{Code: 400, Message: "sweep wallet must be another open wallet in the same currency"}
+
+	ErrInvalidBalanceAdjustment = AppError{Code: 400, Message: "invalid balance adjustment"}
+
+	ErrInvalidStatementRange  = AppError{Code: 400, Message: "invalid statement range"}
+	ErrInvalidStatementFormat = AppError{Code: 400, Message: "invalid statement format"}
+
+	ErrInvalidIdempotencyKey  = AppError{Code: 400, Message: "invalid idempotency key"}
+	ErrIdempotencyKeyConflict = AppError{Code: 409, Message: "idempotency key already used with a different request"}
+
+	ErrUnbalancedJournal = AppError{Code: 500, Message: "ledger journal does not balance"}
+	ErrInternalServer    = AppError{Code: 500, Message: "internal server error"}
 
-	ErrInternalServer = AppError{Code: 500, Message: "internal server error"}
+	ErrWalletBusy     = AppError{Code: 503, Message: "wallet is busy, try again"} // still contended after the db transaction was retried
+	ErrRequestTimeout = AppError{Code: 504, Message: "request timed out"}
 )
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"os"
+
+	"wallet-app/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrMissingSubject = errors.New("token has no subject")
+
+type claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+type IJwtVerifier interface {
+	Verify(token string) (Principal, error)
+}
+
+type JwtVerifier struct {
+	key    interface{}
+	method string
+	issuer string
+}
+
+// NewJwtVerifier accepts RS256 tokens when an RSA public key file is configured, HS256 otherwise.
+func NewJwtVerifier(cfg *config.AuthConfig) (IJwtVerifier, error) {
+	if cfg.RsaPublicKeyFile != "" {
+		pem, err := os.ReadFile(cfg.RsaPublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		return &JwtVerifier{key: key, method: jwt.SigningMethodRS256.Alg(), issuer: cfg.Issuer}, nil
+	}
+	if cfg.HmacSecret != "" {
+		return &JwtVerifier{key: []byte(cfg.HmacSecret), method: jwt.SigningMethodHS256.Alg(), issuer: cfg.Issuer}, nil
+	}
+	return nil, errors.New("auth needs either hmacSecret or rsaPublicKeyFile")
+}
+
+func (j *JwtVerifier) Verify(token string) (Principal, error) {
+	options := []jwt.ParserOption{jwt.WithValidMethods([]string{j.method}), jwt.WithExpirationRequired()}
+	if j.issuer != "" {
+		options = append(options, jwt.WithIssuer(j.issuer))
+	}
+
+	var c claims
+	if _, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) { return j.key, nil }, options...); err != nil {
+		return Principal{}, err
+	}
+	if c.Subject == "" {
+		return Principal{}, ErrMissingSubject
+	}
+	return Principal{UserId: c.Subject, Roles: c.Roles}, nil
+}
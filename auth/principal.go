@@ -0,0 +1,26 @@
+package auth
+
+const RoleAdmin = "admin"
+
+// Principal is the authenticated caller, taken from the token subject and roles claim.
+type Principal struct {
+	UserId string
+	Roles  []string
+}
+
+// System is used by in-process jobs that act on behalf of the platform rather than a user.
+var System = Principal{UserId: "system", Roles: []string{RoleAdmin}}
+
+func (p Principal) IsAdmin() bool {
+	for _, role := range p.Roles {
+		if role == RoleAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccess reports whether the caller may act on resources owned by userId.
+func (p Principal) CanAccess(userId string) bool {
+	return p.IsAdmin() || (p.UserId != "" && p.UserId == userId)
+}
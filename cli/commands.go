@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"wallet-app/common"
+	"wallet-app/request"
+	"wallet-app/response"
+)
+
+// walletInspection is everything inspect shows about a wallet.
+type walletInspection struct {
+	Wallet         response.WalletResponse
+	SpendingLimits response.SpendingLimitsResponse
+	Holds          []response.HoldResponse
+	StatusChanges  []response.WalletStatusChangeResponse
+	Adjustments    []response.BalanceAdjustmentResponse
+}
+
+func (c *walletCtl) inspect(args []string) int {
+	flags := c.newFlagSet("inspect")
+	output := outputFlag(flags)
+	walletId, ok := c.parseWalletCommand(flags, args)
+	if !ok || !c.validOutput(*output) {
+		return exitUsage
+	}
+
+	principal := admin(defaultActor())
+	var inspection walletInspection
+	for _, step := range []struct {
+		name string
+		res  func() response.ResonseWrapper
+		set  func(data any)
+	}{
+		{"balance", func() response.ResonseWrapper { return c.service.GetBalance(context.Background(), principal, walletId) },
+			func(data any) { inspection.Wallet = data.(response.WalletResponse) }},
+		{"spending limits", func() response.ResonseWrapper {
+			return c.service.GetSpendingLimits(context.Background(), principal, walletId)
+		},
+			func(data any) { inspection.SpendingLimits = data.(response.SpendingLimitsResponse) }},
+		{"holds", func() response.ResonseWrapper { return c.service.GetHolds(context.Background(), principal, walletId) },
+			func(data any) { inspection.Holds = data.([]response.HoldResponse) }},
+		{"status changes", func() response.ResonseWrapper {
+			return c.service.GetWalletStatusChanges(context.Background(), principal, walletId)
+		},
+			func(data any) { inspection.StatusChanges = data.([]response.WalletStatusChangeResponse) }},
+		{"adjustments", func() response.ResonseWrapper {
+			return c.service.GetBalanceAdjustments(context.Background(), principal, walletId)
+		},
+			func(data any) { inspection.Adjustments = data.([]response.BalanceAdjustmentResponse) }},
+	} {
+		res := step.res()
+		if res.Err.Code != 0 {
+			fmt.Fprintf(c.stderr, "inspect failed reading %s: %s\n", step.name, res.Err.Message)
+			return exitFailed
+		}
+		step.set(res.Data)
+	}
+
+	return c.print(*output, inspection, func(w io.Writer) {
+		wallet, limits := inspection.Wallet, inspection.SpendingLimits
+		currency := wallet.Currency
+		row(w, "WALLET", wallet.WalletId)
+		row(w, "USER", wallet.UserId)
+		row(w, "STATUS", wallet.Status)
+		row(w, "CURRENCY", currency)
+		row(w, "BALANCE", amount(wallet.CurrentBalance, currency))
+		row(w, "AVAILABLE", amount(wallet.AvailableBalance, currency))
+		row(w, "LIMIT PER TRANSACTION", limit(limits.PerTransaction, currency))
+		row(w, "LIMIT DAILY", fmt.Sprintf("%s (spent %s)", limit(limits.Daily, currency), amount(limits.DailySpent, currency)))
+		row(w, "LIMIT WEEKLY", fmt.Sprintf("%s (spent %s)", limit(limits.Weekly, currency), amount(limits.WeeklySpent, currency)))
+		if limits.Override != nil {
+			row(w, "LIMIT OVERRIDE", fmt.Sprintf("by %s at %s: %s", limits.Override.UpdatedBy, timestamp(limits.Override.UpdatedAt), limits.Override.Reason))
+		}
+
+		row(w)
+		row(w, "HOLD_ID", "STATUS", "AMOUNT", "CAPTURED", "EXPIRES_AT")
+		for _, hold := range inspection.Holds {
+			row(w, hold.HoldId, hold.Status, amount(hold.Amount, currency), amount(hold.CapturedAmount, currency), timestamp(hold.ExpiresAt))
+		}
+
+		row(w)
+		row(w, "CHANGED_AT", "FROM", "TO", "REASON", "ACTOR", "NOTE")
+		for _, change := range inspection.StatusChanges {
+			row(w, timestamp(change.CreatedAt), change.FromStatus, change.ToStatus, change.ReasonCode, change.Actor, orDash(change.Note))
+		}
+
+		row(w)
+		row(w, "ADJUSTED_AT", "KIND", "DIRECTION", "AMOUNT", "BEFORE", "AFTER", "ACTOR", "REASON")
+		for _, adjustment := range inspection.Adjustments {
+			row(w, timestamp(adjustment.CreatedAt), adjustment.Kind, adjustment.Direction, amount(adjustment.Amount, currency),
+				amount(adjustment.BalanceBefore, currency), amount(adjustment.BalanceAfter, currency), adjustment.Actor, adjustment.Reason)
+		}
+	})
+}
+
+func (c *walletCtl) trx(args []string) int {
+	flags := c.newFlagSet("trx")
+	output := outputFlag(flags)
+	limit := flags.Int("limit", 50, "transactions per page, at most 500")
+	all := flags.Bool("all", false, "follow the cursor to the last page")
+	cursor := flags.String("cursor", "", "continue from a previous page's next cursor")
+	var trxTypes stringList
+	flags.Var(&trxTypes, "type", "only this transaction type; repeatable")
+	from := flags.String("from", "", "RFC 3339 time, inclusive")
+	to := flags.String("to", "", "RFC 3339 time, exclusive")
+	counterparty := flags.String("counterparty", "", "only transactions with this wallet")
+	walletId, ok := c.parseWalletCommand(flags, args)
+	if !ok || !c.validOutput(*output) {
+		return exitUsage
+	}
+	req := request.TrxHistoryReq{Cursor: *cursor, Limit: *limit, TrxTypes: trxTypes, CounterpartyWalletId: *counterparty}
+	var err error
+	if req.From, err = parseTime(*from); err != nil {
+		fmt.Fprintln(c.stderr, "invalid -from:", err)
+		return exitUsage
+	}
+	if req.To, err = parseTime(*to); err != nil {
+		fmt.Fprintln(c.stderr, "invalid -to:", err)
+		return exitUsage
+	}
+
+	principal := admin(defaultActor())
+	history := response.TrxHistoryResponse{Transactions: []response.TransactionResponse{}}
+	for {
+		res := c.service.GetTransactions(context.Background(), principal, walletId, req)
+		if res.Err.Code != 0 {
+			fmt.Fprintln(c.stderr, "trx failed:", res.Err.Message)
+			return exitFailed
+		}
+		page := res.Data.(response.TrxHistoryResponse)
+		history.Transactions = append(history.Transactions, page.Transactions...)
+		history.NextCursor = page.NextCursor
+		if !*all || page.NextCursor == "" {
+			break
+		}
+		req.Cursor = page.NextCursor
+	}
+
+	return c.print(*output, history, func(w io.Writer) {
+		row(w, "CREATED_AT", "TRANSACTION_ID", "TYPE", "AMOUNT", "CURRENCY", "COUNTERPARTY", "GROUP_ID")
+		for _, trx := range history.Transactions {
+			row(w, timestamp(trx.CreatedAt), trx.TransactionId, trx.TrxType, amount(trx.Amount, trx.Currency), trx.Currency, orDash(trx.CounterpartyWalletId), orDash(trx.GroupId))
+		}
+		if history.NextCursor != "" {
+			row(w)
+			row(w, "next page: -cursor "+history.NextCursor)
+		}
+	})
+}
+
+func (c *walletCtl) adjust(args []string) int {
+	flags := c.newFlagSet("adjust")
+	output := outputFlag(flags)
+	actor := actorFlag(flags)
+	credit := flags.Uint("credit", 0, "raise the balance by this many minor units")
+	debit := flags.Uint("debit", 0, "lower the balance by this many minor units")
+	reason := flags.String("reason", "", "why the balance is adjusted; required")
+	walletId, ok := c.parseWalletCommand(flags, args)
+	if !ok || !c.validOutput(*output) {
+		return exitUsage
+	}
+	if (*credit == 0) == (*debit == 0) || *reason == "" {
+		fmt.Fprintln(c.stderr, "adjust needs one of -credit or -debit, and -reason")
+		return exitUsage
+	}
+	req := request.BalanceAdjustmentReq{Direction: string(common.EntryDirectionCredit), Amount: *credit, Reason: *reason}
+	if *debit > 0 {
+		req.Direction, req.Amount = string(common.EntryDirectionDebit), *debit
+	}
+
+	res := c.service.AdjustBalance(context.Background(), admin(*actor), walletId, req)
+	if res.Err.Code != 0 {
+		fmt.Fprintln(c.stderr, "adjust failed:", res.Err.Message)
+		return exitFailed
+	}
+	adjustment := res.Data.(response.BalanceAdjustmentResponse)
+	return c.print(*output, adjustment, func(w io.Writer) {
+		row(w, "ADJUSTMENT", adjustment.AdjustmentId)
+		row(w, "WALLET", adjustment.WalletId)
+		row(w, "DIRECTION", adjustment.Direction)
+		row(w, "AMOUNT", amount(adjustment.Amount, adjustment.Currency)+" "+adjustment.Currency)
+		row(w, "BALANCE", amount(adjustment.BalanceBefore, adjustment.Currency)+" -> "+amount(adjustment.BalanceAfter, adjustment.Currency))
+		row(w, "TRANSACTION", adjustment.TransactionId)
+		row(w, "ACTOR", adjustment.Actor)
+		row(w, "REASON", adjustment.Reason)
+	})
+}
+
+func (c *walletCtl) freeze(args []string) int {
+	flags := c.newFlagSet("freeze")
+	debitsOnly := flags.Bool("debits-only", false, "keep accepting money in, only stop money going out")
+	reason := flags.String("reason", "", "reason code: compliance_review, suspected_fraud, legal_order, customer_request, dormant or other; required")
+	return c.changeStatus(flags, args, reason, func() common.WalletStatus {
+		if *debitsOnly {
+			return common.WalletStatusFrozenDebits
+		}
+		return common.WalletStatusFrozen
+	})
+}
+
+func (c *walletCtl) unfreeze(args []string) int {
+	flags := c.newFlagSet("unfreeze")
+	reason := flags.String("reason", string(common.WalletStatusReasonReviewCleared), "reason code")
+	return c.changeStatus(flags, args, reason, func() common.WalletStatus { return common.WalletStatusActive })
+}
+
+// changeStatus runs freeze and unfreeze; status is only read once the flags are parsed.
+func (c *walletCtl) changeStatus(flags *flag.FlagSet, args []string, reason *string, status func() common.WalletStatus) int {
+	output := outputFlag(flags)
+	actor := actorFlag(flags)
+	note := flags.String("note", "", "free text for the audit trail; required with reason other")
+	walletId, ok := c.parseWalletCommand(flags, args)
+	if !ok || !c.validOutput(*output) {
+		return exitUsage
+	}
+	if *reason == "" {
+		fmt.Fprintf(c.stderr, "%s needs -reason\n", flags.Name())
+		return exitUsage
+	}
+
+	req := request.WalletStatusReq{Status: string(status()), ReasonCode: *reason, Note: *note}
+	res := c.service.ChangeWalletStatus(context.Background(), admin(*actor), walletId, req)
+	if res.Err.Code != 0 {
+		fmt.Fprintf(c.stderr, "%s failed: %s\n", flags.Name(), res.Err.Message)
+		return exitFailed
+	}
+	change := res.Data.(response.WalletStatusChangeResponse)
+	return c.print(*output, change, func(w io.Writer) {
+		row(w, "WALLET", change.WalletId)
+		row(w, "STATUS", fmt.Sprintf("%s -> %s", change.FromStatus, change.ToStatus))
+		row(w, "REASON", change.ReasonCode)
+		row(w, "NOTE", orDash(change.Note))
+		row(w, "ACTOR", change.Actor)
+		row(w, "CHANGED_AT", timestamp(change.CreatedAt))
+	})
+}
+
+func (c *walletCtl) reconcile(args []string) int {
+	flags := c.newFlagSet("reconcile")
+	output := outputFlag(flags)
+	actor := actorFlag(flags)
+	repair := flags.Bool("repair", false, "rebuild drifted balances from their transactions")
+	reason := flags.String("reason", "", "reason recorded on the adjustment of a repair")
+	walletIds, err := parse(flags, args)
+	if err != nil || !c.validOutput(*output) {
+		return exitUsage
+	}
+
+	res := c.service.ReconcileWallets(context.Background(), admin(*actor), request.ReconcileReq{WalletIds: walletIds, Repair: *repair, Reason: *reason})
+	if res.Err.Code != 0 {
+		fmt.Fprintln(c.stderr, "reconcile failed:", res.Err.Message)
+		return exitFailed
+	}
+	report := res.Data.(response.ReconciliationReport)
+	if code := c.print(*output, report, func(w io.Writer) {
+		row(w, fmt.Sprintf("checked %d wallets, %d drifted, %d repaired", report.WalletsChecked, len(report.Drifts), report.Repaired))
+		if len(report.Drifts) == 0 {
+			return
+		}
+		row(w)
+		row(w, "WALLET", "CURRENCY", "BALANCE", "REPLAYED", "DELTA", "REPAIRED", "ADJUSTMENT")
+		for _, drift := range report.Drifts {
+			row(w, drift.WalletId, drift.Currency, amount(drift.Balance, drift.Currency), signedAmount(drift.ReplayedBalance, drift.Currency),
+				signedAmount(drift.Delta, drift.Currency), drift.Repaired, orDash(drift.AdjustmentId))
+		}
+	}); code != exitOk {
+		return code
+	}
+	if len(report.Drifts) > report.Repaired {
+		return exitDrifted
+	}
+	return exitOk
+}
+
+func (c *walletCtl) export(args []string) int {
+	flags := c.newFlagSet("export")
+	from := flags.String("from", "", "RFC 3339 time, inclusive; required")
+	to := flags.String("to", "", "RFC 3339 time, exclusive; defaults to now")
+	format := flags.String("format", "csv", "csv, ndjson or camt053")
+	out := flags.String("out", "", "file to write; defaults to stdout")
+	walletId, ok := c.parseWalletCommand(flags, args)
+	if !ok {
+		return exitUsage
+	}
+	req := request.StatementReq{Format: *format}
+	var err error
+	if req.From, err = parseTime(*from); err != nil || req.From == nil {
+		fmt.Fprintln(c.stderr, "export needs -from as an RFC 3339 time")
+		return exitUsage
+	}
+	if req.To, err = parseTime(*to); err != nil {
+		fmt.Fprintln(c.stderr, "invalid -to:", err)
+		return exitUsage
+	}
+
+	writer := c.stdout
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintln(c.stderr, "export failed:", err)
+			return exitFailed
+		}
+		defer file.Close()
+		writer = file
+	}
+	res := c.service.ExportStatement(context.Background(), admin(defaultActor()), walletId, req, writer)
+	if res.Err.Code != 0 {
+		fmt.Fprintln(c.stderr, "export failed:", res.Err.Message)
+		if *out != "" {
+			os.Remove(*out)
+		}
+		return exitFailed
+	}
+	return exitOk
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
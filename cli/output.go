@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"wallet-app/common"
+)
+
+const (
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+func (c *walletCtl) validOutput(output string) bool {
+	if output != outputTable && output != outputJson {
+		fmt.Fprintf(c.stderr, "unknown output %q; use table or json\n", output)
+		return false
+	}
+	return true
+}
+
+// print writes data as indented json, or lets table write it through a tabwriter.
+func (c *walletCtl) print(output string, data any, table func(w io.Writer)) int {
+	if output == outputJson {
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			fmt.Fprintln(c.stderr, "writing output failed:", err)
+			return exitFailed
+		}
+		return exitOk
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	if err := w.Flush(); err != nil {
+		fmt.Fprintln(c.stderr, "writing output failed:", err)
+		return exitFailed
+	}
+	return exitOk
+}
+
+// row writes one tab-separated line.
+func row(w io.Writer, cells ...any) {
+	values := make([]string, len(cells))
+	for i, cell := range cells {
+		values[i] = fmt.Sprint(cell)
+	}
+	fmt.Fprintln(w, strings.Join(values, "\t"))
+}
+
+func amount(minor uint, currency string) string {
+	return common.FormatAmount(int64(minor), currency)
+}
+
+func signedAmount(minor int64, currency string) string {
+	return common.FormatAmount(minor, currency)
+}
+
+// limit shows a spending limit, where 0 means there is none.
+func limit(minor uint, currency string) string {
+	if minor == 0 {
+		return "none"
+	}
+	return amount(minor, currency)
+}
+
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
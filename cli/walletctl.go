@@ -0,0 +1,119 @@
+// Package cli implements walletctl, the operator's command-line tool. Every command goes through
+// service.IWalletService as an admin, so it is checked and audited like the admin APIs.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"wallet-app/auth"
+	"wallet-app/service"
+)
+
+const (
+	exitOk      = 0
+	exitFailed  = 1
+	exitUsage   = 2
+	exitDrifted = 3 // reconcile found drift and left it unrepaired
+)
+
+const usage = `usage: walletctl <command> [flags] [args]
+
+commands:
+  inspect <walletId>     wallet, holds, spending limits, status history and adjustments
+  trx <walletId>         transactions, newest first
+  adjust <walletId>      post an audited manual balance adjustment
+  freeze <walletId>      freeze a wallet, or only its debits
+  unfreeze <walletId>    make a frozen wallet active again
+  reconcile [walletId..] check balances against transactions, optionally repair them
+  export <walletId>      write a statement in csv, ndjson or camt053
+
+Every command but export takes -o table|json. Run walletctl <command> -h for its flags.
+`
+
+type walletCtl struct {
+	service service.IWalletService
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// Run executes one command and returns the process exit code: 0 on success, 1 when the service
+// refuses or fails, 2 on bad usage and 3 when reconcile leaves drift unrepaired.
+func Run(walletService service.IWalletService, args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	ctl := &walletCtl{service: walletService, stdout: stdout, stderr: stderr}
+	commands := map[string]func([]string) int{
+		"inspect":   ctl.inspect,
+		"trx":       ctl.trx,
+		"adjust":    ctl.adjust,
+		"freeze":    ctl.freeze,
+		"unfreeze":  ctl.unfreeze,
+		"reconcile": ctl.reconcile,
+		"export":    ctl.export,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	return command(args[1:])
+}
+
+func (c *walletCtl) newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(c.stderr)
+	return flags
+}
+
+// parse accepts flags before and after the positional arguments, e.g. `trx wallet_1 -o json`.
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseWalletCommand parses a command that takes exactly one wallet id.
+func (c *walletCtl) parseWalletCommand(flags *flag.FlagSet, args []string) (string, bool) {
+	positional, err := parse(flags, args)
+	if err != nil {
+		return "", false
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(c.stderr, "%s takes one wallet id\n", flags.Name())
+		return "", false
+	}
+	return positional[0], true
+}
+
+func outputFlag(flags *flag.FlagSet) *string {
+	return flags.String("o", "table", "output format: table or json")
+}
+
+func actorFlag(flags *flag.FlagSet) *string {
+	return flags.String("actor", defaultActor(), "who is running the command, recorded in the audit trail")
+}
+
+// defaultActor names the operator from the environment, so audit rows never read just "system".
+func defaultActor() string {
+	if user := os.Getenv("USER"); user != "" {
+		return "walletctl:" + user
+	}
+	return "walletctl"
+}
+
+func admin(actor string) auth.Principal {
+	return auth.Principal{UserId: actor, Roles: []string{auth.RoleAdmin}}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"wallet-app/cli"
+	"wallet-app/config"
+	"wallet-app/db"
+	"wallet-app/fx"
+	"wallet-app/manager"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// walletctl is run from the repository root, next to ./config, against the same database as the server.
+func main() {
+	log := logrus.New()
+	log.SetLevel(logrus.WarnLevel) // keep service logs out of the way of the output
+
+	appConfig, err := config.LoadConfig()
+	if err != nil {
+		log.Error("Err loading config; ", err)
+		os.Exit(1)
+	}
+
+	db, err := db.InitDb(&appConfig.Database)
+	if err != nil {
+		log.Error("Err connecting to db; ", err)
+		os.Exit(1)
+	}
+
+	fxProvider, err := fx.NewStaticFxProvider(appConfig.Fx.RatesFile)
+	if err != nil {
+		log.Error("Err loading fx rates; ", err)
+		os.Exit(1)
+	}
+
+	dbTxManager := manager.NewRetryingDbTxManager(log, appConfig.Database, manager.NewDbTxManager(db))
+	walletRepo := repo.NewWalletRepo(db)
+	transactionRepo := repo.NewTransactionRepo(db)
+	ledgerRepo := repo.NewLedgerRepo(db)
+	idempotencyRepo := repo.NewIdempotencyRepo(db)
+	fxQuoteRepo := repo.NewFxQuoteRepo(db)
+	holdRepo := repo.NewHoldRepo(db)
+	outboxRepo := repo.NewOutboxRepo(db)
+	adjustmentRepo := repo.NewAdjustmentRepo(db)
+	spendingLimitRepo := repo.NewSpendingLimitRepo(db)
+	feeRuleRepo := repo.NewFeeRuleRepo(db)
+	mapper := mapper.NewAppMapper()
+	walletService := service.NewWalletService(log, appConfig, walletRepo, transactionRepo, ledgerRepo, idempotencyRepo, fxQuoteRepo, holdRepo, outboxRepo, adjustmentRepo, spendingLimitRepo, feeRuleRepo, fxProvider, mapper, dbTxManager)
+
+	os.Exit(cli.Run(walletService, os.Args[1:], os.Stdout, os.Stderr))
+}
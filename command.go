@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+	"wallet-app/auth"
+	"wallet-app/config"
+	appdb "wallet-app/db"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+)
+
+// runCommand runs a maintenance subcommand instead of the server and returns the process exit code:
+// 0 on success, 1 on failure, 2 on bad usage.
+func runCommand(walletService service.IWalletService, args []string) int {
+	switch args[0] {
+	case "reconcile":
+		return reconcileCommand(walletService, args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q; available commands: migrate, reconcile\n", args[0])
+	return 2
+}
+
+// reconcileCommand prints the reconciliation report as json. It exits with 3 when drift was found and
+// left unrepaired, so it can gate a cron job or a deploy.
+//
+//	wallet-app reconcile [-repair] [-reason text] [walletId...]
+func reconcileCommand(walletService service.IWalletService, args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "rebuild drifted balances from their transactions")
+	reason := flags.String("reason", "", "reason recorded on the adjustment of a repair")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	res := walletService.ReconcileWallets(context.Background(), auth.System, request.ReconcileReq{WalletIds: flags.Args(), Repair: *repair, Reason: *reason})
+	if res.Err.Code != 0 {
+		fmt.Fprintln(os.Stderr, "reconcile failed:", res.Err.Message)
+		return 1
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(res.Data)
+
+	report := res.Data.(response.ReconciliationReport)
+	if len(report.Drifts) > report.Repaired {
+		return 3
+	}
+	return 0
+}
+
+// migrateCommand runs before the app connects, since startup itself migrates up and refuses a newer schema.
+//
+//	wallet-app migrate up
+//	wallet-app migrate down [-steps 1]
+//	wallet-app migrate status
+func migrateCommand(cfg *config.DatabaseConfig, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: wallet-app migrate up|down|status")
+		return 2
+	}
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := flags.Int("steps", 1, "down only; how many applied migrations to revert")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	db, err := appdb.Open(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "connecting to db failed:", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := appdb.MigrateUp(db)
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate up failed:", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+	case "down":
+		if *steps < 1 {
+			fmt.Fprintln(os.Stderr, "-steps must be at least 1")
+			return 2
+		}
+		reverted, err := appdb.MigrateDown(db, *steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate down failed:", err)
+			return 1
+		}
+	case "status":
+		states, err := appdb.MigrationStatus(db)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "migrate status failed:", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED_AT")
+		for _, state := range states {
+			appliedAt := "pending"
+			if state.AppliedAt != nil {
+				appliedAt = state.AppliedAt.UTC().Format(time.RFC3339)
+			}
+			if state.Unknown {
+				appliedAt += " (unknown to this binary)"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", state.Version, state.Name, appliedAt)
+		}
+		w.Flush()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q; use up, down or status\n", args[0])
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,8 @@
+package common
+
+type AdjustmentKind string
+
+const (
+	AdjustmentKindReconciliation AdjustmentKind = "reconciliation" // balance rebuilt from the transactions
+	AdjustmentKindManual         AdjustmentKind = "manual"         // posted by an admin, with an adjustment transaction row
+)
@@ -0,0 +1,13 @@
+package common
+
+import "time"
+
+// Backoff is the wait before the retry that follows the given failed attempt (1-based):
+// base, then doubled after every attempt, capped at max.
+func Backoff(base time.Duration, max time.Duration, attempt int) time.Duration {
+	backoff := base
+	for i := 1; i < attempt && backoff < max; i++ {
+		backoff *= 2
+	}
+	return min(backoff, max)
+}
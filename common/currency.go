@@ -0,0 +1,59 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+const DefaultCurrency = "SGD"
+
+// ISO 4217 minor-unit exponents; amounts are always stored in minor units.
+var currencyExponents = map[string]int{
+	"AUD": 2,
+	"BHD": 3,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func IsSupportedCurrency(code string) bool {
+	_, ok := currencyExponents[code]
+	return ok
+}
+
+func CurrencyExponent(code string) int {
+	return currencyExponents[code]
+}
+
+// FormatAmount renders a minor-unit amount in major units, e.g. 12345 SGD as "123.45".
+func FormatAmount(amount int64, code string) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	exponent := CurrencyExponent(code)
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+	scale := int64(1)
+	for i := 0; i < exponent; i++ {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/scale, exponent, amount%scale)
+}
@@ -0,0 +1,113 @@
+package common
+
+import "errors"
+
+// FeeOperation is what a fee rule charges for. The fx rule applies on top of the transfer_out rule
+// when a transfer converts between currencies.
+type FeeOperation string
+
+const (
+	FeeOperationWithdrawal  FeeOperation = "withdrawal"
+	FeeOperationTransferOut FeeOperation = "transfer_out"
+	FeeOperationFx          FeeOperation = "fx"
+)
+
+func (o FeeOperation) IsValid() bool {
+	switch o {
+	case FeeOperationWithdrawal, FeeOperationTransferOut, FeeOperationFx:
+		return true
+	}
+	return false
+}
+
+type FeeKind string
+
+const (
+	FeeKindFlat       FeeKind = "flat"
+	FeeKindPercentage FeeKind = "percentage" // of the amount, bounded by MinAmount and MaxAmount
+	FeeKindTiered     FeeKind = "tiered"     // flat plus percentage of the tier the amount falls in
+)
+
+const feeBpsScale = 10000
+
+// FeeTier covers amounts up to and including UpTo; 0 means no upper bound.
+type FeeTier struct {
+	UpTo          uint
+	FlatAmount    uint
+	PercentageBps uint
+}
+
+// FeeSchedule is how a fee is computed from an amount. All amounts are in minor units of the rule's
+// currency, percentages in basis points (100 = 1%). Percentages are rounded up to the minor unit.
+type FeeSchedule struct {
+	Kind          FeeKind
+	FlatAmount    uint
+	PercentageBps uint
+	MinAmount     uint
+	MaxAmount     uint // 0 means no maximum
+	Tiers         []FeeTier
+}
+
+func (s FeeSchedule) Validate() error {
+	if s.MaxAmount > 0 && s.MinAmount > s.MaxAmount {
+		return errors.New("minAmount above maxAmount")
+	}
+	switch s.Kind {
+	case FeeKindFlat:
+		if s.FlatAmount == 0 {
+			return errors.New("flat fee needs flatAmount")
+		}
+	case FeeKindPercentage:
+		if s.PercentageBps == 0 || s.PercentageBps > feeBpsScale {
+			return errors.New("percentageBps must be between 1 and 10000")
+		}
+	case FeeKindTiered:
+		if len(s.Tiers) == 0 {
+			return errors.New("tiered fee needs tiers")
+		}
+		for i, tier := range s.Tiers {
+			last := i == len(s.Tiers)-1
+			if tier.PercentageBps > feeBpsScale {
+				return errors.New("percentageBps must be at most 10000")
+			}
+			if last != (tier.UpTo == 0) {
+				return errors.New("only the last tier is open-ended, and it must be")
+			}
+			if i > 0 && !last && tier.UpTo <= s.Tiers[i-1].UpTo {
+				return errors.New("tiers must be in increasing upTo order")
+			}
+		}
+	default:
+		return errors.New("unknown fee kind")
+	}
+	return nil
+}
+
+// Calculate returns the fee on amount. The schedule must be valid.
+func (s FeeSchedule) Calculate(amount uint) uint {
+	fee := uint64(0)
+	switch s.Kind {
+	case FeeKindFlat:
+		fee = uint64(s.FlatAmount)
+	case FeeKindPercentage:
+		fee = percentageOf(amount, s.PercentageBps)
+	case FeeKindTiered:
+		for _, tier := range s.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = uint64(tier.FlatAmount) + percentageOf(amount, tier.PercentageBps)
+				break
+			}
+		}
+	}
+	if fee < uint64(s.MinAmount) {
+		fee = uint64(s.MinAmount)
+	}
+	if s.MaxAmount > 0 && fee > uint64(s.MaxAmount) {
+		fee = uint64(s.MaxAmount)
+	}
+	return uint(fee)
+}
+
+func percentageOf(amount uint, bps uint) uint64 {
+	return (uint64(amount)*uint64(bps) + feeBpsScale - 1) / feeBpsScale
+}
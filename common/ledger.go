@@ -0,0 +1,26 @@
+package common
+
+type EntryDirection string
+
+const (
+	EntryDirectionDebit  EntryDirection = "debit"
+	EntryDirectionCredit EntryDirection = "credit"
+)
+
+type LedgerAccountType string
+
+const (
+	LedgerAccountTypeWallet LedgerAccountType = "wallet" // customer funds; credit-normal
+	LedgerAccountTypeSystem LedgerAccountType = "system" // one per kind and currency
+)
+
+type SystemAccount string
+
+const (
+	SystemAccountCashIn         SystemAccount = "cash_in"
+	SystemAccountCashOut        SystemAccount = "cash_out"
+	SystemAccountFx             SystemAccount = "fx"
+	SystemAccountOpeningBalance SystemAccount = "opening_balance"
+	SystemAccountReversalLoss   SystemAccount = "reversal_loss" // shortfall of forced reversals
+	SystemAccountAdjustment     SystemAccount = "adjustment"    // other side of audited balance adjustments
+)
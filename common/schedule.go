@@ -0,0 +1,80 @@
+package common
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleStatusActive    ScheduleStatus = "active"
+	ScheduleStatusPaused    ScheduleStatus = "paused"
+	ScheduleStatusCancelled ScheduleStatus = "cancelled"
+)
+
+type ScheduleRunOutcome string
+
+const (
+	ScheduleRunSucceeded         ScheduleRunOutcome = "succeeded"
+	ScheduleRunInsufficientFunds ScheduleRunOutcome = "insufficient_funds" // retried with backoff
+	ScheduleRunFailedRetrying    ScheduleRunOutcome = "failed_retrying"    // a server error; retried with backoff
+	ScheduleRunFailed            ScheduleRunOutcome = "failed"             // skipped; the schedule moves to its next occurrence
+)
+
+const MinScheduleInterval = time.Minute
+
+// ScheduleRule is either a standard 5-field cron expression (evaluated in UTC) or a fixed interval.
+type ScheduleRule struct {
+	Cron     string
+	Interval time.Duration
+
+	cronSchedule cron.Schedule
+}
+
+func ParseScheduleRule(cronExpr string, interval time.Duration) (ScheduleRule, error) {
+	if (cronExpr == "") == (interval == 0) {
+		return ScheduleRule{}, errors.New("exactly one of cron and interval is required")
+	}
+	if cronExpr == "" {
+		if interval < MinScheduleInterval {
+			return ScheduleRule{}, errors.New("interval is shorter than a minute")
+		}
+		return ScheduleRule{Interval: interval}, nil
+	}
+	cronSchedule, err := cron.ParseStandard(cronExpr)
+	if err != nil {
+		return ScheduleRule{}, err
+	}
+	return ScheduleRule{Cron: cronExpr, cronSchedule: cronSchedule}, nil
+}
+
+// First is the first occurrence of a schedule created at now, or starting at startAt when given.
+func (r ScheduleRule) First(now time.Time, startAt *time.Time) time.Time {
+	if startAt != nil && startAt.After(now) {
+		if r.cronSchedule != nil {
+			return r.cronSchedule.Next(startAt.UTC().Add(-time.Second))
+		}
+		return startAt.UTC()
+	}
+	return r.NextAfter(now, now)
+}
+
+// NextAfter returns the occurrence following previous. Occurrences missed while the app was down are
+// skipped, so the result is always after now.
+func (r ScheduleRule) NextAfter(previous time.Time, now time.Time) time.Time {
+	previous, now = previous.UTC(), now.UTC()
+	if r.cronSchedule != nil {
+		if previous.Before(now) {
+			previous = now
+		}
+		return r.cronSchedule.Next(previous)
+	}
+	next := previous.Add(r.Interval)
+	if !next.After(now) {
+		next = previous.Add((now.Sub(previous)/r.Interval + 1) * r.Interval)
+	}
+	return next
+}
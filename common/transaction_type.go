@@ -7,4 +7,39 @@ const (
 	TrxTypeWithdrawal  TrxType = "withdrawal"
 	TrxTypeTransferIn  TrxType = "transfer_in"
 	TrxTypeTransferOut TrxType = "transfer_out"
+
+	// A reversal debits the wallet that received a transfer and credits the sender.
+	TrxTypeReversalOut TrxType = "reversal_out"
+	TrxTypeReversalIn  TrxType = "reversal_in"
+
+	// A fee is charged on top of a withdrawal or transfer and credited to the house revenue wallet.
+	TrxTypeFee   TrxType = "fee"
+	TrxTypeFeeIn TrxType = "fee_in"
+
+	// Adjustment rows are manual corrections by an admin, audited in balance_adjustments.
+	TrxTypeAdjustmentIn  TrxType = "adjustment_in"
+	TrxTypeAdjustmentOut TrxType = "adjustment_out"
+
+	// Hold rows record reserving and releasing funds; they do not move the balance.
+	TrxTypeHold        TrxType = "hold"
+	TrxTypeHoldRelease TrxType = "hold_release"
 )
+
+func (t TrxType) IsValid() bool {
+	switch t {
+	case TrxTypeDeposit, TrxTypeWithdrawal, TrxTypeTransferIn, TrxTypeTransferOut, TrxTypeReversalOut, TrxTypeReversalIn, TrxTypeFee, TrxTypeFeeIn, TrxTypeAdjustmentIn, TrxTypeAdjustmentOut, TrxTypeHold, TrxTypeHoldRelease:
+		return true
+	}
+	return false
+}
+
+// Sign is the effect a row of this type has on its wallet's balance: +1, -1 or 0.
+func (t TrxType) Sign() int {
+	switch t {
+	case TrxTypeDeposit, TrxTypeTransferIn, TrxTypeReversalIn, TrxTypeFeeIn, TrxTypeAdjustmentIn:
+		return 1
+	case TrxTypeWithdrawal, TrxTypeTransferOut, TrxTypeReversalOut, TrxTypeFee, TrxTypeAdjustmentOut:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package common
+
+type WalletStatus string
+
+const (
+	WalletStatusActive       WalletStatus = "active"
+	WalletStatusFrozenDebits WalletStatus = "frozen_debits" // deposits and incoming transfers still land
+	WalletStatusFrozen       WalletStatus = "frozen"        // no money moves in or out
+	WalletStatusClosed       WalletStatus = "closed"        // final; the balance was zero or swept out
+)
+
+// walletStatusTransitions lists where each status can move to; closed is final.
+var walletStatusTransitions = map[WalletStatus][]WalletStatus{
+	WalletStatusActive:       {WalletStatusFrozenDebits, WalletStatusFrozen, WalletStatusClosed},
+	WalletStatusFrozenDebits: {WalletStatusActive, WalletStatusFrozen, WalletStatusClosed},
+	WalletStatusFrozen:       {WalletStatusActive, WalletStatusFrozenDebits, WalletStatusClosed},
+}
+
+func (s WalletStatus) IsValid() bool {
+	switch s {
+	case WalletStatusActive, WalletStatusFrozenDebits, WalletStatusFrozen, WalletStatusClosed:
+		return true
+	}
+	return false
+}
+
+func (s WalletStatus) CanTransitionTo(next WalletStatus) bool {
+	for _, allowed := range walletStatusTransitions[s.orActive()] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s WalletStatus) AllowsDebit() bool {
+	return s.orActive() == WalletStatusActive
+}
+
+func (s WalletStatus) AllowsCredit() bool {
+	return s.orActive() == WalletStatusActive || s == WalletStatusFrozenDebits
+}
+
+// orActive reads an empty status, of a wallet built before it was saved, as active.
+func (s WalletStatus) orActive() WalletStatus {
+	if s == "" {
+		return WalletStatusActive
+	}
+	return s
+}
+
+// WalletStatusReason is the reason code an admin gives for a status change.
+type WalletStatusReason string
+
+const (
+	WalletStatusReasonComplianceReview WalletStatusReason = "compliance_review"
+	WalletStatusReasonSuspectedFraud   WalletStatusReason = "suspected_fraud"
+	WalletStatusReasonLegalOrder       WalletStatusReason = "legal_order"
+	WalletStatusReasonReviewCleared    WalletStatusReason = "review_cleared"
+	WalletStatusReasonCustomerRequest  WalletStatusReason = "customer_request"
+	WalletStatusReasonDormant          WalletStatusReason = "dormant"
+	WalletStatusReasonOther            WalletStatusReason = "other" // needs a note
+)
+
+func (r WalletStatusReason) IsValid() bool {
+	switch r {
+	case WalletStatusReasonComplianceReview, WalletStatusReasonSuspectedFraud, WalletStatusReasonLegalOrder, WalletStatusReasonReviewCleared,
+		WalletStatusReasonCustomerRequest, WalletStatusReasonDormant, WalletStatusReasonOther:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package common
+
+type EventType string
+
+const (
+	EventTypeDeposit    EventType = "trx.deposit"
+	EventTypeWithdrawal EventType = "trx.withdrawal"
+	EventTypeTransfer   EventType = "trx.transfer"
+	EventTypeReversal   EventType = "trx.reversal"
+	EventTypeAdjustment EventType = "trx.adjustment"
+)
+
+func (e EventType) IsValid() bool {
+	switch e {
+	case EventTypeDeposit, EventTypeWithdrawal, EventTypeTransfer, EventTypeReversal, EventTypeAdjustment:
+		return true
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"    // gave up after the last attempt; can be redelivered by hand
+	WebhookDeliveryDropped   WebhookDeliveryStatus = "dropped" // its subscription was inactive when it was due; can be redelivered by hand
+)
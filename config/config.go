@@ -1,19 +1,125 @@
 package config
 
+import "time"
+
 type AppConfig struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
+	Server         ServerConfig         `mapstructure:"server"`
+	Grpc           GrpcConfig           `mapstructure:"grpc"`
+	Database       DatabaseConfig       `mapstructure:"database"`
+	Idempotency    IdempotencyConfig    `mapstructure:"idempotency"`
+	Fx             FxConfig             `mapstructure:"fx"`
+	Auth           AuthConfig           `mapstructure:"auth"`
+	Holds          HoldsConfig          `mapstructure:"holds"`
+	Schedules      SchedulesConfig      `mapstructure:"schedules"`
+	Webhooks       WebhooksConfig       `mapstructure:"webhooks"`
+	Reconciliation ReconciliationConfig `mapstructure:"reconciliation"`
+	Limits         LimitsConfig         `mapstructure:"limits"`
+	Fees           FeesConfig           `mapstructure:"fees"`
+	Tracing        TracingConfig        `mapstructure:"tracing"`
+	Timeouts       TimeoutsConfig       `mapstructure:"timeouts"`
 }
 
 type ServerConfig struct {
 	Port int `mapstructure:"port"`
 }
 
+type GrpcConfig struct {
+	Port int `mapstructure:"port"` // 0 disables the gRPC server
+}
+
 type DatabaseConfig struct {
+	Driver   string `mapstructure:"driver"` // postgres or sqlite
+	Path     string `mapstructure:"path"`   // sqlite only; the database file
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	Name     string `mapstructure:"name"`
 	SSLMode  string `mapstructure:"sslmode"`
+	// transactions failed by a deadlock, serialization failure or busy lock are run again, up to MaxAttempts runs
+	MaxAttempts     int           `mapstructure:"maxAttempts"`
+	RetryBackoff    time.Duration `mapstructure:"retryBackoff"` // doubled after every failed attempt, then jittered
+	MaxRetryBackoff time.Duration `mapstructure:"maxRetryBackoff"`
+}
+
+type IdempotencyConfig struct {
+	KeyTtl time.Duration `mapstructure:"keyTtl"` // how long a stored Idempotency-Key is replayed
+}
+
+type FxConfig struct {
+	RatesFile string        `mapstructure:"ratesFile"` // used by the static fx provider
+	QuoteTtl  time.Duration `mapstructure:"quoteTtl"`
+}
+
+type HoldsConfig struct {
+	DefaultTtl    time.Duration `mapstructure:"defaultTtl"` // used when a hold request has no expiry
+	MaxTtl        time.Duration `mapstructure:"maxTtl"`
+	SweepInterval time.Duration `mapstructure:"sweepInterval"` // how often expired holds are released
+}
+
+type SchedulesConfig struct {
+	PollInterval    time.Duration `mapstructure:"pollInterval"`
+	LeaseTtl        time.Duration `mapstructure:"leaseTtl"`     // another instance takes over the scheduler after this
+	MaxAttempts     int           `mapstructure:"maxAttempts"`  // per occurrence, when funds are insufficient
+	RetryBackoff    time.Duration `mapstructure:"retryBackoff"` // doubled after every failed attempt
+	MaxRetryBackoff time.Duration `mapstructure:"maxRetryBackoff"`
+}
+
+type WebhooksConfig struct {
+	DispatchInterval time.Duration `mapstructure:"dispatchInterval"`
+	LeaseTtl         time.Duration `mapstructure:"leaseTtl"` // another instance takes over the dispatcher after this; longer than timeout
+	Timeout          time.Duration `mapstructure:"timeout"`  // per delivery attempt; no attempt starts that could outlast the lease
+	MaxAttempts      int           `mapstructure:"maxAttempts"`
+	RetryBackoff     time.Duration `mapstructure:"retryBackoff"` // doubled after every failed attempt
+	MaxRetryBackoff  time.Duration `mapstructure:"maxRetryBackoff"`
+}
+
+type ReconciliationConfig struct {
+	Interval time.Duration `mapstructure:"interval"` // 0 disables the scheduled job
+	LeaseTtl time.Duration `mapstructure:"leaseTtl"` // longer than interval, so one instance keeps the job
+	Repair   bool          `mapstructure:"repair"`   // whether the scheduled job repairs drift or only reports it
+}
+
+// LimitsConfig holds the default spending limits on withdrawals and transfers. Wallets without an
+// override use the entry for their currency in Currencies, or the top-level values.
+type LimitsConfig struct {
+	SpendingLimits `mapstructure:",squash"`
+	Currencies     map[string]SpendingLimits `mapstructure:"currencies"` // keyed by currency code; viper lower-cases the keys
+}
+
+type SpendingLimits struct {
+	PerTransaction uint `mapstructure:"perTransaction"` // minor units; 0 means no limit
+	Daily          uint `mapstructure:"daily"`          // rolling 24 hours
+	Weekly         uint `mapstructure:"weekly"`         // rolling 7 days
+}
+
+type FeesConfig struct {
+	RevenueWallets map[string]string `mapstructure:"revenueWallets"` // house wallet credited with fees, by currency; viper lower-cases the keys
+}
+
+type AuthConfig struct {
+	HmacSecret       string `mapstructure:"hmacSecret"`       // HS256 shared secret
+	RsaPublicKeyFile string `mapstructure:"rsaPublicKeyFile"` // RS256 public key (PEM); takes precedence over hmacSecret
+	Issuer           string `mapstructure:"issuer"`           // optional; checked against the iss claim
+}
+
+// TracingConfig chooses where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	Exporter     string  `mapstructure:"exporter"`     // none, stdout or otlp
+	OtlpEndpoint string  `mapstructure:"otlpEndpoint"` // host:port of an OTLP/HTTP collector
+	OtlpInsecure bool    `mapstructure:"otlpInsecure"` // plain http, e.g. a local collector
+	ServiceName  string  `mapstructure:"serviceName"`
+	SampleRatio  float64 `mapstructure:"sampleRatio"` // of traces started here; a sampled traceparent is always followed
+}
+
+// TimeoutsConfig bounds how long an http request may run, waits for wallet locks included. A request
+// past its deadline is rolled back and answered with a 504.
+type TimeoutsConfig struct {
+	Default   time.Duration     `mapstructure:"default"` // for routes not listed in Endpoints; 0 means no timeout
+	Endpoints []EndpointTimeout `mapstructure:"endpoints"`
+}
+
+type EndpointTimeout struct {
+	Route   string        `mapstructure:"route"` // method and gin route, e.g. "POST /wallets/:walletId/transfer"
+	Timeout time.Duration `mapstructure:"timeout"`
 }
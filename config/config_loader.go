@@ -9,6 +9,41 @@ func LoadConfig() (*AppConfig, error) {
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 
+	viper.SetDefault("database.driver", "postgres")
+	viper.SetDefault("database.path", "./wallet.db")
+	viper.SetDefault("database.maxAttempts", 5)
+	viper.SetDefault("database.retryBackoff", "20ms")
+	viper.SetDefault("database.maxRetryBackoff", "500ms")
+	viper.SetDefault("idempotency.keyTtl", "24h")
+	viper.SetDefault("fx.ratesFile", "./config/fx_rates.yaml")
+	viper.SetDefault("fx.quoteTtl", "60s")
+	viper.SetDefault("holds.defaultTtl", "168h")
+	viper.SetDefault("holds.maxTtl", "720h")
+	viper.SetDefault("holds.sweepInterval", "1m")
+	viper.SetDefault("schedules.pollInterval", "30s")
+	viper.SetDefault("schedules.leaseTtl", "2m")
+	viper.SetDefault("schedules.maxAttempts", 5)
+	viper.SetDefault("schedules.retryBackoff", "1m")
+	viper.SetDefault("schedules.maxRetryBackoff", "1h")
+	viper.SetDefault("webhooks.dispatchInterval", "5s")
+	viper.SetDefault("webhooks.leaseTtl", "1m")
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.maxAttempts", 10)
+	viper.SetDefault("webhooks.retryBackoff", "30s")
+	viper.SetDefault("webhooks.maxRetryBackoff", "6h")
+	viper.SetDefault("reconciliation.interval", "24h")
+	viper.SetDefault("reconciliation.leaseTtl", "25h")
+	viper.SetDefault("reconciliation.repair", false)
+	viper.SetDefault("limits.perTransaction", 0)
+	viper.SetDefault("limits.daily", 0)
+	viper.SetDefault("limits.weekly", 0)
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlpEndpoint", "localhost:4318")
+	viper.SetDefault("tracing.otlpInsecure", true)
+	viper.SetDefault("tracing.serviceName", "wallet-app")
+	viper.SetDefault("tracing.sampleRatio", 1.0)
+	viper.SetDefault("timeouts.default", "30s")
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"net/http"
+	"wallet-app/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerUiPage loads Swagger UI from a CDN and points it at /openapi.json.
+const swaggerUiPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Wallet App API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+func GetOpenApiSpec(c *gin.Context) {
+	c.JSON(http.StatusOK, openapi.Spec())
+}
+
+func GetSwaggerUi(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUiPage))
+}
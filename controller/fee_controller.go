@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"net/http"
+
+	"wallet-app/apperror"
+	"wallet-app/middleware"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type FeeController struct {
+	log     *logrus.Logger
+	service service.IFeeService
+}
+
+func NewFeeController(log *logrus.Logger, service service.IFeeService) *FeeController {
+	return &FeeController{log: log, service: service}
+}
+
+func (f *FeeController) CreateFeeRule(c *gin.Context) {
+	var req request.FeeRuleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		f.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := f.service.CreateFeeRule(middleware.GetPrincipal(c), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (f *FeeController) GetFeeRules(c *gin.Context) {
+	res := f.service.GetFeeRules(middleware.GetPrincipal(c))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (f *FeeController) GetFeeRule(c *gin.Context) {
+	res := f.service.GetFeeRule(middleware.GetPrincipal(c), c.Param("feeRuleId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (f *FeeController) UpdateFeeRule(c *gin.Context) {
+	var req request.FeeRuleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		f.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := f.service.UpdateFeeRule(middleware.GetPrincipal(c), c.Param("feeRuleId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (f *FeeController) DeleteFeeRule(c *gin.Context) {
+	res := f.service.DeleteFeeRule(middleware.GetPrincipal(c), c.Param("feeRuleId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
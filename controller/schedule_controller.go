@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"net/http"
+
+	"wallet-app/apperror"
+	"wallet-app/middleware"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ScheduleController struct {
+	log     *logrus.Logger
+	service service.IScheduleService
+}
+
+func NewScheduleController(log *logrus.Logger, service service.IScheduleService) *ScheduleController {
+	return &ScheduleController{log: log, service: service}
+}
+
+func (s *ScheduleController) CreateSchedule(c *gin.Context) {
+	var req request.CreateScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := s.service.CreateSchedule(middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *ScheduleController) GetSchedules(c *gin.Context) {
+	res := s.service.GetSchedules(middleware.GetPrincipal(c), c.Param("walletId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *ScheduleController) GetSchedule(c *gin.Context) {
+	res := s.service.GetSchedule(middleware.GetPrincipal(c), c.Param("walletId"), c.Param("scheduleId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *ScheduleController) UpdateSchedule(c *gin.Context) {
+	var req request.UpdateScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := s.service.UpdateSchedule(middleware.GetPrincipal(c), c.Param("walletId"), c.Param("scheduleId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *ScheduleController) CancelSchedule(c *gin.Context) {
+	res := s.service.CancelSchedule(middleware.GetPrincipal(c), c.Param("walletId"), c.Param("scheduleId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (s *ScheduleController) GetScheduleRuns(c *gin.Context) {
+	res := s.service.GetScheduleRuns(middleware.GetPrincipal(c), c.Param("walletId"), c.Param("scheduleId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
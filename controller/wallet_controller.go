@@ -1,17 +1,24 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"wallet-app/apperror"
+	"wallet-app/middleware"
 	"wallet-app/request"
 	"wallet-app/response"
 	"wallet-app/service"
+	"wallet-app/statement"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
+const idempotencyKeyHeader = "Idempotency-Key"
+const maxIdempotencyKeyLength = 255
+
 type WalletController struct {
 	log     *logrus.Logger
 	service service.IWalletService
@@ -29,7 +36,7 @@ func (w *WalletController) CreateWallet(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
 		return
 	}
-	res := w.service.CreateWallet(req)
+	res := w.service.CreateWallet(c.Request.Context(), middleware.GetPrincipal(c), req)
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -38,7 +45,11 @@ func (w *WalletController) CreateWallet(c *gin.Context) {
 }
 
 func (w *WalletController) GetWalletsByUserId(c *gin.Context) {
-	res := w.service.GetWalletsByUserId(c.Param("userId"))
+	res := w.service.GetWalletsByUserId(c.Request.Context(), middleware.GetPrincipal(c), c.Param("userId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
 
@@ -50,7 +61,11 @@ func (w *WalletController) DepositMoney(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
 		return
 	}
-	res := w.service.DepositMoney(c.Param("walletId"), req)
+	idempotencyKey, ok := w.idempotencyKey(c)
+	if !ok {
+		return
+	}
+	res := w.service.DepositMoney(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req, idempotencyKey)
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -66,7 +81,11 @@ func (w *WalletController) WithdrawMoney(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
 		return
 	}
-	res := w.service.WithdrawMoney(c.Param("walletId"), req)
+	idempotencyKey, ok := w.idempotencyKey(c)
+	if !ok {
+		return
+	}
+	res := w.service.WithdrawMoney(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req, idempotencyKey)
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -82,7 +101,47 @@ func (w *WalletController) TransferMoney(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
 		return
 	}
-	res := w.service.TransferMoney(c.Param("walletId"), req)
+	idempotencyKey, ok := w.idempotencyKey(c)
+	if !ok {
+		return
+	}
+	res := w.service.TransferMoney(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req, idempotencyKey)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) QuoteTransfer(c *gin.Context) {
+	var req request.TransferQuoteReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.QuoteTransfer(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) ReverseTransfer(c *gin.Context) {
+	var req request.ReverseTransferReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) { // the body is optional
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	idempotencyKey, ok := w.idempotencyKey(c)
+	if !ok {
+		return
+	}
+	res := w.service.ReverseTransfer(c.Request.Context(), middleware.GetPrincipal(c), c.Param("groupId"), req, idempotencyKey)
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -91,7 +150,7 @@ func (w *WalletController) TransferMoney(c *gin.Context) {
 }
 
 func (w *WalletController) GetBalance(c *gin.Context) {
-	res := w.service.GetBalance(c.Param("walletId"))
+	res := w.service.GetBalance(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"))
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -100,7 +159,201 @@ func (w *WalletController) GetBalance(c *gin.Context) {
 }
 
 func (w *WalletController) GetTransactions(c *gin.Context) {
-	res := w.service.GetTransactions(c.Param("walletId"))
+	var req request.TrxHistoryReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.GetTransactions(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) ExportStatement(c *gin.Context) {
+	var req request.StatementReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	walletId := c.Param("walletId")
+	out := &statementResponseWriter{c: c, format: req.Format, walletId: walletId}
+	res := w.service.ExportStatement(c.Request.Context(), middleware.GetPrincipal(c), walletId, req, out)
+	if res.Err.Code != 0 {
+		if out.started {
+			// headers are gone, the client sees a truncated statement
+			w.log.Errorf("Statement aborted after streaming started; walletId:%s %v", walletId, res.Err.Message)
+			return
+		}
+		c.JSON(res.Err.Code, res)
+	}
+}
+
+// statementResponseWriter defers the attachment headers until the service writes its first byte,
+// so validation and authorization errors can still be answered as JSON.
+type statementResponseWriter struct {
+	c        *gin.Context
+	format   string
+	walletId string
+	started  bool
+}
+
+func (s *statementResponseWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		format := s.format
+		if format == "" {
+			format = statement.FormatCsv
+		}
+		s.c.Header("Content-Type", statement.ContentType(format))
+		s.c.Header("Content-Disposition", `attachment; filename="statement-`+s.walletId+"."+statement.FileExtension(format)+`"`)
+		s.c.Status(http.StatusOK)
+	}
+	n, err := s.c.Writer.Write(p)
+	s.c.Writer.Flush()
+	return n, err
+}
+
+func (w *WalletController) CreateHold(c *gin.Context) {
+	var req request.CreateHoldReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.CreateHold(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) GetHolds(c *gin.Context) {
+	res := w.service.GetHolds(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) CaptureHold(c *gin.Context) {
+	var req request.CaptureHoldReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) { // the body is optional
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.CaptureHold(c.Request.Context(), middleware.GetPrincipal(c), c.Param("holdId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) ReleaseHold(c *gin.Context) {
+	res := w.service.ReleaseHold(c.Request.Context(), middleware.GetPrincipal(c), c.Param("holdId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) ChangeWalletStatus(c *gin.Context) {
+	var req request.WalletStatusReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.ChangeWalletStatus(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) GetWalletStatusChanges(c *gin.Context) {
+	res := w.service.GetWalletStatusChanges(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) PreviewFees(c *gin.Context) {
+	var req request.FeePreviewReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.PreviewFees(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) GetSpendingLimits(c *gin.Context) {
+	res := w.service.GetSpendingLimits(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) SetSpendingLimits(c *gin.Context) {
+	var req request.SpendingLimitReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.SetSpendingLimits(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) DeleteSpendingLimits(c *gin.Context) {
+	res := w.service.DeleteSpendingLimits(c.Request.Context(), middleware.GetPrincipal(c), c.Param("walletId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WalletController) ReconcileWallets(c *gin.Context) {
+	var req request.ReconcileReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) { // the body is optional
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.ReconcileWallets(c.Request.Context(), middleware.GetPrincipal(c), req)
 	if res.Err.Code != 0 {
 		c.JSON(res.Err.Code, res)
 		return
@@ -109,6 +362,20 @@ func (w *WalletController) GetTransactions(c *gin.Context) {
 }
 
 func (w *WalletController) DeleteAll(c *gin.Context) {
-	res := w.service.DeleteAll()
+	res := w.service.DeleteAll(c.Request.Context(), middleware.GetPrincipal(c))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
 	c.JSON(http.StatusOK, res)
 }
+
+func (w *WalletController) idempotencyKey(c *gin.Context) (string, bool) {
+	key := c.GetHeader(idempotencyKeyHeader)
+	if len(key) > maxIdempotencyKeyLength {
+		w.log.Errorf("Idempotency key too long; length:%d", len(key))
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: apperror.ErrInvalidIdempotencyKey})
+		return "", false
+	}
+	return key, true
+}
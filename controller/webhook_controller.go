@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"net/http"
+
+	"wallet-app/apperror"
+	"wallet-app/middleware"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookController struct {
+	log     *logrus.Logger
+	service service.IWebhookService
+}
+
+func NewWebhookController(log *logrus.Logger, service service.IWebhookService) *WebhookController {
+	return &WebhookController{log: log, service: service}
+}
+
+func (w *WebhookController) CreateWebhookSubscription(c *gin.Context) {
+	var req request.CreateWebhookSubscriptionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.CreateWebhookSubscription(middleware.GetPrincipal(c), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) GetWebhookSubscriptions(c *gin.Context) {
+	res := w.service.GetWebhookSubscriptions(middleware.GetPrincipal(c))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) GetWebhookSubscription(c *gin.Context) {
+	res := w.service.GetWebhookSubscription(middleware.GetPrincipal(c), c.Param("subscriptionId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) UpdateWebhookSubscription(c *gin.Context) {
+	var req request.UpdateWebhookSubscriptionReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		w.log.Error("Err ", err.Error())
+		appError := apperror.AppError{Code: 400, Message: err.Error()}
+		c.JSON(http.StatusBadRequest, response.ResonseWrapper{Err: appError})
+		return
+	}
+	res := w.service.UpdateWebhookSubscription(middleware.GetPrincipal(c), c.Param("subscriptionId"), req)
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) DeleteWebhookSubscription(c *gin.Context) {
+	res := w.service.DeleteWebhookSubscription(middleware.GetPrincipal(c), c.Param("subscriptionId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) GetWebhookDeliveries(c *gin.Context) {
+	res := w.service.GetWebhookDeliveries(middleware.GetPrincipal(c), c.Param("subscriptionId"), c.Query("status"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (w *WebhookController) RedeliverWebhook(c *gin.Context) {
+	res := w.service.RedeliverWebhook(middleware.GetPrincipal(c), c.Param("deliveryId"))
+	if res.Err.Code != 0 {
+		c.JSON(res.Err.Code, res)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
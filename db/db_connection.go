@@ -1,35 +1,98 @@
 package db
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"wallet-app/config"
 	"wallet-app/entity"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 
 	"gorm.io/gorm"
 )
 
-func InitDb(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host,
-		cfg.Port,
-		cfg.User,
-		cfg.Password,
-		cfg.Name,
-		cfg.SSLMode,
-	)
-	fmt.Println(dsn)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+const (
+	DriverPostgres = "postgres"
+	DriverSqlite   = "sqlite"
+)
 
+// InitDb connects and applies pending migrations. It fails with ErrSchemaTooNew, before changing
+// anything, when the database was migrated by a newer binary.
+func InitDb(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := Open(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	db.AutoMigrate(&entity.WalletEntity{})
-	db.AutoMigrate(&entity.TrxEntity{})
+	if err := Migrate(db); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
+
+// Open connects to the database of the configured driver without migrating it.
+func Open(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	switch cfg.Driver {
+	case DriverPostgres, "":
+		dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host,
+			cfg.Port,
+			cfg.User,
+			cfg.Password,
+			cfg.Name,
+			cfg.SSLMode,
+		)
+		fmt.Println(dsn)
+		return gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	case DriverSqlite:
+		return OpenSqlite(cfg.Path)
+	}
+	return nil, fmt.Errorf("unknown database driver %q", cfg.Driver)
+}
+
+// OpenSqlite opens a sqlite database file, or any sqlite uri such as file:name?mode=memory&cache=shared.
+// SQLite has no row locks, so every transaction begins IMMEDIATE and takes the database write lock up
+// front; a transaction that would lock a wallet row therefore waits for the previous writer instead
+// of failing when it upgrades from reading to writing. Readers are not blocked in WAL mode.
+func OpenSqlite(path string) (*gorm.DB, error) {
+	if path == "" {
+		return nil, errors.New("database.path is required for sqlite")
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	dsn := path + separator + "_txlock=immediate&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	return gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+}
+
+// IsSqlite reports whether db runs on sqlite, where row locking clauses do not apply.
+func IsSqlite(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverSqlite
+}
+
+func Entities() []interface{} {
+	return []interface{}{
+		&entity.WalletEntity{},
+		&entity.TrxEntity{},
+		&entity.IdempotencyKeyEntity{},
+		&entity.FxQuoteEntity{},
+		&entity.LedgerAccountEntity{},
+		&entity.LedgerEntryEntity{},
+		&entity.HoldEntity{},
+		&entity.ScheduleEntity{},
+		&entity.ScheduleRunEntity{},
+		&entity.LeaseEntity{},
+		&entity.OutboxEventEntity{},
+		&entity.WebhookSubscriptionEntity{},
+		&entity.WebhookDeliveryEntity{},
+		&entity.BalanceAdjustmentEntity{},
+		&entity.SpendingLimitEntity{},
+		&entity.FeeRuleEntity{},
+		&entity.WalletStatusChangeEntity{},
+		&entity.SchemaMigrationEntity{},
+	}
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgLockNotAvailable     = "55P03"
+	pgUniqueViolation      = "23505"
+	sqliteBusy             = 5
+	sqliteLocked           = 6
+	sqlitePrimaryKey       = 1555 // SQLITE_CONSTRAINT_PRIMARYKEY
+	sqliteUnique           = 2067 // SQLITE_CONSTRAINT_UNIQUE
+)
+
+// IsRetryable reports whether err failed a transaction that may succeed when run again from the
+// start: a deadlock, a serialization failure or a lock that could not be taken.
+func IsRetryable(err error) bool {
+	return IsDeadlock(err) || IsSerializationFailure(err) || IsLockNotAvailable(err)
+}
+
+// IsDeadlock reports whether err means Postgres aborted the transaction to break a deadlock.
+func IsDeadlock(err error) bool {
+	return hasPgCode(err, pgDeadlockDetected)
+}
+
+// IsSerializationFailure reports whether err means Postgres could not serialize the transaction with
+// a concurrent one, under REPEATABLE READ or SERIALIZABLE isolation.
+func IsSerializationFailure(err error) bool {
+	return hasPgCode(err, pgSerializationFailure)
+}
+
+// IsLockNotAvailable reports whether err means a lock could not be taken: a NOWAIT lock on a row that
+// another transaction holds, or a lock_timeout, on Postgres, or a busy or locked database on SQLite.
+func IsLockNotAvailable(err error) bool {
+	if hasPgCode(err, pgLockNotAvailable) {
+		return true
+	}
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		code := sqliteErr.Code() & 0xff // extended result codes keep the primary code in the low byte
+		return code == sqliteBusy || code == sqliteLocked
+	}
+	return false
+}
+
+// IsUniqueViolation reports whether err means an insert failed on a primary key or unique index that
+// already has the row's value.
+func IsUniqueViolation(err error) bool {
+	if hasPgCode(err, pgUniqueViolation) {
+		return true
+	}
+	var sqliteErr interface{ Code() int }
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code() == sqlitePrimaryKey || sqliteErr.Code() == sqliteUnique
+	}
+	return false
+}
+
+func hasPgCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
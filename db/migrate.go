@@ -0,0 +1,373 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+// Migrations are numbered SQL files, one directory per driver, e.g. migrations/postgres/0002_name.up.sql
+// with its 0002_name.down.sql. Both drivers must have the same versions. Statements end with a semicolon
+// at the end of a line. ALTER TABLE ... ADD COLUMN IF NOT EXISTS works on SQLite too, which skips the
+// statement when the column exists. A file starting with the line `-- migrate:no-transaction` runs outside
+// a transaction, which Postgres needs for CREATE INDEX CONCURRENTLY, though still under the migration lock.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// ErrSchemaTooNew is returned when the database was migrated by a newer binary than this one.
+var ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+const noTransactionMarker = "-- migrate:no-transaction"
+
+// migrationLockId serializes migrations of app instances starting together on Postgres.
+const migrationLockId = 7270533
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// addColumnIfNotExists matches ALTER TABLE ... ADD COLUMN IF NOT EXISTS, which SQLite lacks.
+var addColumnIfNotExists = regexp.MustCompile(`(?is)^\s*ALTER\s+TABLE\s+(\w+)\s+ADD\s+COLUMN\s+IF\s+NOT\s+EXISTS\s+(\w+)(.*)$`)
+
+// concurrentIndex finds the name of an index a migration builds with CREATE INDEX CONCURRENTLY.
+var concurrentIndex = regexp.MustCompile(`(?i)CREATE\s+(?:UNIQUE\s+)?INDEX\s+CONCURRENTLY\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationState struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time // nil while pending
+	Unknown   bool       // applied by a newer binary
+}
+
+// Migrate brings the schema up to the latest migration.
+func Migrate(db *gorm.DB) error {
+	_, err := MigrateUp(db)
+	return err
+}
+
+// Migrations returns the embedded migrations of a driver, oldest first.
+func Migrations(driver string) ([]Migration, error) {
+	dir := path.Join("migrations", driver)
+	files, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for driver %q", driver)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		match := migrationFileName.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", path.Join(dir, file.Name()))
+		}
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		version, _ := strconv.Atoi(match[1])
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names, %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs an up and a down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrateUp applies every pending migration in order and returns the ones it applied.
+func MigrateUp(db *gorm.DB) ([]Migration, error) {
+	migrations, applied, err := loadMigrationState(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSchemaVersion(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := runMigration(db, migration, true); err != nil {
+			return done, fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the latest steps applied migrations, newest first, and returns the ones it reverted.
+func MigrateDown(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, applied, err := loadMigrationState(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSchemaVersion(migrations, applied); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := runMigration(db, migration, false); err != nil {
+			return done, fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// MigrationStatus lists every known migration and any applied by a newer binary, oldest first.
+func MigrationStatus(db *gorm.DB) ([]MigrationState, error) {
+	migrations, applied, err := loadMigrationState(db)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]MigrationState, 0, len(migrations))
+	for _, migration := range migrations {
+		state := MigrationState{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			state.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		states = append(states, state)
+	}
+	for _, record := range applied {
+		states = append(states, MigrationState{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt, Unknown: true})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Version < states[j].Version })
+	return states, nil
+}
+
+// CheckSchemaVersion fails with ErrSchemaTooNew when the database has a migration this binary does not know.
+func CheckSchemaVersion(db *gorm.DB) error {
+	migrations, applied, err := loadMigrationState(db)
+	if err != nil {
+		return err
+	}
+	return checkSchemaVersion(migrations, applied)
+}
+
+func checkSchemaVersion(migrations []Migration, applied map[int]entity.SchemaMigrationEntity) error {
+	latest := 0
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("%w: database has migration %d, this binary knows up to %d", ErrSchemaTooNew, version, latest)
+		}
+	}
+	return nil
+}
+
+func loadMigrationState(db *gorm.DB) ([]Migration, map[int]entity.SchemaMigrationEntity, error) {
+	migrations, err := Migrations(db.Dialector.Name())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !db.Migrator().HasTable(&entity.SchemaMigrationEntity{}) {
+		if err := db.Migrator().CreateTable(&entity.SchemaMigrationEntity{}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var records []entity.SchemaMigrationEntity
+	if err := db.Find(&records).Error; err != nil {
+		return nil, nil, err
+	}
+	applied := make(map[int]entity.SchemaMigrationEntity, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return migrations, applied, nil
+}
+
+// runMigration executes the up or down file of a migration and records the result in the same
+// transaction, unless the file opts out of transactions. Either way it runs under the migration lock
+// and only if another instance did not run it while this one waited for the lock.
+func runMigration(db *gorm.DB, migration Migration, up bool) error {
+	sql := migration.Down
+	if up {
+		sql = migration.Up
+	}
+	run := func(tx *gorm.DB) error {
+		if err := execStatements(tx, sql); err != nil {
+			return err
+		}
+		if up {
+			return tx.Create(&entity.SchemaMigrationEntity{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		}
+		return tx.Delete(&entity.SchemaMigrationEntity{}, "version = ?", migration.Version).Error
+	}
+	if strings.HasPrefix(sql, noTransactionMarker) {
+		return runWithoutTransaction(db, migration, up, sql, run)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == DriverPostgres {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockId).Error; err != nil {
+				return err
+			}
+		}
+		if done, err := isMigrationDone(tx, migration, up); err != nil || done {
+			return err
+		}
+		return run(tx)
+	})
+}
+
+// runWithoutTransaction runs a no-transaction migration. On Postgres it holds the migration lock as a
+// session lock on one connection of the pool, which every statement of the migration then runs on.
+// A CREATE INDEX CONCURRENTLY that fails, or whose instance dies, leaves an INVALID index behind that
+// IF NOT EXISTS would take for done, so such indexes of the migration are dropped before it runs and
+// after it fails.
+func runWithoutTransaction(db *gorm.DB, migration Migration, up bool, sql string, run func(*gorm.DB) error) error {
+	if db.Dialector.Name() != DriverPostgres {
+		if done, err := isMigrationDone(db, migration, up); err != nil || done {
+			return err
+		}
+		return run(db)
+	}
+
+	ctx := context.Background()
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	connDb := db.WithContext(ctx)
+	connDb.Statement.ConnPool = conn
+
+	if err := connDb.Exec("SELECT pg_advisory_lock(?)", migrationLockId).Error; err != nil {
+		return err
+	}
+	defer connDb.Exec("SELECT pg_advisory_unlock(?)", migrationLockId)
+
+	if done, err := isMigrationDone(connDb, migration, up); err != nil || done {
+		return err
+	}
+	indexes := concurrentIndexNames(sql)
+	if err := dropInvalidIndexes(connDb, indexes); err != nil {
+		return err
+	}
+	if err := run(connDb); err != nil {
+		if dropErr := dropInvalidIndexes(connDb, indexes); dropErr != nil {
+			return fmt.Errorf("%w; dropping invalid indexes: %v", err, dropErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// isMigrationDone reports whether the migration is already applied, when going up, or already
+// reverted, when going down: another instance may have run it while this one waited for the lock.
+func isMigrationDone(db *gorm.DB, migration Migration, up bool) (bool, error) {
+	var count int64
+	if err := db.Model(&entity.SchemaMigrationEntity{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+		return false, err
+	}
+	applied := count > 0
+	return applied == up, nil
+}
+
+// concurrentIndexNames returns the indexes a migration builds with CREATE INDEX CONCURRENTLY.
+func concurrentIndexNames(sql string) []string {
+	var names []string
+	for _, match := range concurrentIndex.FindAllStringSubmatch(sql, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func dropInvalidIndexes(db *gorm.DB, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	var invalid []string
+	err := db.Raw(`SELECT c.relname FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid
+		WHERE NOT i.indisvalid AND c.relname IN ? AND pg_catalog.pg_table_is_visible(c.oid)`, names).Scan(&invalid).Error
+	if err != nil {
+		return err
+	}
+	for _, name := range invalid {
+		if err := db.Exec(fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %q", name)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execStatements(db *gorm.DB, sql string) error {
+	var statement strings.Builder
+	for _, line := range strings.Split(sql, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			if err := execStatement(db, statement.String()); err != nil {
+				return err
+			}
+			statement.Reset()
+		}
+	}
+	if strings.TrimSpace(statement.String()) != "" {
+		return fmt.Errorf("statement without a closing semicolon: %s", statement.String())
+	}
+	return nil
+}
+
+func execStatement(db *gorm.DB, statement string) error {
+	if match := addColumnIfNotExists.FindStringSubmatch(statement); match != nil && db.Dialector.Name() != DriverPostgres {
+		if db.Migrator().HasColumn(match[1], match[2]) {
+			return nil
+		}
+		statement = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s%s", match[1], match[2], match[3])
+	}
+	return db.Exec(statement).Error
+}
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS wallet_status_changes;
+DROP TABLE IF EXISTS fee_rules;
+DROP TABLE IF EXISTS spending_limits;
+DROP TABLE IF EXISTS balance_adjustments;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS outbox_events;
+DROP TABLE IF EXISTS leases;
+DROP TABLE IF EXISTS schedule_runs;
+DROP TABLE IF EXISTS schedules;
+DROP TABLE IF EXISTS holds;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+DROP TABLE IF EXISTS fx_quotes;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS wallets;
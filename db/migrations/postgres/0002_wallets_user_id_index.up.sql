@@ -0,0 +1,3 @@
+-- migrate:no-transaction
+-- Built concurrently so listing a user's wallets gets an index without blocking writes to wallets.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_wallets_user_id ON wallets (user_id);
@@ -0,0 +1,6 @@
+-- Of keys used by more than one caller, the latest is kept.
+DELETE FROM idempotency_keys older USING idempotency_keys newer
+WHERE older.idempotency_key = newer.idempotency_key AND (older.created_at, older.ctid) < (newer.created_at, newer.ctid);
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key);
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS user_id;
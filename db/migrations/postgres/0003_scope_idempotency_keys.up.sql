@@ -0,0 +1,8 @@
+-- Idempotency keys are scoped to the user who sent them and the wallet they were sent for, so keys of
+-- different callers no longer collide. Keys already stored are kept for the wallet's owner.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS user_id text;
+UPDATE idempotency_keys SET user_id = wallets.user_id FROM wallets WHERE wallets.id = idempotency_keys.wallet_id AND idempotency_keys.user_id IS NULL;
+UPDATE idempotency_keys SET user_id = '' WHERE user_id IS NULL;
+UPDATE idempotency_keys SET wallet_id = '' WHERE wallet_id IS NULL;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (user_id, wallet_id, idempotency_key);
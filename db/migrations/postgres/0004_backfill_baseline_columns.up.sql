@@ -0,0 +1,21 @@
+-- Databases AutoMigrate created before currencies, fx, holds, reversals, fees and wallet statuses
+-- have the baseline wallets and transactions tables, which 0001 leaves as they are. The columns
+-- added since are added here, and the rows already stored are SGD wallets that are active.
+
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS currency text DEFAULT 'SGD';
+ALTER TABLE wallets ADD COLUMN IF NOT EXISTS status text DEFAULT 'active';
+UPDATE wallets SET currency = 'SGD' WHERE currency IS NULL OR currency = '';
+UPDATE wallets SET status = 'active' WHERE status IS NULL OR status = '';
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency text DEFAULT 'SGD';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS counterparty_amount bigint;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS counterparty_currency text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rate text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_quote_id text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS hold_id text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversal_of text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversed_amount bigint;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fee bigint;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fee_of text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fee_rule_id text;
+UPDATE transactions SET currency = 'SGD' WHERE currency IS NULL OR currency = '';
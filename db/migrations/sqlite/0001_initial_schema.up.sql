@@ -0,0 +1,230 @@
+-- The schema as AutoMigrate created it before versioned migrations. IF NOT EXISTS lets databases
+-- created that way adopt this migration; 0004 adds the columns such databases may be missing.
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id text PRIMARY KEY,
+    user_id text,
+    balance integer,
+    currency text DEFAULT 'SGD',
+    status text DEFAULT 'active',
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id text PRIMARY KEY,
+    wallet_id text,
+    amount integer,
+    currency text DEFAULT 'SGD',
+    counterparty_wallet_id text,
+    counterparty_amount integer,
+    counterparty_currency text,
+    fx_rate text,
+    fx_quote_id text,
+    trx_type text,
+    group_id text,
+    hold_id text,
+    reversal_of text,
+    reversed_amount integer,
+    fee integer,
+    fee_of text,
+    fee_rule_id text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_group_id ON transactions (group_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_created ON transactions (wallet_id, created_at, id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key text PRIMARY KEY,
+    wallet_id text,
+    fingerprint text,
+    response text,
+    created_at datetime,
+    expires_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id text PRIMARY KEY,
+    wallet_id text,
+    counterparty_wallet_id text,
+    source_currency text,
+    source_amount integer,
+    destination_currency text,
+    destination_amount integer,
+    rate text,
+    expires_at datetime,
+    consumed_at datetime,
+    created_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id text PRIMARY KEY,
+    type text,
+    wallet_id text,
+    currency text,
+    created_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id text PRIMARY KEY,
+    journal_id text,
+    account_id text,
+    direction text,
+    amount integer,
+    currency text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_journal_id ON ledger_entries (journal_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account_id ON ledger_entries (account_id);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id text PRIMARY KEY,
+    wallet_id text,
+    amount integer,
+    captured_amount integer,
+    currency text,
+    status text,
+    trx_id text,
+    expires_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_holds_wallet_status ON holds (wallet_id, status);
+CREATE INDEX IF NOT EXISTS idx_holds_expires_at ON holds (expires_at);
+
+CREATE TABLE IF NOT EXISTS schedules (
+    id text PRIMARY KEY,
+    wallet_id text,
+    counterparty_wallet_id text,
+    amount integer,
+    currency text,
+    cron text,
+    interval_seconds integer,
+    status text,
+    next_run_at datetime,
+    attempt_at datetime,
+    attempt integer,
+    last_run_at datetime,
+    last_outcome text,
+    created_by text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_schedules_wallet_id ON schedules (wallet_id);
+CREATE INDEX IF NOT EXISTS idx_schedules_due ON schedules (status, attempt_at);
+
+CREATE TABLE IF NOT EXISTS schedule_runs (
+    id text PRIMARY KEY,
+    schedule_id text,
+    scheduled_for datetime,
+    attempt integer,
+    outcome text,
+    trx_id text,
+    error text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_schedule_runs_schedule_id ON schedule_runs (schedule_id);
+
+CREATE TABLE IF NOT EXISTS leases (
+    name text PRIMARY KEY,
+    holder text,
+    expires_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id text PRIMARY KEY,
+    event_type text,
+    wallet_id text,
+    payload text,
+    created_at datetime,
+    dispatched_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_dispatched_at ON outbox_events (dispatched_at);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id text PRIMARY KEY,
+    url text,
+    secret text,
+    event_types text,
+    active numeric,
+    created_by text,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id text PRIMARY KEY,
+    event_id text,
+    subscription_id text,
+    status text,
+    attempt integer,
+    next_attempt_at datetime,
+    last_status_code integer,
+    last_error text,
+    delivered_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS balance_adjustments (
+    id text PRIMARY KEY,
+    wallet_id text,
+    kind text,
+    direction text,
+    amount integer,
+    currency text,
+    balance_before integer,
+    balance_after integer,
+    reason text,
+    actor text,
+    journal_id text,
+    trx_id text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_balance_adjustments_wallet_id ON balance_adjustments (wallet_id);
+
+CREATE TABLE IF NOT EXISTS spending_limits (
+    wallet_id text PRIMARY KEY,
+    per_transaction integer,
+    daily integer,
+    weekly integer,
+    reason text,
+    updated_by text,
+    created_at datetime,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS fee_rules (
+    id text PRIMARY KEY,
+    operation text,
+    currency text,
+    kind text,
+    flat_amount integer,
+    percentage_bps integer,
+    min_amount integer,
+    max_amount integer,
+    tiers text,
+    active numeric,
+    created_by text,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_fee_rules_lookup ON fee_rules (operation, currency);
+
+CREATE TABLE IF NOT EXISTS wallet_status_changes (
+    id text PRIMARY KEY,
+    wallet_id text,
+    from_status text,
+    to_status text,
+    reason_code text,
+    note text,
+    actor text,
+    sweep_trx_id text,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_wallet_status_changes_wallet_id ON wallet_status_changes (wallet_id);
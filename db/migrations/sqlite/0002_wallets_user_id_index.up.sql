@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_wallets_user_id ON wallets (user_id);
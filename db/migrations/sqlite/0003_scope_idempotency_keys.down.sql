@@ -0,0 +1,15 @@
+-- Of keys used by more than one caller, the latest is kept.
+CREATE TABLE idempotency_keys_global (
+    idempotency_key text PRIMARY KEY,
+    wallet_id text,
+    fingerprint text,
+    response text,
+    created_at datetime,
+    expires_at datetime
+);
+INSERT OR REPLACE INTO idempotency_keys_global (idempotency_key, wallet_id, fingerprint, response, created_at, expires_at)
+SELECT idempotency_key, wallet_id, fingerprint, response, created_at, expires_at
+FROM idempotency_keys ORDER BY created_at;
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_global RENAME TO idempotency_keys;
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
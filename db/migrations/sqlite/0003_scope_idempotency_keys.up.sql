@@ -0,0 +1,18 @@
+-- SQLite cannot change a primary key, so the table is rebuilt. Keys already stored are kept for the
+-- wallet's owner.
+CREATE TABLE idempotency_keys_scoped (
+    user_id text NOT NULL,
+    wallet_id text NOT NULL,
+    idempotency_key text NOT NULL,
+    fingerprint text,
+    response text,
+    created_at datetime,
+    expires_at datetime,
+    PRIMARY KEY (user_id, wallet_id, idempotency_key)
+);
+INSERT INTO idempotency_keys_scoped (user_id, wallet_id, idempotency_key, fingerprint, response, created_at, expires_at)
+SELECT COALESCE((SELECT wallets.user_id FROM wallets WHERE wallets.id = idempotency_keys.wallet_id), ''), COALESCE(wallet_id, ''), idempotency_key, fingerprint, response, created_at, expires_at
+FROM idempotency_keys;
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_scoped RENAME TO idempotency_keys;
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
@@ -0,0 +1,2 @@
+-- The columns are part of the schema 0001 creates, so reverting this migration keeps them: dropping
+-- them would leave the schema of version 3 without them.
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// BalanceAdjustmentEntity is the audit record of a balance change made outside the normal money
+// operations, by reconciliation or by hand.
+type BalanceAdjustmentEntity struct {
+	ID            string                `gorm:"primaryKey;column:id"`
+	WalletId      string                `gorm:"column:wallet_id;index"`
+	Kind          common.AdjustmentKind `gorm:"column:kind"`
+	Direction     common.EntryDirection `gorm:"column:direction"` // credit raises the balance
+	Amount        uint                  `gorm:"column:amount"`
+	Currency      string                `gorm:"column:currency"`
+	BalanceBefore uint                  `gorm:"column:balance_before"`
+	BalanceAfter  uint                  `gorm:"column:balance_after"`
+	Reason        string                `gorm:"column:reason"`
+	Actor         string                `gorm:"column:actor"`      // user id of the admin, or system
+	JournalId     string                `gorm:"column:journal_id"` // ledger journal that moved the balance
+	TrxId         string                `gorm:"column:trx_id"`     // adjustment transaction row; manual adjustments only
+	CreatedAt     time.Time             `gorm:"column:created_at"`
+}
+
+func (BalanceAdjustmentEntity) TableName() string {
+	return "balance_adjustments"
+}
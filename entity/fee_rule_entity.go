@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// FeeRuleEntity is how much is charged for one operation in one currency. At most one rule per
+// operation and currency is active.
+type FeeRuleEntity struct {
+	ID            string              `gorm:"primaryKey;column:id"`
+	Operation     common.FeeOperation `gorm:"column:operation;index:idx_fee_rules_lookup,priority:1"`
+	Currency      string              `gorm:"column:currency;index:idx_fee_rules_lookup,priority:2"`
+	Kind          common.FeeKind      `gorm:"column:kind"`
+	FlatAmount    uint                `gorm:"column:flat_amount"`
+	PercentageBps uint                `gorm:"column:percentage_bps"`
+	MinAmount     uint                `gorm:"column:min_amount"`
+	MaxAmount     uint                `gorm:"column:max_amount"`
+	Tiers         string              `gorm:"column:tiers"` // JSON list of common.FeeTier
+	Active        bool                `gorm:"column:active"`
+	CreatedBy     string              `gorm:"column:created_by"`
+	CreatedAt     time.Time           `gorm:"column:created_at"`
+	UpdatedAt     time.Time           `gorm:"column:updated_at"`
+}
+
+func (FeeRuleEntity) TableName() string {
+	return "fee_rules"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type FxQuoteEntity struct {
+	ID                   string     `gorm:"primaryKey;column:id"`
+	WalletId             string     `gorm:"column:wallet_id"`
+	CounterpartyWalletId string     `gorm:"column:counterparty_wallet_id"`
+	SourceCurrency       string     `gorm:"column:source_currency"`
+	SourceAmount         uint       `gorm:"column:source_amount"`
+	DestinationCurrency  string     `gorm:"column:destination_currency"`
+	DestinationAmount    uint       `gorm:"column:destination_amount"`
+	Rate                 string     `gorm:"column:rate"`
+	ExpiresAt            time.Time  `gorm:"column:expires_at"`
+	ConsumedAt           *time.Time `gorm:"column:consumed_at"`
+	CreatedAt            time.Time  `gorm:"column:created_at"`
+}
+
+func (FxQuoteEntity) TableName() string {
+	return "fx_quotes"
+}
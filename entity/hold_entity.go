@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type HoldEntity struct {
+	ID             string            `gorm:"primaryKey;column:id"`
+	WalletId       string            `gorm:"column:wallet_id;index:idx_holds_wallet_status,priority:1"`
+	Amount         uint              `gorm:"column:amount"`          // amount reserved
+	CapturedAmount uint              `gorm:"column:captured_amount"` // set once captured; the rest was released
+	Currency       string            `gorm:"column:currency"`
+	Status         common.HoldStatus `gorm:"column:status;index:idx_holds_wallet_status,priority:2"`
+	TrxId          string            `gorm:"column:trx_id"` // withdrawal or transfer_out the hold was captured into
+	ExpiresAt      time.Time         `gorm:"column:expires_at;index"`
+	CreatedAt      time.Time         `gorm:"column:created_at"`
+	UpdatedAt      time.Time         `gorm:"column:updated_at"`
+}
+
+func (HoldEntity) TableName() string {
+	return "holds"
+}
+
+// IsActive reports whether the hold still reserves funds at now. Expired holds stop counting
+// against the available balance right away, even before the sweeper marks them expired.
+func (h HoldEntity) IsActive(now time.Time) bool {
+	return h.Status == common.HoldStatusActive && now.Before(h.ExpiresAt)
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// IdempotencyKeyEntity is the answer to the first request sent with a key. A key is scoped to the user
+// who sent it and the wallet it was sent for, so keys of different callers never collide.
+type IdempotencyKeyEntity struct {
+	UserId      string    `gorm:"primaryKey;column:user_id"`
+	WalletId    string    `gorm:"primaryKey;column:wallet_id"`
+	Key         string    `gorm:"primaryKey;column:idempotency_key"`
+	Fingerprint string    `gorm:"column:fingerprint"`
+	Response    string    `gorm:"column:response"` // TrxResponse as json
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	ExpiresAt   time.Time `gorm:"column:expires_at;index"`
+}
+
+func (IdempotencyKeyEntity) TableName() string {
+	return "idempotency_keys"
+}
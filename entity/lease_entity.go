@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// LeaseEntity lets one app instance at a time own a background job.
+type LeaseEntity struct {
+	Name      string    `gorm:"primaryKey;column:name"`
+	Holder    string    `gorm:"column:holder"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (LeaseEntity) TableName() string {
+	return "leases"
+}
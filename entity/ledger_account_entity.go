@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type LedgerAccountEntity struct {
+	ID        string                   `gorm:"primaryKey;column:id"`
+	Type      common.LedgerAccountType `gorm:"column:type"`
+	WalletId  string                   `gorm:"column:wallet_id"`
+	Currency  string                   `gorm:"column:currency"`
+	CreatedAt time.Time                `gorm:"column:created_at"`
+}
+
+func (LedgerAccountEntity) TableName() string {
+	return "ledger_accounts"
+}
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type LedgerEntryEntity struct {
+	ID        string                `gorm:"primaryKey;column:id"`
+	JournalId string                `gorm:"column:journal_id;index"` // trx id, or group id for transfers
+	AccountId string                `gorm:"column:account_id;index"`
+	Direction common.EntryDirection `gorm:"column:direction"`
+	Amount    uint                  `gorm:"column:amount"`
+	Currency  string                `gorm:"column:currency"`
+	CreatedAt time.Time             `gorm:"column:created_at"`
+}
+
+func (LedgerEntryEntity) TableName() string {
+	return "ledger_entries"
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// OutboxEventEntity is written in the same db transaction as the money movement it describes,
+// so an event exists if and only if the movement was committed.
+type OutboxEventEntity struct {
+	ID           string           `gorm:"primaryKey;column:id"`
+	EventType    common.EventType `gorm:"column:event_type"`
+	WalletId     string           `gorm:"column:wallet_id"` // wallet the operation was made on
+	Payload      string           `gorm:"column:payload"`   // WebhookEventPayload as json, sent as is
+	CreatedAt    time.Time        `gorm:"column:created_at"`
+	DispatchedAt *time.Time       `gorm:"column:dispatched_at;index"` // set once a delivery per subscription was created
+}
+
+func (OutboxEventEntity) TableName() string {
+	return "outbox_events"
+}
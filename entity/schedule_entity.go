@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// ScheduleEntity is a standing order: a transfer of Amount from WalletId to CounterpartyWalletId on every
+// occurrence of its rule (Cron or IntervalSeconds).
+type ScheduleEntity struct {
+	ID                   string                    `gorm:"primaryKey;column:id"`
+	WalletId             string                    `gorm:"column:wallet_id;index"`
+	CounterpartyWalletId string                    `gorm:"column:counterparty_wallet_id"`
+	Amount               uint                      `gorm:"column:amount"`
+	Currency             string                    `gorm:"column:currency"`
+	Cron                 string                    `gorm:"column:cron"`
+	IntervalSeconds      int64                     `gorm:"column:interval_seconds"`
+	Status               common.ScheduleStatus     `gorm:"column:status;index:idx_schedules_due,priority:1"`
+	NextRunAt            time.Time                 `gorm:"column:next_run_at"`                                   // the occurrence being worked on
+	AttemptAt            time.Time                 `gorm:"column:attempt_at;index:idx_schedules_due,priority:2"` // NextRunAt, or later while retrying
+	Attempt              int                       `gorm:"column:attempt"`                                       // failed attempts of the current occurrence
+	LastRunAt            *time.Time                `gorm:"column:last_run_at"`
+	LastOutcome          common.ScheduleRunOutcome `gorm:"column:last_outcome"`
+	CreatedBy            string                    `gorm:"column:created_by"`
+	CreatedAt            time.Time                 `gorm:"column:created_at"`
+	UpdatedAt            time.Time                 `gorm:"column:updated_at"`
+}
+
+func (ScheduleEntity) TableName() string {
+	return "schedules"
+}
+
+func (s ScheduleEntity) Rule() (common.ScheduleRule, error) {
+	return common.ParseScheduleRule(s.Cron, time.Duration(s.IntervalSeconds)*time.Second)
+}
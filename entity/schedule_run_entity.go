@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type ScheduleRunEntity struct {
+	ID           string                    `gorm:"primaryKey;column:id"`
+	ScheduleId   string                    `gorm:"column:schedule_id;index"`
+	ScheduledFor time.Time                 `gorm:"column:scheduled_for"`
+	Attempt      int                       `gorm:"column:attempt"`
+	Outcome      common.ScheduleRunOutcome `gorm:"column:outcome"`
+	TrxId        string                    `gorm:"column:trx_id"` // transfer_out row of a successful run
+	Error        string                    `gorm:"column:error"`
+	CreatedAt    time.Time                 `gorm:"column:created_at"`
+}
+
+func (ScheduleRunEntity) TableName() string {
+	return "schedule_runs"
+}
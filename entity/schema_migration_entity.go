@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// SchemaMigrationEntity records one applied migration from db/migrations.
+type SchemaMigrationEntity struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false;column:version"`
+	Name      string    `gorm:"column:name"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (SchemaMigrationEntity) TableName() string {
+	return "schema_migrations"
+}
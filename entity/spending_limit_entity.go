@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// SpendingLimitEntity overrides the configured spending limits of one wallet. A nil limit falls back
+// to the configured default, 0 means no limit.
+type SpendingLimitEntity struct {
+	WalletId       string    `gorm:"primaryKey;column:wallet_id"`
+	PerTransaction *uint     `gorm:"column:per_transaction"`
+	Daily          *uint     `gorm:"column:daily"`
+	Weekly         *uint     `gorm:"column:weekly"`
+	Reason         string    `gorm:"column:reason"`
+	UpdatedBy      string    `gorm:"column:updated_by"` // user id of the admin
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+}
+
+func (SpendingLimitEntity) TableName() string {
+	return "spending_limits"
+}
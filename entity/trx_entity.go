@@ -6,13 +6,24 @@ import (
 )
 
 type TrxEntity struct {
-	ID                   string         `gorm:"primaryKey;column:id"`
-	WalletId             string         `gorm:"column:wallet_id"`
+	ID                   string         `gorm:"primaryKey;column:id;index:idx_transactions_wallet_created,priority:3"`
+	WalletId             string         `gorm:"column:wallet_id;index:idx_transactions_wallet_created,priority:1"`
 	Amount               uint           `gorm:"column:amount"`
+	Currency             string         `gorm:"column:currency;default:SGD"`
 	CounterpartyWalletId string         `gorm:"column:counterparty_wallet_id"`
+	CounterpartyAmount   uint           `gorm:"column:counterparty_amount"`   // amount on the other leg of a transfer
+	CounterpartyCurrency string         `gorm:"column:counterparty_currency"` // currency on the other leg of a transfer
+	FxRate               string         `gorm:"column:fx_rate"`
+	FxQuoteId            string         `gorm:"column:fx_quote_id"`
 	TrxType              common.TrxType `gorm:"column:trx_type"`
-	GroupId              string         `gorm:"column:group_id"`
-	CreatedAt            time.Time      `gorm:"column:created_at"`
+	GroupId              string         `gorm:"column:group_id;index"`
+	HoldId               string         `gorm:"column:hold_id"`         // set on hold, hold_release and captured rows
+	ReversalOf           string         `gorm:"column:reversal_of"`     // on reversal rows, the GroupId of the reversed transfer
+	ReversedAmount       uint           `gorm:"column:reversed_amount"` // on transfer rows, how much has been reversed so far
+	Fee                  uint           `gorm:"column:fee"`             // on charged rows, the total fee posted on top as fee rows
+	FeeOf                string         `gorm:"column:fee_of"`          // on fee rows, the id of the charged row
+	FeeRuleId            string         `gorm:"column:fee_rule_id"`
+	CreatedAt            time.Time      `gorm:"column:created_at;index:idx_transactions_wallet_created,priority:2"`
 }
 
 func (TrxEntity) TableName() string {
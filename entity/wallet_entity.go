@@ -1,13 +1,18 @@
 package entity
 
-import "time"
+import (
+	"time"
+	"wallet-app/common"
+)
 
 type WalletEntity struct {
-	ID        string    `gorm:"primaryKey;column:id"`
-	UserId    string    `gorm:"column:user_id"`
-	Balance   uint      `gorm:"column:balance"`
-	CreatedAt time.Time `gorm:"column:created_at"`
-	UpdatedAt time.Time `gorm:"column:updated_at"`
+	ID        string              `gorm:"primaryKey;column:id"`
+	UserId    string              `gorm:"column:user_id;index"`
+	Balance   uint                `gorm:"column:balance"`
+	Currency  string              `gorm:"column:currency;default:SGD"`
+	Status    common.WalletStatus `gorm:"column:status;default:active"`
+	CreatedAt time.Time           `gorm:"column:created_at"`
+	UpdatedAt time.Time           `gorm:"column:updated_at"`
 }
 
 func (WalletEntity) TableName() string {
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// WalletStatusChangeEntity is the audit record of an admin moving a wallet between statuses.
+type WalletStatusChangeEntity struct {
+	ID         string                    `gorm:"primaryKey;column:id"`
+	WalletId   string                    `gorm:"column:wallet_id;index"`
+	FromStatus common.WalletStatus       `gorm:"column:from_status"`
+	ToStatus   common.WalletStatus       `gorm:"column:to_status"`
+	ReasonCode common.WalletStatusReason `gorm:"column:reason_code"`
+	Note       string                    `gorm:"column:note"`
+	Actor      string                    `gorm:"column:actor"`
+	SweepTrxId string                    `gorm:"column:sweep_trx_id"` // on closing, the transfer_out row that emptied the wallet
+	CreatedAt  time.Time                 `gorm:"column:created_at"`
+}
+
+func (WalletStatusChangeEntity) TableName() string {
+	return "wallet_status_changes"
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type WebhookDeliveryEntity struct {
+	ID             string                       `gorm:"primaryKey;column:id"`
+	EventId        string                       `gorm:"column:event_id;index"`
+	SubscriptionId string                       `gorm:"column:subscription_id;index"`
+	Status         common.WebhookDeliveryStatus `gorm:"column:status;index:idx_webhook_deliveries_due,priority:1"`
+	Attempt        int                          `gorm:"column:attempt"` // failed attempts so far
+	NextAttemptAt  time.Time                    `gorm:"column:next_attempt_at;index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int                          `gorm:"column:last_status_code"`
+	LastError      string                       `gorm:"column:last_error"`
+	DeliveredAt    *time.Time                   `gorm:"column:delivered_at"`
+	CreatedAt      time.Time                    `gorm:"column:created_at"`
+	UpdatedAt      time.Time                    `gorm:"column:updated_at"`
+}
+
+func (WebhookDeliveryEntity) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"wallet-app/common"
+)
+
+type WebhookSubscriptionEntity struct {
+	ID         string    `gorm:"primaryKey;column:id"`
+	Url        string    `gorm:"column:url"`
+	Secret     string    `gorm:"column:secret"`      // HMAC-SHA256 key for the signature header
+	EventTypes string    `gorm:"column:event_types"` // comma separated; empty means every event type
+	Active     bool      `gorm:"column:active"`
+	CreatedBy  string    `gorm:"column:created_by"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (WebhookSubscriptionEntity) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s WebhookSubscriptionEntity) EventTypeList() []string {
+	if s.EventTypes == "" {
+		return []string{}
+	}
+	return strings.Split(s.EventTypes, ",")
+}
+
+func (s WebhookSubscriptionEntity) Accepts(eventType common.EventType) bool {
+	return s.Active && (s.EventTypes == "" || slices.Contains(s.EventTypeList(), string(eventType)))
+}
@@ -0,0 +1,51 @@
+package fx
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// RateScale is the number of decimal places a rate is kept to once quoted.
+const RateScale = 10
+
+var ErrRateNotFound = errors.New("fx rate not found")
+
+// IFxProvider returns how many units of `to` one unit of `from` buys, both in major units.
+type IFxProvider interface {
+	GetRate(from string, to string) (*big.Rat, error)
+}
+
+// Convert turns a minor-unit amount into the destination currency's minor units, rounding down.
+func Convert(amount uint, rate *big.Rat, fromExponent int, toExponent int) uint {
+	value := new(big.Rat).Mul(new(big.Rat).SetUint64(uint64(amount)), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(toExponent-fromExponent))), nil))
+	if toExponent >= fromExponent {
+		value.Mul(value, scale)
+	} else {
+		value.Quo(value, scale)
+	}
+	return uint(new(big.Int).Quo(value.Num(), value.Denom()).Uint64())
+}
+
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(RateScale)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func ParseRate(s string) (*big.Rat, bool) {
+	return new(big.Rat).SetString(s)
+}
+
+func roundRate(rate *big.Rat) *big.Rat {
+	rounded, _ := ParseRate(rate.FloatString(RateScale))
+	return rounded
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
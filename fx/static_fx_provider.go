@@ -0,0 +1,54 @@
+package fx
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type staticRatesFile struct {
+	Rates map[string]string `yaml:"rates"` // "SGD/USD": "0.74"
+}
+
+// StaticFxProvider serves fixed rates from a yaml file, intended for local use and tests.
+type StaticFxProvider struct {
+	rates map[string]*big.Rat
+}
+
+func NewStaticFxProvider(path string) (IFxProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file staticRatesFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]*big.Rat, len(file.Rates))
+	for pair, value := range file.Rates {
+		rate, ok := ParseRate(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid fx rate; pair:%s rate:%s", pair, value)
+		}
+		rates[strings.ToUpper(pair)] = rate
+	}
+	return &StaticFxProvider{rates: rates}, nil
+}
+
+func (s *StaticFxProvider) GetRate(from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	if rate, ok := s.rates[from+"/"+to]; ok {
+		return roundRate(rate), nil
+	}
+	if rate, ok := s.rates[to+"/"+from]; ok {
+		return roundRate(new(big.Rat).Inv(rate)), nil
+	}
+	return nil, ErrRateNotFound
+}
@@ -0,0 +1,216 @@
+package grpcserver
+
+import (
+	"time"
+
+	"wallet-app/proto/walletpb"
+	"wallet-app/response"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toWallet(r response.WalletResponse) *walletpb.Wallet {
+	return &walletpb.Wallet{
+		WalletId:         r.WalletId,
+		UserId:           r.UserId,
+		CurrentBalance:   uint64(r.CurrentBalance),
+		AvailableBalance: uint64(r.AvailableBalance),
+		Currency:         r.Currency,
+		Exponent:         int32(r.Exponent),
+		Status:           string(r.Status),
+	}
+}
+
+func toTrx(r response.TrxResponse) *walletpb.Trx {
+	return &walletpb.Trx{
+		TransactionId:        r.TransactionId,
+		WalletId:             r.WalletId,
+		Amount:               uint64(r.Amount),
+		CurrentBalance:       uint64(r.CurrentBalance),
+		Fee:                  uint64(r.Fee),
+		Currency:             r.Currency,
+		Exponent:             int32(r.Exponent),
+		CounterpartyAmount:   uint64(r.CounterpartyAmount),
+		CounterpartyCurrency: r.CounterpartyCurrency,
+		FxRate:               r.FxRate,
+	}
+}
+
+func toTransferQuote(r response.TransferQuoteResponse) *walletpb.TransferQuote {
+	return &walletpb.TransferQuote{
+		QuoteId:              r.QuoteId,
+		WalletId:             r.WalletId,
+		CounterpartyWalletId: r.CounterpartyWalletId,
+		SourceAmount:         uint64(r.SourceAmount),
+		SourceCurrency:       r.SourceCurrency,
+		SourceExponent:       int32(r.SourceExponent),
+		DestinationAmount:    uint64(r.DestinationAmount),
+		DestinationCurrency:  r.DestinationCurrency,
+		DestinationExponent:  int32(r.DestinationExponent),
+		Rate:                 r.Rate,
+		ExpiresAt:            timestamppb.New(r.ExpiresAt),
+	}
+}
+
+func toFeePreview(r response.FeePreviewResponse) *walletpb.FeePreview {
+	res := &walletpb.FeePreview{
+		WalletId:   r.WalletId,
+		TrxType:    string(r.TrxType),
+		Amount:     uint64(r.Amount),
+		Currency:   r.Currency,
+		Exponent:   int32(r.Exponent),
+		TotalFee:   uint64(r.TotalFee),
+		TotalDebit: uint64(r.TotalDebit),
+	}
+	for _, fee := range r.Fees {
+		res.Fees = append(res.Fees, &walletpb.FeeLine{Operation: string(fee.Operation), FeeRuleId: fee.FeeRuleId, Amount: uint64(fee.Amount)})
+	}
+	return res
+}
+
+func toTransaction(r response.TransactionResponse) *walletpb.Transaction {
+	return &walletpb.Transaction{
+		TransactionId:        r.TransactionId,
+		WalletId:             r.WalletId,
+		Amount:               uint64(r.Amount),
+		Currency:             r.Currency,
+		Exponent:             int32(r.Exponent),
+		CounterpartyWalletId: r.CounterpartyWalletId,
+		CounterpartyAmount:   uint64(r.CounterpartyAmount),
+		CounterpartyCurrency: r.CounterpartyCurrency,
+		FxRate:               r.FxRate,
+		TrxType:              string(r.TrxType),
+		GroupId:              r.GroupId,
+		ReversedAmount:       uint64(r.ReversedAmount),
+		ReversalOf:           r.ReversalOf,
+		Fee:                  uint64(r.Fee),
+		FeeOf:                r.FeeOf,
+		CreatedAt:            timestamppb.New(r.CreatedAt),
+	}
+}
+
+func toTransactions(rs []response.TransactionResponse) []*walletpb.Transaction {
+	res := make([]*walletpb.Transaction, 0, len(rs))
+	for _, r := range rs {
+		res = append(res, toTransaction(r))
+	}
+	return res
+}
+
+func toHold(r response.HoldResponse) *walletpb.Hold {
+	return &walletpb.Hold{
+		HoldId:         r.HoldId,
+		WalletId:       r.WalletId,
+		Amount:         uint64(r.Amount),
+		CapturedAmount: uint64(r.CapturedAmount),
+		Currency:       r.Currency,
+		Exponent:       int32(r.Exponent),
+		Status:         string(r.Status),
+		TransactionId:  r.TransactionId,
+		ExpiresAt:      timestamppb.New(r.ExpiresAt),
+		CreatedAt:      timestamppb.New(r.CreatedAt),
+	}
+}
+
+func toSpendingLimits(r response.SpendingLimitsResponse) *walletpb.SpendingLimits {
+	res := &walletpb.SpendingLimits{
+		WalletId:       r.WalletId,
+		Currency:       r.Currency,
+		Exponent:       int32(r.Exponent),
+		PerTransaction: uint64(r.PerTransaction),
+		Daily:          uint64(r.Daily),
+		Weekly:         uint64(r.Weekly),
+		DailySpent:     uint64(r.DailySpent),
+		WeeklySpent:    uint64(r.WeeklySpent),
+	}
+	if r.Override != nil {
+		res.Override = &walletpb.SpendingLimitOverride{
+			PerTransaction: toOptionalUint64(r.Override.PerTransaction),
+			Daily:          toOptionalUint64(r.Override.Daily),
+			Weekly:         toOptionalUint64(r.Override.Weekly),
+			Reason:         r.Override.Reason,
+			UpdatedBy:      r.Override.UpdatedBy,
+			UpdatedAt:      timestamppb.New(r.Override.UpdatedAt),
+		}
+	}
+	return res
+}
+
+func toWalletStatusChange(r response.WalletStatusChangeResponse) *walletpb.WalletStatusChange {
+	return &walletpb.WalletStatusChange{
+		ChangeId:   r.ChangeId,
+		WalletId:   r.WalletId,
+		FromStatus: string(r.FromStatus),
+		ToStatus:   string(r.ToStatus),
+		ReasonCode: string(r.ReasonCode),
+		Note:       r.Note,
+		Actor:      r.Actor,
+		SweepTrxId: r.SweepTrxId,
+		CreatedAt:  timestamppb.New(r.CreatedAt),
+	}
+}
+
+func toBalanceAdjustment(r response.BalanceAdjustmentResponse) *walletpb.BalanceAdjustment {
+	return &walletpb.BalanceAdjustment{
+		AdjustmentId:  r.AdjustmentId,
+		WalletId:      r.WalletId,
+		Kind:          string(r.Kind),
+		Direction:     string(r.Direction),
+		Amount:        uint64(r.Amount),
+		Currency:      r.Currency,
+		Exponent:      int32(r.Exponent),
+		BalanceBefore: uint64(r.BalanceBefore),
+		BalanceAfter:  uint64(r.BalanceAfter),
+		Reason:        r.Reason,
+		Actor:         r.Actor,
+		TransactionId: r.TransactionId,
+		CreatedAt:     timestamppb.New(r.CreatedAt),
+	}
+}
+
+func toReconciliationReport(r response.ReconciliationReport) *walletpb.ReconciliationReport {
+	res := &walletpb.ReconciliationReport{
+		WalletsChecked: int32(r.WalletsChecked),
+		Repaired:       int32(r.Repaired),
+		StartedAt:      timestamppb.New(r.StartedAt),
+		FinishedAt:     timestamppb.New(r.FinishedAt),
+	}
+	for _, drift := range r.Drifts {
+		res.Drifts = append(res.Drifts, &walletpb.WalletDrift{
+			WalletId:        drift.WalletId,
+			Currency:        drift.Currency,
+			Exponent:        int32(drift.Exponent),
+			Balance:         uint64(drift.Balance),
+			ReplayedBalance: drift.ReplayedBalance,
+			Delta:           drift.Delta,
+			Repaired:        drift.Repaired,
+			AdjustmentId:    drift.AdjustmentId,
+		})
+	}
+	return res
+}
+
+func toOptionalUint64(v *uint) *uint64 {
+	if v == nil {
+		return nil
+	}
+	u := uint64(*v)
+	return &u
+}
+
+func fromOptionalUint64(v *uint64) *uint {
+	if v == nil {
+		return nil
+	}
+	u := uint(*v)
+	return &u
+}
+
+// fromTimestamp reads an unset timestamp as nil, the way the HTTP API treats a missing query parameter.
+func fromTimestamp(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
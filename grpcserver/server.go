@@ -0,0 +1,101 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/proto/walletpb"
+	"wallet-app/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationMetadata   = "authorization"
+	idempotencyKeyMetadata  = "idempotency-key"
+	maxIdempotencyKeyLength = 255
+)
+
+type principalKey struct{}
+
+// NewServer returns a gRPC server with the WalletService registered behind the same bearer token
+// authentication as the HTTP API.
+func NewServer(log *logrus.Logger, verifier auth.IJwtVerifier, walletService service.IWalletService) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryAuthenticate(log, verifier)),
+		grpc.ChainStreamInterceptor(streamAuthenticate(log, verifier)),
+	)
+	walletpb.RegisterWalletServiceServer(server, NewWalletGrpcServer(log, walletService))
+	return server
+}
+
+func unaryAuthenticate(log *logrus.Logger, verifier auth.IJwtVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(log, verifier, ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthenticate(log *logrus.Logger, verifier auth.IJwtVerifier) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(log, verifier, stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate rejects calls without a valid bearer token and stores the caller in the context.
+func authenticate(log *logrus.Logger, verifier auth.IJwtVerifier, ctx context.Context) (context.Context, error) {
+	token, ok := strings.CutPrefix(firstMetadata(ctx, authorizationMetadata), "Bearer ")
+	if !ok || token == "" {
+		log.Error("Missing bearer token")
+		return ctx, toStatus(apperror.ErrUnauthorized)
+	}
+	principal, err := verifier.Verify(token)
+	if err != nil {
+		log.Error("Invalid bearer token; ", err)
+		return ctx, toStatus(apperror.ErrUnauthorized)
+	}
+	return context.WithValue(ctx, principalKey{}, principal), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func principalFrom(ctx context.Context) auth.Principal {
+	if principal, ok := ctx.Value(principalKey{}).(auth.Principal); ok {
+		return principal
+	}
+	return auth.Principal{}
+}
+
+func idempotencyKey(ctx context.Context) (string, error) {
+	key := firstMetadata(ctx, idempotencyKeyMetadata)
+	if len(key) > maxIdempotencyKeyLength {
+		return "", toStatus(apperror.ErrInvalidIdempotencyKey)
+	}
+	return key, nil
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package grpcserver
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"wallet-app/apperror"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "wallet-app"
+
+// statusCodeOverrides are the errors whose gRPC code does not follow from their HTTP code, mostly
+// lookups the HTTP API answers with 400.
+var statusCodeOverrides = map[string]codes.Code{
+	apperror.ErrWalletIdNotFound.Message:            codes.NotFound,
+	apperror.ErrUserNotFound.Message:                codes.NotFound,
+	apperror.ErrWalletNotFound.Message:              codes.NotFound,
+	apperror.ErrCounterpartyWalletNotFound.Message:  codes.NotFound,
+	apperror.ErrFxQuoteNotFound.Message:             codes.NotFound,
+	apperror.ErrHoldNotFound.Message:                codes.NotFound,
+	apperror.ErrTransferNotFound.Message:            codes.NotFound,
+	apperror.ErrScheduleNotFound.Message:            codes.NotFound,
+	apperror.ErrWebhookSubscriptionNotFound.Message: codes.NotFound,
+	apperror.ErrWebhookDeliveryNotFound.Message:     codes.NotFound,
+	apperror.ErrFeeRuleNotFound.Message:             codes.NotFound,
+	apperror.ErrInsufficientAmount.Message:          codes.FailedPrecondition,
+	apperror.ErrFxQuoteExpired.Message:              codes.FailedPrecondition,
+	apperror.ErrFxQuoteAlreadyUsed.Message:          codes.AlreadyExists,
+	apperror.ErrIdempotencyKeyConflict.Message:      codes.AlreadyExists,
+	apperror.ErrFxRateNotAvailable.Message:          codes.Unavailable,
+}
+
+// toStatus maps an AppError to a gRPC status. The AppError message is the status message, and an
+// ErrorInfo detail carries it as a reason code together with the HTTP code and any Details as json.
+func toStatus(appErr apperror.AppError) error {
+	code, ok := statusCodeOverrides[appErr.Message]
+	if !ok {
+		code = httpToGrpcCode(appErr.Code)
+	}
+	info := &errdetails.ErrorInfo{
+		Reason:   errorReason(appErr.Message),
+		Domain:   errorDomain,
+		Metadata: map[string]string{"httpCode": strconv.Itoa(appErr.Code)},
+	}
+	if appErr.Details != nil {
+		if details, err := json.Marshal(appErr.Details); err == nil {
+			info.Metadata["details"] = string(details)
+		}
+	}
+	st := status.New(code, appErr.Message)
+	if withDetails, err := st.WithDetails(info); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func httpToGrpcCode(httpCode int) codes.Code {
+	switch httpCode {
+	case 400:
+		return codes.InvalidArgument
+	case 401:
+		return codes.Unauthenticated
+	case 403:
+		return codes.PermissionDenied
+	case 404:
+		return codes.NotFound
+	case 409, 422:
+		return codes.FailedPrecondition
+	case 429:
+		return codes.ResourceExhausted
+	case 503:
+		return codes.Unavailable
+	case 504:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
+
+// errorReason turns "wallet not found" into WALLET_NOT_FOUND.
+func errorReason(message string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToUpper(message), func(r rune) bool {
+		return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	}), "_")
+}
@@ -0,0 +1,390 @@
+package grpcserver
+
+import (
+	"bufio"
+	"context"
+
+	"wallet-app/apperror"
+	"wallet-app/entity"
+	"wallet-app/mapper"
+	"wallet-app/proto/walletpb"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/statement"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxTrxPageSize      = 500
+	statementBufferSize = 32 * 1024
+)
+
+// WalletGrpcServer serves service.IWalletService over gRPC. Like the controllers it only validates
+// the request shape and leaves everything else to the service.
+type WalletGrpcServer struct {
+	walletpb.UnimplementedWalletServiceServer
+	log     *logrus.Logger
+	service service.IWalletService
+	mapper  *mapper.AppMapper
+}
+
+func NewWalletGrpcServer(log *logrus.Logger, service service.IWalletService) *WalletGrpcServer {
+	return &WalletGrpcServer{log: log, service: service, mapper: mapper.NewAppMapper()}
+}
+
+func (s *WalletGrpcServer) CreateWallet(ctx context.Context, req *walletpb.CreateWalletRequest) (*walletpb.Wallet, error) {
+	if req.Currency == "" {
+		return nil, invalidArgument("currency is required")
+	}
+	res := s.service.CreateWallet(ctx, principalFrom(ctx), request.CreateWalletReq{UserId: req.UserId, Currency: req.Currency})
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toWallet(res.Data.(response.WalletResponse)), nil
+}
+
+func (s *WalletGrpcServer) GetWalletsByUserId(ctx context.Context, req *walletpb.GetWalletsByUserIdRequest) (*walletpb.WalletList, error) {
+	res := s.service.GetWalletsByUserId(ctx, principalFrom(ctx), req.UserId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	wallets := &walletpb.WalletList{}
+	for _, wallet := range res.Data.([]response.WalletResponse) {
+		wallets.Wallets = append(wallets.Wallets, toWallet(wallet))
+	}
+	return wallets, nil
+}
+
+func (s *WalletGrpcServer) DepositMoney(ctx context.Context, req *walletpb.TrxRequest) (*walletpb.Trx, error) {
+	if req.Amount == 0 {
+		return nil, invalidArgument("amount is required")
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.trx(s.service.DepositMoney(ctx, principalFrom(ctx), req.WalletId, request.TrxReq{Amount: uint(req.Amount)}, key))
+}
+
+func (s *WalletGrpcServer) WithdrawMoney(ctx context.Context, req *walletpb.TrxRequest) (*walletpb.Trx, error) {
+	if req.Amount == 0 {
+		return nil, invalidArgument("amount is required")
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.trx(s.service.WithdrawMoney(ctx, principalFrom(ctx), req.WalletId, request.TrxReq{Amount: uint(req.Amount)}, key))
+}
+
+func (s *WalletGrpcServer) TransferMoney(ctx context.Context, req *walletpb.TransferRequest) (*walletpb.Trx, error) {
+	if req.Amount == 0 || req.CounterpartyWalletId == "" {
+		return nil, invalidArgument("amount and counterparty_wallet_id are required")
+	}
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transferReq := request.TransferReq{Amount: uint(req.Amount), CounterpartyWalletId: req.CounterpartyWalletId, QuoteId: req.QuoteId}
+	return s.trx(s.service.TransferMoney(ctx, principalFrom(ctx), req.WalletId, transferReq, key))
+}
+
+func (s *WalletGrpcServer) QuoteTransfer(ctx context.Context, req *walletpb.TransferQuoteRequest) (*walletpb.TransferQuote, error) {
+	if req.Amount == 0 || req.CounterpartyWalletId == "" {
+		return nil, invalidArgument("amount and counterparty_wallet_id are required")
+	}
+	res := s.service.QuoteTransfer(ctx, principalFrom(ctx), req.WalletId, request.TransferQuoteReq{Amount: uint(req.Amount), CounterpartyWalletId: req.CounterpartyWalletId})
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toTransferQuote(res.Data.(response.TransferQuoteResponse)), nil
+}
+
+func (s *WalletGrpcServer) PreviewFees(ctx context.Context, req *walletpb.FeePreviewRequest) (*walletpb.FeePreview, error) {
+	if req.Amount == 0 || (req.TrxType != "withdrawal" && req.TrxType != "transfer_out") {
+		return nil, invalidArgument("amount is required and trx_type must be withdrawal or transfer_out")
+	}
+	previewReq := request.FeePreviewReq{TrxType: req.TrxType, Amount: uint(req.Amount), CounterpartyWalletId: req.CounterpartyWalletId}
+	res := s.service.PreviewFees(ctx, principalFrom(ctx), req.WalletId, previewReq)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toFeePreview(res.Data.(response.FeePreviewResponse)), nil
+}
+
+func (s *WalletGrpcServer) ReverseTransfer(ctx context.Context, req *walletpb.ReverseTransferRequest) (*walletpb.Trx, error) {
+	key, err := idempotencyKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.trx(s.service.ReverseTransfer(ctx, principalFrom(ctx), req.GroupId, request.ReverseTransferReq{Amount: uint(req.Amount), Force: req.Force}, key))
+}
+
+func (s *WalletGrpcServer) GetBalance(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.Wallet, error) {
+	res := s.service.GetBalance(ctx, principalFrom(ctx), req.WalletId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toWallet(res.Data.(response.WalletResponse)), nil
+}
+
+func (s *WalletGrpcServer) GetTransactions(ctx context.Context, req *walletpb.GetTransactionsRequest) (*walletpb.TransactionPage, error) {
+	historyReq, err := toTrxHistoryReq(req)
+	if err != nil {
+		return nil, err
+	}
+	res := s.service.GetTransactions(ctx, principalFrom(ctx), req.WalletId, historyReq)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	page := res.Data.(response.TrxHistoryResponse)
+	return &walletpb.TransactionPage{Transactions: toTransactions(page.Transactions), NextCursor: page.NextCursor}, nil
+}
+
+func (s *WalletGrpcServer) StreamTransactions(req *walletpb.GetTransactionsRequest, stream walletpb.WalletService_StreamTransactionsServer) error {
+	historyReq, err := toTrxHistoryReq(req)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	for {
+		res := s.service.GetTransactions(ctx, principalFrom(ctx), req.WalletId, historyReq)
+		if res.Err.Code != 0 {
+			return toStatus(res.Err)
+		}
+		page := res.Data.(response.TrxHistoryResponse)
+		for _, trx := range page.Transactions {
+			if err := stream.Send(toTransaction(trx)); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		historyReq.Cursor = page.NextCursor
+	}
+}
+
+func (s *WalletGrpcServer) ExportStatement(req *walletpb.ExportStatementRequest, stream walletpb.WalletService_ExportStatementServer) error {
+	format := req.Format
+	if format == "" {
+		format = statement.FormatCsv
+	}
+	out := &statementStreamWriter{stream: stream, contentType: statement.ContentType(format)}
+	buffered := bufio.NewWriterSize(out, statementBufferSize)
+	statementReq := request.StatementReq{From: fromTimestamp(req.From), To: fromTimestamp(req.To), Format: req.Format}
+	res := s.service.ExportStatement(stream.Context(), principalFrom(stream.Context()), req.WalletId, statementReq, buffered)
+	if res.Err.Code != 0 {
+		if out.started {
+			s.log.Errorf("Statement aborted after streaming started; walletId:%s %v", req.WalletId, res.Err.Message)
+		}
+		return toStatus(res.Err)
+	}
+	return buffered.Flush()
+}
+
+// statementStreamWriter sends each buffered block of the statement as a chunk; the first one names
+// the content type.
+type statementStreamWriter struct {
+	stream      walletpb.WalletService_ExportStatementServer
+	contentType string
+	started     bool
+}
+
+func (w *statementStreamWriter) Write(p []byte) (int, error) {
+	chunk := &walletpb.StatementChunk{Data: append([]byte(nil), p...)}
+	if !w.started {
+		w.started = true
+		chunk.ContentType = w.contentType
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (s *WalletGrpcServer) CreateHold(ctx context.Context, req *walletpb.CreateHoldRequest) (*walletpb.Hold, error) {
+	if req.Amount == 0 {
+		return nil, invalidArgument("amount is required")
+	}
+	res := s.service.CreateHold(ctx, principalFrom(ctx), req.WalletId, request.CreateHoldReq{Amount: uint(req.Amount), ExpiresInSeconds: uint(req.ExpiresInSeconds)})
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toHold(res.Data.(response.HoldResponse)), nil
+}
+
+func (s *WalletGrpcServer) CaptureHold(ctx context.Context, req *walletpb.CaptureHoldRequest) (*walletpb.Trx, error) {
+	captureReq := request.CaptureHoldReq{Amount: uint(req.Amount), CounterpartyWalletId: req.CounterpartyWalletId, QuoteId: req.QuoteId}
+	return s.trx(s.service.CaptureHold(ctx, principalFrom(ctx), req.HoldId, captureReq))
+}
+
+func (s *WalletGrpcServer) ReleaseHold(ctx context.Context, req *walletpb.HoldIdRequest) (*walletpb.Hold, error) {
+	res := s.service.ReleaseHold(ctx, principalFrom(ctx), req.HoldId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toHold(res.Data.(response.HoldResponse)), nil
+}
+
+func (s *WalletGrpcServer) GetHolds(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.HoldList, error) {
+	res := s.service.GetHolds(ctx, principalFrom(ctx), req.WalletId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	holds := &walletpb.HoldList{}
+	for _, hold := range res.Data.([]response.HoldResponse) {
+		holds.Holds = append(holds.Holds, toHold(hold))
+	}
+	return holds, nil
+}
+
+// ExpireHolds is run by a job in the server; over gRPC it is limited to admins.
+func (s *WalletGrpcServer) ExpireHolds(ctx context.Context, _ *walletpb.Empty) (*walletpb.ExpireHoldsResponse, error) {
+	if !principalFrom(ctx).IsAdmin() {
+		return nil, toStatus(apperror.ErrForbidden)
+	}
+	res := s.service.ExpireHolds(ctx)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return &walletpb.ExpireHoldsResponse{Expired: int64(res.Data.(int))}, nil
+}
+
+func (s *WalletGrpcServer) GetSpendingLimits(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.SpendingLimits, error) {
+	return s.spendingLimits(s.service.GetSpendingLimits(ctx, principalFrom(ctx), req.WalletId))
+}
+
+func (s *WalletGrpcServer) SetSpendingLimits(ctx context.Context, req *walletpb.SetSpendingLimitsRequest) (*walletpb.SpendingLimits, error) {
+	if req.Reason == "" {
+		return nil, invalidArgument("reason is required")
+	}
+	limitReq := request.SpendingLimitReq{
+		PerTransaction: fromOptionalUint64(req.PerTransaction),
+		Daily:          fromOptionalUint64(req.Daily),
+		Weekly:         fromOptionalUint64(req.Weekly),
+		Reason:         req.Reason,
+	}
+	return s.spendingLimits(s.service.SetSpendingLimits(ctx, principalFrom(ctx), req.WalletId, limitReq))
+}
+
+func (s *WalletGrpcServer) DeleteSpendingLimits(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.SpendingLimits, error) {
+	return s.spendingLimits(s.service.DeleteSpendingLimits(ctx, principalFrom(ctx), req.WalletId))
+}
+
+func (s *WalletGrpcServer) ChangeWalletStatus(ctx context.Context, req *walletpb.ChangeWalletStatusRequest) (*walletpb.WalletStatusChange, error) {
+	if req.Status == "" || req.ReasonCode == "" {
+		return nil, invalidArgument("status and reason_code are required")
+	}
+	statusReq := request.WalletStatusReq{Status: req.Status, ReasonCode: req.ReasonCode, Note: req.Note, SweepToWalletId: req.SweepToWalletId}
+	res := s.service.ChangeWalletStatus(ctx, principalFrom(ctx), req.WalletId, statusReq)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toWalletStatusChange(res.Data.(response.WalletStatusChangeResponse)), nil
+}
+
+func (s *WalletGrpcServer) GetWalletStatusChanges(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.WalletStatusChangeList, error) {
+	res := s.service.GetWalletStatusChanges(ctx, principalFrom(ctx), req.WalletId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	changes := &walletpb.WalletStatusChangeList{}
+	for _, change := range res.Data.([]response.WalletStatusChangeResponse) {
+		changes.Changes = append(changes.Changes, toWalletStatusChange(change))
+	}
+	return changes, nil
+}
+
+func (s *WalletGrpcServer) AdjustBalance(ctx context.Context, req *walletpb.AdjustBalanceRequest) (*walletpb.BalanceAdjustment, error) {
+	if req.Direction == "" || req.Amount == 0 || req.Reason == "" {
+		return nil, invalidArgument("direction, amount and reason are required")
+	}
+	adjustmentReq := request.BalanceAdjustmentReq{Direction: req.Direction, Amount: uint(req.Amount), Reason: req.Reason}
+	res := s.service.AdjustBalance(ctx, principalFrom(ctx), req.WalletId, adjustmentReq)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toBalanceAdjustment(res.Data.(response.BalanceAdjustmentResponse)), nil
+}
+
+func (s *WalletGrpcServer) GetBalanceAdjustments(ctx context.Context, req *walletpb.WalletIdRequest) (*walletpb.BalanceAdjustmentList, error) {
+	res := s.service.GetBalanceAdjustments(ctx, principalFrom(ctx), req.WalletId)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	adjustments := &walletpb.BalanceAdjustmentList{}
+	for _, adjustment := range res.Data.([]response.BalanceAdjustmentResponse) {
+		adjustments.Adjustments = append(adjustments.Adjustments, toBalanceAdjustment(adjustment))
+	}
+	return adjustments, nil
+}
+
+func (s *WalletGrpcServer) ReconcileWallets(ctx context.Context, req *walletpb.ReconcileRequest) (*walletpb.ReconciliationReport, error) {
+	res := s.service.ReconcileWallets(ctx, principalFrom(ctx), request.ReconcileReq{WalletIds: req.WalletIds, Repair: req.Repair, Reason: req.Reason})
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toReconciliationReport(res.Data.(response.ReconciliationReport)), nil
+}
+
+func (s *WalletGrpcServer) DeleteAll(ctx context.Context, _ *walletpb.Empty) (*walletpb.Empty, error) {
+	res := s.service.DeleteAll(ctx, principalFrom(ctx))
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return &walletpb.Empty{}, nil
+}
+
+// GetAllTrxs takes no caller in the service; over gRPC it is limited to admins.
+func (s *WalletGrpcServer) GetAllTrxs(ctx context.Context, _ *walletpb.Empty) (*walletpb.TransactionList, error) {
+	if !principalFrom(ctx).IsAdmin() {
+		return nil, toStatus(apperror.ErrForbidden)
+	}
+	res := s.service.GetAllTrxs(ctx)
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return &walletpb.TransactionList{Transactions: toTransactions(s.mapper.ToTransactionResponses(res.Data.([]entity.TrxEntity)))}, nil
+}
+
+func (s *WalletGrpcServer) trx(res response.ResonseWrapper) (*walletpb.Trx, error) {
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toTrx(res.Data.(response.TrxResponse)), nil
+}
+
+func (s *WalletGrpcServer) spendingLimits(res response.ResonseWrapper) (*walletpb.SpendingLimits, error) {
+	if res.Err.Code != 0 {
+		return nil, toStatus(res.Err)
+	}
+	return toSpendingLimits(res.Data.(response.SpendingLimitsResponse)), nil
+}
+
+func toTrxHistoryReq(req *walletpb.GetTransactionsRequest) (request.TrxHistoryReq, error) {
+	if req.Limit < 0 || req.Limit > maxTrxPageSize {
+		return request.TrxHistoryReq{}, invalidArgument("limit must be between 1 and 500")
+	}
+	return request.TrxHistoryReq{
+		Cursor:               req.Cursor,
+		Limit:                int(req.Limit),
+		TrxTypes:             req.TrxTypes,
+		MinAmount:            fromOptionalUint64(req.MinAmount),
+		MaxAmount:            fromOptionalUint64(req.MaxAmount),
+		From:                 fromTimestamp(req.From),
+		To:                   fromTimestamp(req.To),
+		CounterpartyWalletId: req.CounterpartyWalletId,
+	}, nil
+}
+
+// invalidArgument answers a malformed request the way the controllers answer a failed binding.
+func invalidArgument(message string) error {
+	return toStatus(apperror.AppError{Code: 400, Message: message})
+}
@@ -1,17 +1,31 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"os"
+	"time"
+	"wallet-app/auth"
 	"wallet-app/config"
 	"wallet-app/controller"
 	"wallet-app/db"
+	"wallet-app/fx"
+	"wallet-app/grpcserver"
 	"wallet-app/manager"
 	"wallet-app/mapper"
+	"wallet-app/metrics"
+	"wallet-app/middleware"
 	"wallet-app/repo"
+	"wallet-app/request"
 	"wallet-app/route"
+	"wallet-app/scheduler"
 	"wallet-app/service"
+	"wallet-app/tracing"
+	"wallet-app/webhook"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,6 +41,10 @@ func main() {
 		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(migrateCommand(&appConfig.Database, os.Args[2:]))
+	}
+
 	log.Info("Connect to db")
 	db, err := db.InitDb(&appConfig.Database)
 	if err != nil {
@@ -34,16 +52,128 @@ func main() {
 		return
 	}
 
-	dbTxManager := manager.NewDbTxManager(db)
-	walletRepo := repo.NewWalletRepo(db)
-	transactionRepo := repo.NewTransactionRepo(db)
+	log.Info("Load fx rates")
+	fxProvider, err := fx.NewStaticFxProvider(appConfig.Fx.RatesFile)
+	if err != nil {
+		log.Error("Err loading fx rates; ", err)
+		return
+	}
+
+	appMetrics, err := metrics.NewMetrics(db)
+	if err != nil {
+		log.Error("Err preparing metrics; ", err)
+		return
+	}
+
+	tracerProvider, err := tracing.NewTracerProvider(&appConfig.Tracing)
+	if err != nil {
+		log.Error("Err preparing tracing; ", err)
+		return
+	}
+	defer tracerProvider.Shutdown(context.Background())
+	if err := tracing.InstrumentDb(db, tracerProvider); err != nil {
+		log.Error("Err instrumenting db; ", err)
+		return
+	}
+
+	// retries wrap the instrumented manager, so every attempt is timed and traced as a transaction of its own
+	dbTxManager := manager.NewRetryingDbTxManager(log, appConfig.Database,
+		metrics.NewDbTxManager(appMetrics, tracing.NewDbTxManager(tracerProvider, manager.NewDbTxManager(db))))
+	walletRepo := metrics.NewWalletRepo(appMetrics, tracing.NewWalletRepo(tracerProvider, repo.NewWalletRepo(db)))
+	transactionRepo := metrics.NewTrxRepo(appMetrics, tracing.NewTrxRepo(tracerProvider, repo.NewTransactionRepo(db)))
+	ledgerRepo := metrics.NewLedgerRepo(appMetrics, repo.NewLedgerRepo(db))
+	idempotencyRepo := repo.NewIdempotencyRepo(db)
+	fxQuoteRepo := metrics.NewFxQuoteRepo(appMetrics, repo.NewFxQuoteRepo(db))
+	holdRepo := metrics.NewHoldRepo(appMetrics, repo.NewHoldRepo(db))
+	scheduleRepo := repo.NewScheduleRepo(db)
+	leaseRepo := repo.NewLeaseRepo(db)
+	outboxRepo := repo.NewOutboxRepo(db)
+	webhookRepo := repo.NewWebhookRepo(db)
+	adjustmentRepo := repo.NewAdjustmentRepo(db)
+	spendingLimitRepo := repo.NewSpendingLimitRepo(db)
+	feeRuleRepo := repo.NewFeeRuleRepo(db)
 	mapper := mapper.NewAppMapper()
-	service := service.NewWalletService(log, walletRepo, transactionRepo, mapper, dbTxManager)
-	controller := controller.NewWalletController(log, service)
+	walletService := metrics.NewWalletService(appMetrics, tracing.NewWalletService(tracerProvider,
+		service.NewWalletService(log, appConfig, walletRepo, transactionRepo, ledgerRepo, idempotencyRepo, fxQuoteRepo, holdRepo, outboxRepo, adjustmentRepo, spendingLimitRepo, feeRuleRepo, fxProvider, mapper, dbTxManager)))
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(walletService, os.Args[1:]))
+	}
+
+	jwtVerifier, err := auth.NewJwtVerifier(&appConfig.Auth)
+	if err != nil {
+		log.Error("Err preparing auth; ", err)
+		return
+	}
+
+	go purgeExpiredIdempotencyKeys(log, idempotencyRepo, appConfig.Idempotency.KeyTtl)
+	go expireHolds(walletService, appConfig.Holds.SweepInterval)
+
+	scheduleService := service.NewScheduleService(log, appConfig, walletService, walletRepo, scheduleRepo, mapper, dbTxManager)
+	leaseHolder := instanceId()
+	log.Infof("Start scheduler; instance:%s", leaseHolder)
+	scheduleJob := scheduler.NewLeasedJob(log, leaseRepo, "schedules", leaseHolder, appConfig.Schedules.PollInterval, appConfig.Schedules.LeaseTtl,
+		func(now time.Time) { scheduleService.RunDueSchedules(now) })
+	go scheduleJob.Start()
+
+	webhookService := service.NewWebhookService(log, appConfig, outboxRepo, webhookRepo, webhook.NewHttpWebhookSender(appConfig.Webhooks.Timeout), mapper, dbTxManager)
+	webhookJob := scheduler.NewLeasedJob(log, leaseRepo, "webhooks", leaseHolder, appConfig.Webhooks.DispatchInterval, appConfig.Webhooks.LeaseTtl,
+		func(now time.Time) {
+			webhookService.DispatchOutboxEvents(now)
+			webhookService.DeliverWebhooks(now)
+		})
+	go webhookJob.Start()
+
+	if appConfig.Reconciliation.Interval > 0 {
+		reconcileReq := request.ReconcileReq{Repair: appConfig.Reconciliation.Repair}
+		reconcileJob := scheduler.NewLeasedJob(log, leaseRepo, "reconciliation", leaseHolder, appConfig.Reconciliation.Interval, appConfig.Reconciliation.LeaseTtl,
+			func(now time.Time) { walletService.ReconcileWallets(context.Background(), auth.System, reconcileReq) })
+		go reconcileJob.Start()
+	}
+
+	walletController := controller.NewWalletController(log, walletService)
+	scheduleController := controller.NewScheduleController(log, scheduleService)
+	webhookController := controller.NewWebhookController(log, webhookService)
+	feeController := controller.NewFeeController(log, service.NewFeeService(log, appConfig, feeRuleRepo, mapper))
 	r := gin.Default()
-	route.InitRoutes(r, controller)
+	r.Use(middleware.Tracing(appConfig.Tracing.ServiceName, tracerProvider), middleware.Metrics(appMetrics), middleware.Timeout(appConfig.Timeouts))
+	route.InitRoutes(r, middleware.Authenticate(log, jwtVerifier), walletController, scheduleController, webhookController, feeController)
+	route.InitDocsRoutes(r)
+	route.InitMetricsRoutes(r, appMetrics.Handler())
+
+	if appConfig.Grpc.Port > 0 {
+		grpcPort := fmt.Sprintf(":%d", appConfig.Grpc.Port)
+		lis, err := net.Listen("tcp", grpcPort)
+		if err != nil {
+			log.Error("Err listening for grpc; ", err)
+			return
+		}
+		grpcServer := grpcserver.NewServer(log, jwtVerifier, walletService)
+		log.Infof("Start grpc server; port:%s", grpcPort)
+		go grpcServer.Serve(lis)
+	}
 
 	serverPort := fmt.Sprintf(":%d", appConfig.Server.Port)
 	log.Infof("Start server; port:%s", serverPort)
 	r.Run(serverPort)
 }
+
+func purgeExpiredIdempotencyKeys(log *logrus.Logger, idempotencyRepo repo.IIdempotencyRepo, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := idempotencyRepo.DeleteExpiredIdempotencyKeys(time.Now()); err != nil {
+			log.Error("Err purging expired idempotency keys; ", err)
+		}
+	}
+}
+
+func expireHolds(service service.IWalletService, interval time.Duration) {
+	for range time.Tick(interval) {
+		service.ExpireHolds(context.Background())
+	}
+}
+
+// instanceId names this process as a lease holder.
+func instanceId() string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.New().String()[:8])
+}
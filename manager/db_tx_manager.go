@@ -1,9 +1,19 @@
 package manager
 
-import "gorm.io/gorm"
+import (
+	"context"
 
+	"gorm.io/gorm"
+)
+
+// IDbTxManager hands out the db transactions are begun on. The db is bound to the caller's context,
+// so a cancelled or timed out request rolls back its transaction and aborts its queries.
 type IDbTxManager interface {
-	GetTx() *gorm.DB
+	GetTx(ctx context.Context) *gorm.DB
+	// InTx runs fn in a unit of work: a transaction committed when fn returns nil and rolled back when
+	// it returns an error or panics. A panic is raised again once the transaction is rolled back; a
+	// failed commit is returned as the error.
+	InTx(ctx context.Context, fn func(uow *UnitOfWork) error) error
 }
 
 type DbTxManager struct {
@@ -14,6 +24,28 @@ func NewDbTxManager(db *gorm.DB) IDbTxManager {
 	return &DbTxManager{db: db}
 }
 
-func (t *DbTxManager) GetTx() *gorm.DB {
-	return t.db
+func (t *DbTxManager) GetTx(ctx context.Context) *gorm.DB {
+	return t.db.WithContext(ctx)
+}
+
+func (t *DbTxManager) InTx(ctx context.Context, fn func(uow *UnitOfWork) error) error {
+	tx := t.GetTx(ctx).Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+	finished := false
+	defer func() {
+		// fn panicked, or called runtime.Goexit; the panic carries on once this returns
+		if !finished {
+			tx.Rollback()
+		}
+	}()
+
+	err := fn(&UnitOfWork{tx: tx})
+	finished = true
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+	"wallet-app/common"
+	"wallet-app/config"
+	appdb "wallet-app/db"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RetryingDbTxManager runs a unit of work again when it fails with an error the transaction may
+// succeed on from the start (see db.IsRetryable), after a jittered backoff so that the transactions
+// that collided do not collide again. A unit of work must therefore be safe to run more than once:
+// it reads everything it decides on inside the transaction.
+type RetryingDbTxManager struct {
+	log  *logrus.Logger
+	cfg  config.DatabaseConfig
+	next IDbTxManager
+}
+
+func NewRetryingDbTxManager(log *logrus.Logger, cfg config.DatabaseConfig, next IDbTxManager) IDbTxManager {
+	return &RetryingDbTxManager{log: log, cfg: cfg, next: next}
+}
+
+func (t *RetryingDbTxManager) GetTx(ctx context.Context) *gorm.DB {
+	return t.next.GetTx(ctx)
+}
+
+// InTx gives up after cfg.MaxAttempts runs, or when ctx is done, with the error of the last run.
+func (t *RetryingDbTxManager) InTx(ctx context.Context, fn func(uow *UnitOfWork) error) error {
+	for attempt := 1; ; attempt++ {
+		err := t.next.InTx(ctx, fn)
+		if err == nil || !appdb.IsRetryable(err) || attempt >= t.cfg.MaxAttempts {
+			return err
+		}
+
+		backoff := jitter(common.Backoff(t.cfg.RetryBackoff, t.cfg.MaxRetryBackoff, attempt))
+		t.log.Infof("Retrying dbTx; attempt:%d backoff:%s %v", attempt, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// jitter spreads a backoff evenly over its upper half.
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 1 {
+		return backoff
+	}
+	return backoff/2 + rand.N(backoff/2+1)
+}
@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork is the transaction IDbTxManager.InTx runs a function in. Repo methods named ...WithTx
+// are given Tx(); repos implementing TxBinder can instead be bound to it with Bind.
+type UnitOfWork struct {
+	tx         *gorm.DB
+	savepoints int
+}
+
+func (u *UnitOfWork) Tx() *gorm.DB {
+	return u.tx
+}
+
+// Savepoint runs fn nested in the unit of work. When fn returns an error or panics, only what fn did
+// is rolled back and the error (or panic) is passed on; the caller may carry on with the transaction,
+// e.g. after a failed insert on Postgres, which otherwise aborts the whole transaction. Savepoints nest.
+func (u *UnitOfWork) Savepoint(fn func(uow *UnitOfWork) error) error {
+	u.savepoints++
+	name := fmt.Sprintf("uow_savepoint_%d", u.savepoints)
+	if err := u.tx.Session(&gorm.Session{}).SavePoint(name).Error; err != nil {
+		return err
+	}
+	finished := false
+	defer func() {
+		if !finished {
+			u.tx.Session(&gorm.Session{}).RollbackTo(name)
+		}
+	}()
+
+	err := fn(u)
+	finished = true
+	if err != nil {
+		if rollbackErr := u.tx.Session(&gorm.Session{}).RollbackTo(name).Error; rollbackErr != nil {
+			return fmt.Errorf("%w; rolling back to savepoint: %v", err, rollbackErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// TxBinder is implemented by repos that hand out a copy of themselves bound to a transaction, whose
+// methods all run in it.
+type TxBinder[R any] interface {
+	BindTx(tx *gorm.DB) R
+}
+
+// Bind returns repo bound to the transaction of the unit of work, e.g.
+// manager.Bind[repo.ITrxRepo](uow, w.trxRepo).FindTrxsByGroupId(ctx, groupId).
+func Bind[R any](uow *UnitOfWork, repo TxBinder[R]) R {
+	return repo.BindTx(uow.tx)
+}
@@ -1,6 +1,9 @@
 package mapper
 
 import (
+	"encoding/json"
+	"time"
+	"wallet-app/common"
 	"wallet-app/entity"
 	"wallet-app/response"
 )
@@ -13,5 +16,279 @@ func NewAppMapper() *AppMapper {
 }
 
 func (a *AppMapper) ToTrxResponse(e entity.TrxEntity, balance uint) response.TrxResponse {
-	return response.TrxResponse{TransactionId: e.ID, WalletId: e.WalletId, Amount: e.Amount, CurrentBalance: balance}
+	return response.TrxResponse{
+		TransactionId:        e.ID,
+		WalletId:             e.WalletId,
+		Amount:               e.Amount,
+		CurrentBalance:       balance,
+		Fee:                  e.Fee,
+		Currency:             e.Currency,
+		Exponent:             common.CurrencyExponent(e.Currency),
+		CounterpartyAmount:   e.CounterpartyAmount,
+		CounterpartyCurrency: e.CounterpartyCurrency,
+		FxRate:               e.FxRate,
+	}
+}
+
+func (a *AppMapper) ToWalletResponse(e entity.WalletEntity, availableBalance uint) response.WalletResponse {
+	return response.WalletResponse{WalletId: e.ID, UserId: e.UserId, CurrentBalance: e.Balance, AvailableBalance: availableBalance, Currency: e.Currency, Exponent: common.CurrencyExponent(e.Currency), Status: e.Status}
+}
+
+func (a *AppMapper) ToWalletResponses(es []entity.WalletEntity, availableBalances map[string]uint) []response.WalletResponse {
+	res := make([]response.WalletResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToWalletResponse(e, availableBalances[e.ID]))
+	}
+	return res
+}
+
+func (a *AppMapper) ToTransactionResponse(e entity.TrxEntity) response.TransactionResponse {
+	return response.TransactionResponse{
+		TransactionId:        e.ID,
+		WalletId:             e.WalletId,
+		Amount:               e.Amount,
+		Currency:             e.Currency,
+		Exponent:             common.CurrencyExponent(e.Currency),
+		CounterpartyWalletId: e.CounterpartyWalletId,
+		CounterpartyAmount:   e.CounterpartyAmount,
+		CounterpartyCurrency: e.CounterpartyCurrency,
+		FxRate:               e.FxRate,
+		TrxType:              e.TrxType,
+		GroupId:              e.GroupId,
+		ReversedAmount:       e.ReversedAmount,
+		ReversalOf:           e.ReversalOf,
+		Fee:                  e.Fee,
+		FeeOf:                e.FeeOf,
+		CreatedAt:            e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToTransactionResponses(es []entity.TrxEntity) []response.TransactionResponse {
+	res := make([]response.TransactionResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToTransactionResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToTransferQuoteResponse(e entity.FxQuoteEntity) response.TransferQuoteResponse {
+	return response.TransferQuoteResponse{
+		QuoteId:              e.ID,
+		WalletId:             e.WalletId,
+		CounterpartyWalletId: e.CounterpartyWalletId,
+		SourceAmount:         e.SourceAmount,
+		SourceCurrency:       e.SourceCurrency,
+		SourceExponent:       common.CurrencyExponent(e.SourceCurrency),
+		DestinationAmount:    e.DestinationAmount,
+		DestinationCurrency:  e.DestinationCurrency,
+		DestinationExponent:  common.CurrencyExponent(e.DestinationCurrency),
+		Rate:                 e.Rate,
+		ExpiresAt:            e.ExpiresAt,
+	}
+}
+
+func (a *AppMapper) ToHoldResponse(e entity.HoldEntity) response.HoldResponse {
+	status := e.Status
+	if status == common.HoldStatusActive && !e.IsActive(time.Now()) {
+		status = common.HoldStatusExpired // not swept yet
+	}
+	return response.HoldResponse{
+		HoldId:         e.ID,
+		WalletId:       e.WalletId,
+		Amount:         e.Amount,
+		CapturedAmount: e.CapturedAmount,
+		Currency:       e.Currency,
+		Exponent:       common.CurrencyExponent(e.Currency),
+		Status:         status,
+		TransactionId:  e.TrxId,
+		ExpiresAt:      e.ExpiresAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToHoldResponses(es []entity.HoldEntity) []response.HoldResponse {
+	res := make([]response.HoldResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToHoldResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToScheduleResponse(e entity.ScheduleEntity) response.ScheduleResponse {
+	interval := ""
+	if e.IntervalSeconds > 0 {
+		interval = (time.Duration(e.IntervalSeconds) * time.Second).String()
+	}
+	return response.ScheduleResponse{
+		ScheduleId:           e.ID,
+		WalletId:             e.WalletId,
+		CounterpartyWalletId: e.CounterpartyWalletId,
+		Amount:               e.Amount,
+		Currency:             e.Currency,
+		Exponent:             common.CurrencyExponent(e.Currency),
+		Cron:                 e.Cron,
+		Interval:             interval,
+		Status:               e.Status,
+		NextRunAt:            e.NextRunAt,
+		Attempt:              e.Attempt,
+		LastRunAt:            e.LastRunAt,
+		LastOutcome:          e.LastOutcome,
+		CreatedAt:            e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToScheduleResponses(es []entity.ScheduleEntity) []response.ScheduleResponse {
+	res := make([]response.ScheduleResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToScheduleResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToScheduleRunResponses(es []entity.ScheduleRunEntity) []response.ScheduleRunResponse {
+	res := make([]response.ScheduleRunResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, response.ScheduleRunResponse{
+			RunId:         e.ID,
+			ScheduleId:    e.ScheduleId,
+			ScheduledFor:  e.ScheduledFor,
+			Attempt:       e.Attempt,
+			Outcome:       e.Outcome,
+			TransactionId: e.TrxId,
+			Error:         e.Error,
+			CreatedAt:     e.CreatedAt,
+		})
+	}
+	return res
+}
+
+func (a *AppMapper) ToWebhookSubscriptionResponse(e entity.WebhookSubscriptionEntity) response.WebhookSubscriptionResponse {
+	return response.WebhookSubscriptionResponse{
+		SubscriptionId: e.ID,
+		Url:            e.Url,
+		EventTypes:     e.EventTypeList(),
+		Active:         e.Active,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToWebhookSubscriptionResponses(es []entity.WebhookSubscriptionEntity) []response.WebhookSubscriptionResponse {
+	res := make([]response.WebhookSubscriptionResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToWebhookSubscriptionResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToWebhookDeliveryResponse(e entity.WebhookDeliveryEntity) response.WebhookDeliveryResponse {
+	return response.WebhookDeliveryResponse{
+		DeliveryId:     e.ID,
+		EventId:        e.EventId,
+		SubscriptionId: e.SubscriptionId,
+		Status:         e.Status,
+		Attempt:        e.Attempt,
+		NextAttemptAt:  e.NextAttemptAt,
+		LastStatusCode: e.LastStatusCode,
+		LastError:      e.LastError,
+		DeliveredAt:    e.DeliveredAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToWebhookDeliveryResponses(es []entity.WebhookDeliveryEntity) []response.WebhookDeliveryResponse {
+	res := make([]response.WebhookDeliveryResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToWebhookDeliveryResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToSpendingLimitOverrideResponse(e entity.SpendingLimitEntity) *response.SpendingLimitOverrideResponse {
+	return &response.SpendingLimitOverrideResponse{
+		PerTransaction: e.PerTransaction,
+		Daily:          e.Daily,
+		Weekly:         e.Weekly,
+		Reason:         e.Reason,
+		UpdatedBy:      e.UpdatedBy,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+func (a *AppMapper) ToFeeRuleResponse(e entity.FeeRuleEntity) response.FeeRuleResponse {
+	var tiers []common.FeeTier
+	if e.Tiers != "" {
+		json.Unmarshal([]byte(e.Tiers), &tiers)
+	}
+	return response.FeeRuleResponse{
+		FeeRuleId:     e.ID,
+		Operation:     e.Operation,
+		Currency:      e.Currency,
+		Exponent:      common.CurrencyExponent(e.Currency),
+		Kind:          e.Kind,
+		FlatAmount:    e.FlatAmount,
+		PercentageBps: e.PercentageBps,
+		MinAmount:     e.MinAmount,
+		MaxAmount:     e.MaxAmount,
+		Tiers:         tiers,
+		Active:        e.Active,
+		CreatedBy:     e.CreatedBy,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+}
+
+func (a *AppMapper) ToFeeRuleResponses(es []entity.FeeRuleEntity) []response.FeeRuleResponse {
+	res := make([]response.FeeRuleResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToFeeRuleResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToWalletStatusChangeResponse(e entity.WalletStatusChangeEntity) response.WalletStatusChangeResponse {
+	return response.WalletStatusChangeResponse{
+		ChangeId:   e.ID,
+		WalletId:   e.WalletId,
+		FromStatus: e.FromStatus,
+		ToStatus:   e.ToStatus,
+		ReasonCode: e.ReasonCode,
+		Note:       e.Note,
+		Actor:      e.Actor,
+		SweepTrxId: e.SweepTrxId,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToWalletStatusChangeResponses(es []entity.WalletStatusChangeEntity) []response.WalletStatusChangeResponse {
+	res := make([]response.WalletStatusChangeResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToWalletStatusChangeResponse(e))
+	}
+	return res
+}
+
+func (a *AppMapper) ToBalanceAdjustmentResponse(e entity.BalanceAdjustmentEntity) response.BalanceAdjustmentResponse {
+	return response.BalanceAdjustmentResponse{
+		AdjustmentId:  e.ID,
+		WalletId:      e.WalletId,
+		Kind:          e.Kind,
+		Direction:     e.Direction,
+		Amount:        e.Amount,
+		Currency:      e.Currency,
+		Exponent:      common.CurrencyExponent(e.Currency),
+		BalanceBefore: e.BalanceBefore,
+		BalanceAfter:  e.BalanceAfter,
+		Reason:        e.Reason,
+		Actor:         e.Actor,
+		TransactionId: e.TrxId,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func (a *AppMapper) ToBalanceAdjustmentResponses(es []entity.BalanceAdjustmentEntity) []response.BalanceAdjustmentResponse {
+	res := make([]response.BalanceAdjustmentResponse, 0, len(es))
+	for _, e := range es {
+		res = append(res, a.ToBalanceAdjustmentResponse(e))
+	}
+	return res
 }
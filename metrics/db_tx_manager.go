@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+	"wallet-app/manager"
+
+	"gorm.io/gorm"
+)
+
+const (
+	outcomeCommit      = "commit"
+	outcomeCommitError = "commit_error"
+	outcomeRollback    = "rollback"
+)
+
+// NewDbTxManager times every transaction begun on the db next hands out. gorm only lets a connection
+// pool see begin, commit and rollback, so the pool of that db is swapped for one that wraps each
+// transaction, and units of work are run on it by a plain manager.DbTxManager.
+func NewDbTxManager(metrics *Metrics, next manager.IDbTxManager) manager.IDbTxManager {
+	db := next.GetTx(context.Background()).Session(&gorm.Session{})
+	db.Statement.ConnPool = &timedConnPool{ConnPool: db.Statement.ConnPool, metrics: metrics}
+	return manager.NewDbTxManager(db)
+}
+
+type timedConnPool struct {
+	gorm.ConnPool
+	metrics *Metrics
+}
+
+func (p *timedConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	var tx gorm.ConnPool
+	var err error
+	switch beginner := p.ConnPool.(type) {
+	case gorm.TxBeginner:
+		tx, err = beginner.BeginTx(ctx, opts)
+	case gorm.ConnPoolBeginner:
+		tx, err = beginner.BeginTx(ctx, opts)
+	default:
+		return nil, gorm.ErrInvalidTransaction
+	}
+	if err != nil {
+		return nil, err
+	}
+	committer, ok := tx.(gorm.TxCommitter)
+	if !ok {
+		return nil, errors.New("transaction cannot commit")
+	}
+	return &timedTx{ConnPool: tx, committer: committer, metrics: p.metrics, begunAt: time.Now()}, nil
+}
+
+// GetDBConn keeps db.DB() working on the wrapped pool.
+func (p *timedConnPool) GetDBConn() (*sql.DB, error) {
+	if connector, ok := p.ConnPool.(gorm.GetDBConnector); ok {
+		return connector.GetDBConn()
+	}
+	if sqlDb, ok := p.ConnPool.(*sql.DB); ok {
+		return sqlDb, nil
+	}
+	return nil, gorm.ErrInvalidDB
+}
+
+// timedTx observes the first commit or rollback only, should a caller roll back after a failed commit.
+type timedTx struct {
+	gorm.ConnPool
+	committer gorm.TxCommitter
+	metrics   *Metrics
+	begunAt   time.Time
+	ended     sync.Once
+}
+
+func (t *timedTx) Commit() error {
+	err := t.committer.Commit()
+	if err != nil {
+		t.end(outcomeCommitError)
+	} else {
+		t.end(outcomeCommit)
+	}
+	return err
+}
+
+func (t *timedTx) Rollback() error {
+	err := t.committer.Rollback()
+	t.end(outcomeRollback)
+	return err
+}
+
+func (t *timedTx) end(outcome string) {
+	t.ended.Do(func() {
+		t.metrics.dbTxDuration.WithLabelValues(outcome).Observe(time.Since(t.begunAt).Seconds())
+		if outcome != outcomeCommit {
+			t.metrics.dbTxRollbacks.Inc()
+		}
+	})
+}
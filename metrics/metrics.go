@@ -0,0 +1,108 @@
+// Package metrics exposes Prometheus metrics. Business code is not instrumented itself: the service,
+// repos and db transaction manager are wrapped in decorators that observe the calls going through them.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const namespace = "wallet"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequestDuration *prometheus.HistogramVec
+	transactions        *prometheus.CounterVec
+	transactionAmount   *prometheus.CounterVec
+	insufficientFunds   *prometheus.CounterVec
+	lockContention      *prometheus.CounterVec
+	dbTxDuration        *prometheus.HistogramVec
+	dbTxRollbacks       prometheus.Counter
+}
+
+// NewMetrics registers every metric on a registry of its own, together with the Go runtime, process
+// and, when db is not nil, connection pool collectors.
+func NewMetrics(db *gorm.DB) (*Metrics, error) {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by Gin route, method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_total",
+			Help:      "Money movements by transaction type and outcome (ok, rejected or failed).",
+		}, []string{"trx_type", "outcome"}),
+		transactionAmount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_amount_minor_total",
+			Help:      "Sum of successful money movements in minor units, by transaction type and currency.",
+		}, []string{"trx_type", "currency"}),
+		insufficientFunds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "insufficient_funds_total",
+			Help:      "Money movements rejected for insufficient funds, by transaction type.",
+		}, []string{"trx_type"}),
+		lockContention: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "lock_contention_total",
+			Help:      "Row locks that could not be taken, e.g. deadlocks or lock timeouts, by table, on reads for update and on writes.",
+		}, []string{"table"}),
+		dbTxDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_transaction_duration_seconds",
+			Help:      "Duration of db transactions from begin to commit or rollback, by outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"outcome"}),
+		dbTxRollbacks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_transaction_rollbacks_total",
+			Help:      "Db transactions rolled back, including failed commits.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequestDuration,
+		m.transactions,
+		m.transactionAmount,
+		m.insufficientFunds,
+		m.lockContention,
+		m.dbTxDuration,
+		m.dbTxRollbacks,
+	)
+	if db != nil {
+		sqlDb, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		m.registry.MustRegister(collectors.NewDBStatsCollector(sqlDb, namespace))
+	}
+	return m, nil
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Registry is exposed for tests.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// ObserveHttpRequest records one request; route is the Gin route pattern, not the request path, so
+// wallet ids do not become labels.
+func (m *Metrics) ObserveHttpRequest(method string, route string, status int, duration time.Duration) {
+	m.httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/repo"
+
+	"gorm.io/gorm"
+)
+
+// walletRepo counts wallet row locks that could not be taken: deadlocks, lock timeouts and a busy
+// SQLite database.
+type walletRepo struct {
+	repo.IWalletRepo
+	metrics *Metrics
+}
+
+func NewWalletRepo(metrics *Metrics, next repo.IWalletRepo) repo.IWalletRepo {
+	return &walletRepo{IWalletRepo: next, metrics: metrics}
+}
+
+func (r *walletRepo) BindTx(tx *gorm.DB) repo.IWalletRepo {
+	return &walletRepo{IWalletRepo: r.IWalletRepo.BindTx(tx), metrics: r.metrics}
+}
+
+func (r *walletRepo) FindWalletByIdWithTx(walletId string, tx *gorm.DB) (entity.WalletEntity, error) {
+	wallet, err := r.IWalletRepo.FindWalletByIdWithTx(walletId, tx)
+	r.metrics.observeLock(entity.WalletEntity{}.TableName(), err)
+	return wallet, err
+}
+
+// fxQuoteRepo counts fx quote row locks that could not be taken.
+type fxQuoteRepo struct {
+	repo.IFxQuoteRepo
+	metrics *Metrics
+}
+
+func NewFxQuoteRepo(metrics *Metrics, next repo.IFxQuoteRepo) repo.IFxQuoteRepo {
+	return &fxQuoteRepo{IFxQuoteRepo: next, metrics: metrics}
+}
+
+func (r *fxQuoteRepo) FindFxQuoteByIdWithTx(quoteId string, tx *gorm.DB) (entity.FxQuoteEntity, error) {
+	quote, err := r.IFxQuoteRepo.FindFxQuoteByIdWithTx(quoteId, tx)
+	r.metrics.observeLock(entity.FxQuoteEntity{}.TableName(), err)
+	return quote, err
+}
+
+// trxRepo counts transaction row writes that could not take their locks.
+type trxRepo struct {
+	repo.ITrxRepo
+	metrics *Metrics
+}
+
+func NewTrxRepo(metrics *Metrics, next repo.ITrxRepo) repo.ITrxRepo {
+	return &trxRepo{ITrxRepo: next, metrics: metrics}
+}
+
+func (r *trxRepo) BindTx(tx *gorm.DB) repo.ITrxRepo {
+	return &trxRepo{ITrxRepo: r.ITrxRepo.BindTx(tx), metrics: r.metrics}
+}
+
+func (r *trxRepo) SaveTrxWithDbTx(trx entity.TrxEntity, dbTx *gorm.DB) error {
+	err := r.ITrxRepo.SaveTrxWithDbTx(trx, dbTx)
+	r.metrics.observeLock(entity.TrxEntity{}.TableName(), err)
+	return err
+}
+
+func (r *trxRepo) SaveTrxsWithDbTx(trxs []entity.TrxEntity, dbTx *gorm.DB) error {
+	err := r.ITrxRepo.SaveTrxsWithDbTx(trxs, dbTx)
+	r.metrics.observeLock(entity.TrxEntity{}.TableName(), err)
+	return err
+}
+
+// ledgerRepo counts ledger account and entry writes that could not take their locks.
+type ledgerRepo struct {
+	repo.ILedgerRepo
+	metrics *Metrics
+}
+
+func NewLedgerRepo(metrics *Metrics, next repo.ILedgerRepo) repo.ILedgerRepo {
+	return &ledgerRepo{ILedgerRepo: next, metrics: metrics}
+}
+
+func (r *ledgerRepo) EnsureLedgerAccountWithTx(account entity.LedgerAccountEntity, tx *gorm.DB) (bool, error) {
+	created, err := r.ILedgerRepo.EnsureLedgerAccountWithTx(account, tx)
+	r.metrics.observeLock(entity.LedgerAccountEntity{}.TableName(), err)
+	return created, err
+}
+
+func (r *ledgerRepo) SaveLedgerEntriesWithTx(entries []entity.LedgerEntryEntity, tx *gorm.DB) error {
+	err := r.ILedgerRepo.SaveLedgerEntriesWithTx(entries, tx)
+	r.metrics.observeLock(entity.LedgerEntryEntity{}.TableName(), err)
+	return err
+}
+
+// holdRepo counts hold reads and writes in a transaction that could not take their locks.
+type holdRepo struct {
+	repo.IHoldRepo
+	metrics *Metrics
+}
+
+func NewHoldRepo(metrics *Metrics, next repo.IHoldRepo) repo.IHoldRepo {
+	return &holdRepo{IHoldRepo: next, metrics: metrics}
+}
+
+func (r *holdRepo) FindHoldByIdWithTx(holdId string, tx *gorm.DB) (entity.HoldEntity, error) {
+	hold, err := r.IHoldRepo.FindHoldByIdWithTx(holdId, tx)
+	r.metrics.observeLock(entity.HoldEntity{}.TableName(), err)
+	return hold, err
+}
+
+func (r *holdRepo) SaveHoldWithTx(hold entity.HoldEntity, tx *gorm.DB) error {
+	err := r.IHoldRepo.SaveHoldWithTx(hold, tx)
+	r.metrics.observeLock(entity.HoldEntity{}.TableName(), err)
+	return err
+}
+
+func (m *Metrics) observeLock(table string, err error) {
+	if appdb.IsRetryable(err) {
+		m.lockContention.WithLabelValues(table).Inc()
+	}
+}
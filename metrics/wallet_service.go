@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+)
+
+const (
+	outcomeOk       = "ok"
+	outcomeRejected = "rejected" // 4xx, e.g. insufficient funds or a frozen wallet
+	outcomeFailed   = "failed"   // 5xx
+)
+
+// walletService counts the money movements going through the wrapped service. Every other method
+// is passed through untouched by the embedded interface.
+type walletService struct {
+	service.IWalletService
+	metrics *Metrics
+}
+
+func NewWalletService(metrics *Metrics, next service.IWalletService) service.IWalletService {
+	return &walletService{IWalletService: next, metrics: metrics}
+}
+
+func (s *walletService) DepositMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper {
+	return s.observe(common.TrxTypeDeposit, s.IWalletService.DepositMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) WithdrawMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper {
+	return s.observe(common.TrxTypeWithdrawal, s.IWalletService.WithdrawMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) TransferMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TransferReq, idempotencyKey string) response.ResonseWrapper {
+	return s.observe(common.TrxTypeTransferOut, s.IWalletService.TransferMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) ReverseTransfer(ctx context.Context, principal auth.Principal, groupId string, req request.ReverseTransferReq, idempotencyKey string) response.ResonseWrapper {
+	return s.observe(common.TrxTypeReversalOut, s.IWalletService.ReverseTransfer(ctx, principal, groupId, req, idempotencyKey))
+}
+
+// CaptureHold is a withdrawal, or a transfer when the capture names a counterparty.
+func (s *walletService) CaptureHold(ctx context.Context, principal auth.Principal, holdId string, req request.CaptureHoldReq) response.ResonseWrapper {
+	trxType := common.TrxTypeWithdrawal
+	if req.CounterpartyWalletId != "" {
+		trxType = common.TrxTypeTransferOut
+	}
+	return s.observe(trxType, s.IWalletService.CaptureHold(ctx, principal, holdId, req))
+}
+
+func (s *walletService) AdjustBalance(ctx context.Context, principal auth.Principal, walletId string, req request.BalanceAdjustmentReq) response.ResonseWrapper {
+	trxType := common.TrxTypeAdjustmentIn
+	if req.Direction == string(common.EntryDirectionDebit) {
+		trxType = common.TrxTypeAdjustmentOut
+	}
+	res := s.IWalletService.AdjustBalance(ctx, principal, walletId, req)
+	s.count(trxType, res.Err)
+	if adjustment, ok := res.Data.(response.BalanceAdjustmentResponse); ok && res.Err.Code == 0 {
+		s.addAmount(trxType, adjustment.Currency, adjustment.Amount)
+	}
+	return res
+}
+
+// observe counts a movement answered with a TrxResponse, and its fee as a fee movement.
+func (s *walletService) observe(trxType common.TrxType, res response.ResonseWrapper) response.ResonseWrapper {
+	s.count(trxType, res.Err)
+	if trx, ok := res.Data.(response.TrxResponse); ok && res.Err.Code == 0 {
+		s.addAmount(trxType, trx.Currency, trx.Amount)
+		if trx.Fee > 0 {
+			s.metrics.transactions.WithLabelValues(string(common.TrxTypeFee), outcomeOk).Inc()
+			s.addAmount(common.TrxTypeFee, trx.Currency, trx.Fee)
+		}
+	}
+	return res
+}
+
+func (s *walletService) count(trxType common.TrxType, appErr apperror.AppError) {
+	outcome := outcomeOk
+	switch {
+	case appErr.Code >= 500:
+		outcome = outcomeFailed
+	case appErr.Code != 0:
+		outcome = outcomeRejected
+	}
+	s.metrics.transactions.WithLabelValues(string(trxType), outcome).Inc()
+	if appErr.Message == apperror.ErrInsufficientAmount.Message || appErr.Message == apperror.ErrReversalInsufficientFunds.Message {
+		s.metrics.insufficientFunds.WithLabelValues(string(trxType)).Inc()
+	}
+}
+
+func (s *walletService) addAmount(trxType common.TrxType, currency string, amount uint) {
+	s.metrics.transactionAmount.WithLabelValues(string(trxType), currency).Add(float64(amount))
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const principalKey = "principal"
+
+// Authenticate rejects requests without a valid bearer token and stores the caller for the controllers.
+func Authenticate(log *logrus.Logger, verifier auth.IJwtVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			log.Error("Missing bearer token")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ResonseWrapper{Err: apperror.ErrUnauthorized})
+			return
+		}
+
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			log.Error("Invalid bearer token; ", err)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.ResonseWrapper{Err: apperror.ErrUnauthorized})
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Next()
+	}
+}
+
+func GetPrincipal(c *gin.Context) auth.Principal {
+	if principal, ok := c.Get(principalKey); ok {
+		return principal.(auth.Principal)
+	}
+	return auth.Principal{}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"time"
+
+	"wallet-app/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests no route matched, so unknown paths do not become labels.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the latency and status of every request by its route pattern.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		m.ObserveHttpRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
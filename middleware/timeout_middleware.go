@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"wallet-app/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Timeout puts a deadline on the request context, the one configured for the route or the default.
+// The service begins its db transaction under that context, so a request past its deadline, or one
+// whose client went away, stops waiting for locks and rolls back.
+func Timeout(cfg config.TimeoutsConfig) gin.HandlerFunc {
+	timeouts := make(map[string]time.Duration, len(cfg.Endpoints))
+	for _, endpoint := range cfg.Endpoints {
+		timeouts[endpoint.Route] = endpoint.Timeout
+	}
+	return func(c *gin.Context) {
+		timeout, ok := timeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = cfg.Default
+		}
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"wallet-app/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing starts a server span for every request, continuing the caller's trace when the request
+// carries a W3C traceparent header, and puts it in the request context the controllers pass on.
+// Prometheus scrapes are left out.
+func Tracing(serviceName string, provider trace.TracerProvider) gin.HandlerFunc {
+	return otelgin.Middleware(serviceName,
+		otelgin.WithTracerProvider(provider),
+		otelgin.WithPropagators(tracing.Propagator()),
+		otelgin.WithGinFilter(func(c *gin.Context) bool { return c.FullPath() != "/metrics" }),
+	)
+}
@@ -0,0 +1,83 @@
+package openapi
+
+import "wallet-app/apperror"
+
+// appErrors lists every apperror value by name. Each one is published in the components' examples,
+// which the operations that can answer with it refer to. Keep it in step with the apperror package.
+var appErrors = []namedAppError{
+	{"ErrWalletIdNotFound", apperror.ErrWalletIdNotFound},
+	{"ErrIncompatibleRequest", apperror.ErrIncompatibleRequest},
+	{"ErrUnauthorized", apperror.ErrUnauthorized},
+	{"ErrForbidden", apperror.ErrForbidden},
+	{"ErrUserNotFound", apperror.ErrUserNotFound},
+	{"ErrWalletNotFound", apperror.ErrWalletNotFound},
+	{"ErrCounterpartyWalletNotFound", apperror.ErrCounterpartyWalletNotFound},
+	{"ErrCounterpartyWalletCannotBeSameAsUserWallet", apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet},
+	{"ErrInsufficientAmount", apperror.ErrInsufficientAmount},
+	{"ErrUnsupportedCurrency", apperror.ErrUnsupportedCurrency},
+	{"ErrInvalidTrxFilter", apperror.ErrInvalidTrxFilter},
+	{"ErrInvalidCursor", apperror.ErrInvalidCursor},
+	{"ErrFxQuoteRequired", apperror.ErrFxQuoteRequired},
+	{"ErrFxQuoteNotFound", apperror.ErrFxQuoteNotFound},
+	{"ErrFxQuoteMismatch", apperror.ErrFxQuoteMismatch},
+	{"ErrFxQuoteExpired", apperror.ErrFxQuoteExpired},
+	{"ErrFxQuoteAlreadyUsed", apperror.ErrFxQuoteAlreadyUsed},
+	{"ErrFxRateNotAvailable", apperror.ErrFxRateNotAvailable},
+	{"ErrFxAmountTooSmall", apperror.ErrFxAmountTooSmall},
+	{"ErrHoldNotFound", apperror.ErrHoldNotFound},
+	{"ErrHoldNotActive", apperror.ErrHoldNotActive},
+	{"ErrHoldExpired", apperror.ErrHoldExpired},
+	{"ErrHoldCaptureExceedsAmount", apperror.ErrHoldCaptureExceedsAmount},
+	{"ErrInvalidHoldExpiry", apperror.ErrInvalidHoldExpiry},
+	{"ErrTransferNotFound", apperror.ErrTransferNotFound},
+	{"ErrTransferAlreadyReversed", apperror.ErrTransferAlreadyReversed},
+	{"ErrReversalExceedsTransfer", apperror.ErrReversalExceedsTransfer},
+	{"ErrReversalInsufficientFunds", apperror.ErrReversalInsufficientFunds},
+	{"ErrScheduleNotFound", apperror.ErrScheduleNotFound},
+	{"ErrInvalidScheduleRule", apperror.ErrInvalidScheduleRule},
+	{"ErrScheduleCrossCurrency", apperror.ErrScheduleCrossCurrency},
+	{"ErrScheduleCancelled", apperror.ErrScheduleCancelled},
+	{"ErrWebhookSubscriptionNotFound", apperror.ErrWebhookSubscriptionNotFound},
+	{"ErrWebhookDeliveryNotFound", apperror.ErrWebhookDeliveryNotFound},
+	{"ErrInvalidWebhookUrl", apperror.ErrInvalidWebhookUrl},
+	{"ErrInvalidWebhookEventType", apperror.ErrInvalidWebhookEventType},
+	{"ErrSpendingLimitExceeded", apperror.ErrSpendingLimitExceeded},
+	{"ErrInvalidSpendingLimit", apperror.ErrInvalidSpendingLimit},
+	{"ErrFeeRuleNotFound", apperror.ErrFeeRuleNotFound},
+	{"ErrInvalidFeeRule", apperror.ErrInvalidFeeRule},
+	{"ErrFeeRuleConflict", apperror.ErrFeeRuleConflict},
+	{"ErrFeeRevenueWalletNotConfigured", apperror.ErrFeeRevenueWalletNotConfigured},
+	{"ErrWalletFrozen", apperror.ErrWalletFrozen},
+	{"ErrWalletClosed", apperror.ErrWalletClosed},
+	{"ErrCounterpartyWalletFrozen", apperror.ErrCounterpartyWalletFrozen},
+	{"ErrCounterpartyWalletClosed", apperror.ErrCounterpartyWalletClosed},
+	{"ErrInvalidWalletStatus", apperror.ErrInvalidWalletStatus},
+	{"ErrInvalidWalletStatusReason", apperror.ErrInvalidWalletStatusReason},
+	{"ErrWalletStatusTransitionNotAllowed", apperror.ErrWalletStatusTransitionNotAllowed},
+	{"ErrWalletBalanceNotZero", apperror.ErrWalletBalanceNotZero},
+	{"ErrWalletHasActiveHolds", apperror.ErrWalletHasActiveHolds},
+	{"ErrInvalidSweepWallet", apperror.ErrInvalidSweepWallet},
+	{"ErrInvalidBalanceAdjustment", apperror.ErrInvalidBalanceAdjustment},
+	{"ErrInvalidStatementRange", apperror.ErrInvalidStatementRange},
+	{"ErrInvalidStatementFormat", apperror.ErrInvalidStatementFormat},
+	{"ErrInvalidIdempotencyKey", apperror.ErrInvalidIdempotencyKey},
+	{"ErrIdempotencyKeyConflict", apperror.ErrIdempotencyKeyConflict},
+	{"ErrUnbalancedJournal", apperror.ErrUnbalancedJournal},
+	{"ErrInternalServer", apperror.ErrInternalServer},
+	{"ErrWalletBusy", apperror.ErrWalletBusy},
+	{"ErrRequestTimeout", apperror.ErrRequestTimeout},
+}
+
+type namedAppError struct {
+	name string
+	err  apperror.AppError
+}
+
+// appErrorNames finds the name of an apperror value by its message, which is unique.
+var appErrorNames = func() map[string]string {
+	names := map[string]string{}
+	for _, e := range appErrors {
+		names[e.err.Message] = e.name
+	}
+	return names
+}()
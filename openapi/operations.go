@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"wallet-app/apperror"
+	"wallet-app/request"
+	"wallet-app/response"
+)
+
+// operation describes one route of route.InitRoutes. Body and query are the request structs the
+// controller binds, data is what the service puts in ResonseWrapper.Data.
+type operation struct {
+	method      string
+	path        string // gin syntax, e.g. /wallets/:walletId/deposit
+	operationId string
+	tag         string
+	summary     string
+	body        any
+	query       any
+	queryParams []Parameter // query values read without binding a struct
+	data        any
+	rawContent  []string            // content types of a response that is not a ResonseWrapper
+	idempotent  bool                // accepts an Idempotency-Key header
+	public      bool                // no bearer token needed
+	errors      []apperror.AppError // what the service can answer with, besides ErrUnauthorized, ErrInternalServer, ErrWalletBusy and ErrRequestTimeout
+}
+
+const (
+	tagWallets      = "Wallets"
+	tagTransactions = "Transactions"
+	tagHolds        = "Holds"
+	tagSchedules    = "Schedules"
+	tagWebhooks     = "Webhooks"
+	tagAdmin        = "Admin"
+	tagDocs         = "Docs"
+)
+
+var tags = []string{tagWallets, tagTransactions, tagHolds, tagSchedules, tagWebhooks, tagAdmin, tagDocs}
+
+var operations = []operation{
+	{method: "POST", path: "/wallets", operationId: "CreateWallet", tag: tagWallets, summary: "Create a wallet for the caller, or for userId when called by an admin",
+		body: request.CreateWalletReq{}, data: response.WalletResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrUnsupportedCurrency}},
+	{method: "GET", path: "/wallets/user/:userId", operationId: "GetWalletsByUserId", tag: tagWallets, summary: "List a user's wallets",
+		data:   []response.WalletResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden}},
+	{method: "POST", path: "/wallets/:walletId/deposit", operationId: "DepositMoney", tag: tagTransactions, summary: "Deposit money into a wallet",
+		body: request.TrxReq{}, data: response.TrxResponse{}, idempotent: true,
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrIdempotencyKeyConflict, apperror.ErrUnbalancedJournal, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/withdraw", operationId: "WithdrawMoney", tag: tagTransactions, summary: "Withdraw money from a wallet",
+		body: request.TrxReq{}, data: response.TrxResponse{}, idempotent: true,
+		errors: []apperror.AppError{apperror.ErrFeeRevenueWalletNotConfigured, apperror.ErrForbidden, apperror.ErrIdempotencyKeyConflict, apperror.ErrInsufficientAmount, apperror.ErrSpendingLimitExceeded, apperror.ErrUnbalancedJournal, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/transfer", operationId: "TransferMoney", tag: tagTransactions, summary: "Transfer money to another wallet, with a quote when the currencies differ",
+		body: request.TransferReq{}, data: response.TrxResponse{}, idempotent: true,
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet, apperror.ErrCounterpartyWalletClosed, apperror.ErrCounterpartyWalletFrozen, apperror.ErrCounterpartyWalletNotFound, apperror.ErrFeeRevenueWalletNotConfigured, apperror.ErrForbidden, apperror.ErrFxQuoteAlreadyUsed, apperror.ErrFxQuoteExpired, apperror.ErrFxQuoteMismatch, apperror.ErrFxQuoteNotFound, apperror.ErrFxQuoteRequired, apperror.ErrIdempotencyKeyConflict, apperror.ErrInsufficientAmount, apperror.ErrSpendingLimitExceeded, apperror.ErrUnbalancedJournal, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/transfer/quote", operationId: "QuoteTransfer", tag: tagTransactions, summary: "Quote a transfer between currencies",
+		body: request.TransferQuoteReq{}, data: response.TransferQuoteResponse{},
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet, apperror.ErrCounterpartyWalletNotFound, apperror.ErrForbidden, apperror.ErrFxAmountTooSmall, apperror.ErrFxRateNotAvailable, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/fees/preview", operationId: "PreviewFees", tag: tagTransactions, summary: "Preview the fees of a withdrawal or transfer",
+		body: request.FeePreviewReq{}, data: response.FeePreviewResponse{},
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletNotFound, apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/balance", operationId: "GetBalance", tag: tagWallets, summary: "Get a wallet's current and available balance",
+		data:   response.WalletResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/transactions", operationId: "GetTransactions", tag: tagTransactions, summary: "List a wallet's transactions, newest first, one page at a time",
+		query: request.TrxHistoryReq{}, data: response.TrxHistoryResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidCursor, apperror.ErrInvalidTrxFilter, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/statements", operationId: "ExportStatement", tag: tagTransactions, summary: "Download a statement for a period",
+		query: request.StatementReq{}, rawContent: []string{"text/csv", "application/x-ndjson", "application/xml"},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidStatementFormat, apperror.ErrInvalidStatementRange, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/holds", operationId: "CreateHold", tag: tagHolds, summary: "Reserve funds on a wallet",
+		body: request.CreateHoldReq{}, data: response.HoldResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInsufficientAmount, apperror.ErrInvalidHoldExpiry, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/holds", operationId: "GetHolds", tag: tagHolds, summary: "List a wallet's holds",
+		data:   []response.HoldResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/limits", operationId: "GetSpendingLimits", tag: tagWallets, summary: "Get a wallet's spending limits and what is spent against them",
+		data:   response.SpendingLimitsResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "PUT", path: "/admin/wallets/:walletId/limits", operationId: "SetSpendingLimits", tag: tagAdmin, summary: "Override a wallet's spending limits",
+		body: request.SpendingLimitReq{}, data: response.SpendingLimitsResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidSpendingLimit, apperror.ErrWalletNotFound}},
+	{method: "DELETE", path: "/admin/wallets/:walletId/limits", operationId: "DeleteSpendingLimits", tag: tagAdmin, summary: "Drop a wallet's spending limit override",
+		data:   response.SpendingLimitsResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/admin/wallets/:walletId/status", operationId: "ChangeWalletStatus", tag: tagAdmin, summary: "Freeze, unfreeze or close a wallet",
+		body: request.WalletStatusReq{}, data: response.WalletStatusChangeResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidSweepWallet, apperror.ErrInvalidWalletStatus, apperror.ErrInvalidWalletStatusReason, apperror.ErrUnbalancedJournal, apperror.ErrWalletBalanceNotZero, apperror.ErrWalletHasActiveHolds, apperror.ErrWalletNotFound, apperror.ErrWalletStatusTransitionNotAllowed}},
+	{method: "GET", path: "/admin/wallets/:walletId/status", operationId: "GetWalletStatusChanges", tag: tagAdmin, summary: "List a wallet's status changes",
+		data:   []response.WalletStatusChangeResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/wallets/:walletId/schedules", operationId: "CreateSchedule", tag: tagSchedules, summary: "Schedule a recurring transfer",
+		body: request.CreateScheduleReq{}, data: response.ScheduleResponse{},
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet, apperror.ErrCounterpartyWalletNotFound, apperror.ErrForbidden, apperror.ErrInvalidScheduleRule, apperror.ErrScheduleCrossCurrency, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/schedules", operationId: "GetSchedules", tag: tagSchedules, summary: "List a wallet's schedules",
+		data:   []response.ScheduleResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/schedules/:scheduleId", operationId: "GetSchedule", tag: tagSchedules, summary: "Get a schedule",
+		data:   response.ScheduleResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrScheduleNotFound, apperror.ErrWalletNotFound}},
+	{method: "PUT", path: "/wallets/:walletId/schedules/:scheduleId", operationId: "UpdateSchedule", tag: tagSchedules, summary: "Change, pause or resume a schedule",
+		body: request.UpdateScheduleReq{}, data: response.ScheduleResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrIncompatibleRequest, apperror.ErrInvalidScheduleRule, apperror.ErrScheduleCancelled, apperror.ErrScheduleNotFound, apperror.ErrWalletNotFound}},
+	{method: "DELETE", path: "/wallets/:walletId/schedules/:scheduleId", operationId: "CancelSchedule", tag: tagSchedules, summary: "Cancel a schedule",
+		data:   response.ScheduleResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrScheduleNotFound, apperror.ErrWalletNotFound}},
+	{method: "GET", path: "/wallets/:walletId/schedules/:scheduleId/runs", operationId: "GetScheduleRuns", tag: tagSchedules, summary: "List the runs of a schedule",
+		data:   []response.ScheduleRunResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrScheduleNotFound, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/transfers/:groupId/reverse", operationId: "ReverseTransfer", tag: tagTransactions, summary: "Reverse a transfer in full or in part",
+		body: request.ReverseTransferReq{}, data: response.TrxResponse{}, idempotent: true,
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletClosed, apperror.ErrCounterpartyWalletFrozen, apperror.ErrCounterpartyWalletNotFound, apperror.ErrForbidden, apperror.ErrFxAmountTooSmall, apperror.ErrIdempotencyKeyConflict, apperror.ErrInsufficientAmount, apperror.ErrReversalExceedsTransfer, apperror.ErrReversalInsufficientFunds, apperror.ErrTransferAlreadyReversed, apperror.ErrTransferNotFound, apperror.ErrUnbalancedJournal, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/holds/:holdId/capture", operationId: "CaptureHold", tag: tagHolds, summary: "Capture a hold into a withdrawal or transfer",
+		body: request.CaptureHoldReq{}, data: response.TrxResponse{},
+		errors: []apperror.AppError{apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet, apperror.ErrCounterpartyWalletClosed, apperror.ErrCounterpartyWalletFrozen, apperror.ErrCounterpartyWalletNotFound, apperror.ErrFeeRevenueWalletNotConfigured, apperror.ErrForbidden, apperror.ErrFxQuoteAlreadyUsed, apperror.ErrFxQuoteExpired, apperror.ErrFxQuoteMismatch, apperror.ErrFxQuoteNotFound, apperror.ErrFxQuoteRequired, apperror.ErrHoldCaptureExceedsAmount, apperror.ErrHoldExpired, apperror.ErrHoldNotActive, apperror.ErrHoldNotFound, apperror.ErrInsufficientAmount, apperror.ErrSpendingLimitExceeded, apperror.ErrUnbalancedJournal, apperror.ErrWalletClosed, apperror.ErrWalletFrozen, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/holds/:holdId/release", operationId: "ReleaseHold", tag: tagHolds, summary: "Release a hold",
+		data:   response.HoldResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrHoldExpired, apperror.ErrHoldNotActive, apperror.ErrHoldNotFound, apperror.ErrWalletNotFound}},
+	{method: "POST", path: "/webhooks", operationId: "CreateWebhookSubscription", tag: tagWebhooks, summary: "Subscribe a url to events; the secret is only returned here",
+		body: request.CreateWebhookSubscriptionReq{}, data: response.WebhookSubscriptionResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidWebhookEventType, apperror.ErrInvalidWebhookUrl}},
+	{method: "GET", path: "/webhooks", operationId: "GetWebhookSubscriptions", tag: tagWebhooks, summary: "List webhook subscriptions",
+		data:   []response.WebhookSubscriptionResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden}},
+	{method: "GET", path: "/webhooks/:subscriptionId", operationId: "GetWebhookSubscription", tag: tagWebhooks, summary: "Get a webhook subscription",
+		data:   response.WebhookSubscriptionResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWebhookSubscriptionNotFound}},
+	{method: "PUT", path: "/webhooks/:subscriptionId", operationId: "UpdateWebhookSubscription", tag: tagWebhooks, summary: "Change a webhook subscription",
+		body: request.UpdateWebhookSubscriptionReq{}, data: response.WebhookSubscriptionResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrInvalidWebhookEventType, apperror.ErrInvalidWebhookUrl, apperror.ErrWebhookSubscriptionNotFound}},
+	{method: "DELETE", path: "/webhooks/:subscriptionId", operationId: "DeleteWebhookSubscription", tag: tagWebhooks, summary: "Delete a webhook subscription",
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWebhookSubscriptionNotFound}},
+	{method: "GET", path: "/webhooks/:subscriptionId/deliveries", operationId: "GetWebhookDeliveries", tag: tagWebhooks, summary: "List a subscription's deliveries",
+		queryParams: []Parameter{{Name: "status", In: "query", Description: "only deliveries in this status", Schema: &Schema{Type: "string"}}},
+		data:        []response.WebhookDeliveryResponse{},
+		errors:      []apperror.AppError{apperror.ErrForbidden, apperror.ErrIncompatibleRequest, apperror.ErrWebhookSubscriptionNotFound}},
+	{method: "POST", path: "/webhook-deliveries/:deliveryId/redeliver", operationId: "RedeliverWebhook", tag: tagWebhooks, summary: "Queue a delivery again",
+		data:   response.WebhookDeliveryResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrWebhookDeliveryNotFound}},
+	{method: "POST", path: "/admin/fee-rules", operationId: "CreateFeeRule", tag: tagAdmin, summary: "Create a fee rule",
+		body: request.FeeRuleReq{}, data: response.FeeRuleResponse{},
+		errors: []apperror.AppError{apperror.ErrFeeRevenueWalletNotConfigured, apperror.ErrFeeRuleConflict, apperror.ErrForbidden, apperror.ErrInvalidFeeRule, apperror.ErrUnsupportedCurrency}},
+	{method: "GET", path: "/admin/fee-rules", operationId: "GetFeeRules", tag: tagAdmin, summary: "List fee rules",
+		data:   []response.FeeRuleResponse{},
+		errors: []apperror.AppError{apperror.ErrForbidden}},
+	{method: "GET", path: "/admin/fee-rules/:feeRuleId", operationId: "GetFeeRule", tag: tagAdmin, summary: "Get a fee rule",
+		data:   response.FeeRuleResponse{},
+		errors: []apperror.AppError{apperror.ErrFeeRuleNotFound, apperror.ErrForbidden}},
+	{method: "PUT", path: "/admin/fee-rules/:feeRuleId", operationId: "UpdateFeeRule", tag: tagAdmin, summary: "Replace a fee rule",
+		body: request.FeeRuleReq{}, data: response.FeeRuleResponse{},
+		errors: []apperror.AppError{apperror.ErrFeeRevenueWalletNotConfigured, apperror.ErrFeeRuleConflict, apperror.ErrFeeRuleNotFound, apperror.ErrForbidden, apperror.ErrInvalidFeeRule, apperror.ErrUnsupportedCurrency}},
+	{method: "DELETE", path: "/admin/fee-rules/:feeRuleId", operationId: "DeleteFeeRule", tag: tagAdmin, summary: "Delete a fee rule",
+		errors: []apperror.AppError{apperror.ErrFeeRuleNotFound, apperror.ErrForbidden}},
+	{method: "POST", path: "/admin/reconciliation", operationId: "ReconcileWallets", tag: tagAdmin, summary: "Check cached balances against the transactions, and optionally repair them",
+		body: request.ReconcileReq{}, data: response.ReconciliationReport{},
+		errors: []apperror.AppError{apperror.ErrForbidden, apperror.ErrUnbalancedJournal}},
+	{method: "DELETE", path: "/delete-all", operationId: "DeleteAll", tag: tagAdmin, summary: "Delete every wallet and all of its data, in one db transaction",
+		errors: []apperror.AppError{apperror.ErrForbidden}},
+	{method: "GET", path: "/openapi.json", operationId: "GetOpenApiSpec", tag: tagDocs, summary: "This document",
+		rawContent: []string{"application/json"}, public: true},
+	{method: "GET", path: "/docs", operationId: "GetSwaggerUi", tag: tagDocs, summary: "Swagger UI for this document",
+		rawContent: []string{"text/html"}, public: true},
+}
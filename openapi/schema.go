@@ -0,0 +1,158 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry turns Go types into schemas, adding every named struct to the components once and
+// referring to it from then on.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: map[string]*Schema{}}
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Pointer:
+		schema := *r.schemaOf(t.Elem())
+		if schema.Ref != "" { // siblings of $ref are ignored in OpenAPI 3.0
+			return &Schema{Ref: schema.Ref}
+		}
+		schema.Nullable = true
+		return &schema
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Int, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: ptr(0.0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		return r.ref(t)
+	}
+	return &Schema{} // interface{}: any value
+}
+
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	name := t.Name()
+	if _, ok := r.schemas[name]; !ok {
+		r.schemas[name] = &Schema{} // placeholder, for types that refer to themselves
+		r.schemas[name] = r.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema describes a struct the way encoding/json writes it and gin binds it.
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range jsonFields(t) {
+		property := r.schemaOf(field.Type)
+		applyBinding(property, field.Tag.Get("binding"))
+		schema.Properties[jsonName(field)] = property
+		if isRequired(field) {
+			schema.Required = append(schema.Required, jsonName(field))
+		}
+	}
+	return schema
+}
+
+// jsonFields lists the exported fields of t, with embedded structs flattened.
+func jsonFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get("json") == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			fields = append(fields, jsonFields(field.Type)...)
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func jsonName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" {
+		return name
+	}
+	return field.Name
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBinding carries the oneof, min and max rules of a gin binding tag over to the schema.
+func applyBinding(schema *Schema, binding string) {
+	for _, rule := range strings.Split(binding, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				schema.Enum = append(schema.Enum, option)
+			}
+		case "min":
+			if bound, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Minimum = &bound
+			}
+		case "max":
+			if bound, err := strconv.ParseFloat(value, 64); err == nil {
+				schema.Maximum = &bound
+			}
+		}
+	}
+}
+
+// queryParameters describes a struct bound with ShouldBindQuery.
+func (r *schemaRegistry) queryParameters(t reflect.Type) []Parameter {
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("form")
+		if name == "" || name == "-" {
+			continue
+		}
+		schema := r.schemaOf(field.Type)
+		schema.Nullable = false
+		applyBinding(schema, field.Tag.Get("binding"))
+		param := Parameter{Name: name, In: "query", Required: isRequired(field), Schema: schema}
+		if field.Type.Kind() == reflect.Slice {
+			param.Explode = ptr(true)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"wallet-app/apperror"
+)
+
+const bearerAuth = "bearerAuth"
+
+var (
+	specOnce sync.Once
+	spec     Document
+)
+
+// Spec returns the document, built on first use.
+func Spec() Document {
+	specOnce.Do(func() { spec = Build() })
+	return spec
+}
+
+func Build() Document {
+	schemas := newSchemaRegistry()
+	schemas.schemas["AppError"] = appErrorSchema()
+
+	doc := Document{
+		OpenApi: "3.0.3",
+		Info: Info{
+			Title:   "Wallet App",
+			Version: "1.0.0",
+			Description: "Every response except statements and the docs is a ResonseWrapper: Data on success, Err on failure. " +
+				"Amounts are in minor units of the wallet's currency, see Exponent.",
+		},
+		Security: []SecurityReq{{bearerAuth: {}}},
+		Paths:    map[string]PathItem{},
+	}
+	for _, tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	for _, op := range operations {
+		path := Path(op.path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = PathItem{}
+		}
+		doc.Paths[path][strings.ToLower(op.method)] = buildOperation(op, schemas)
+	}
+
+	doc.Components = Components{
+		Schemas:         schemas.schemas,
+		Examples:        map[string]*Example{},
+		SecuritySchemes: map[string]*SecurityScheme{bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"}},
+	}
+	for _, e := range appErrors {
+		doc.Components.Examples[e.name] = &Example{Summary: e.err.Message, Value: errorBody(e.err)}
+	}
+	return doc
+}
+
+// Path converts a gin path to OpenAPI syntax: /wallets/:walletId becomes /wallets/{walletId}.
+func Path(ginPath string) string {
+	segments := strings.Split(ginPath, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func buildOperation(op operation, schemas *schemaRegistry) *Operation {
+	operation := &Operation{
+		OperationId: op.operationId,
+		Summary:     op.summary,
+		Tags:        []string{op.tag},
+		Responses:   map[string]*Response{},
+	}
+	if op.public {
+		operation.Security = &[]SecurityReq{}
+	}
+
+	for _, segment := range strings.Split(op.path, "/") {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			operation.Parameters = append(operation.Parameters, Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+	if op.query != nil {
+		operation.Parameters = append(operation.Parameters, schemas.queryParameters(reflect.TypeOf(op.query))...)
+	}
+	operation.Parameters = append(operation.Parameters, op.queryParams...)
+	if op.idempotent {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        "Idempotency-Key",
+			In:          "header",
+			Description: "retries with the same key and body replay the first response",
+			Schema:      &Schema{Type: "string"},
+		})
+	}
+
+	if op.body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: schemas.schemaOf(reflect.TypeOf(op.body))}},
+		}
+	}
+
+	operation.Responses["200"] = successResponse(op, schemas)
+	if op.public {
+		return operation
+	}
+	errs := append([]apperror.AppError{apperror.ErrUnauthorized, apperror.ErrInternalServer, apperror.ErrWalletBusy, apperror.ErrRequestTimeout}, op.errors...)
+	if op.idempotent {
+		errs = append(errs, apperror.ErrInvalidIdempotencyKey)
+	}
+	byCode := map[int][]apperror.AppError{}
+	for _, appErr := range errs {
+		byCode[appErr.Code] = append(byCode[appErr.Code], appErr)
+	}
+	if op.body != nil || op.query != nil {
+		byCode[http.StatusBadRequest] = append(byCode[http.StatusBadRequest], apperror.AppError{}) // binding errors
+	}
+	for code, appErrs := range byCode {
+		operation.Responses[strconv.Itoa(code)] = errorResponse(code, appErrs)
+	}
+	return operation
+}
+
+func successResponse(op operation, schemas *schemaRegistry) *Response {
+	if len(op.rawContent) > 0 {
+		content := map[string]MediaType{}
+		for _, contentType := range op.rawContent {
+			schema := &Schema{Type: "string"}
+			if contentType == "application/json" {
+				schema = &Schema{Type: "object"}
+			}
+			content[contentType] = MediaType{Schema: schema}
+		}
+		return &Response{Description: "OK", Content: content}
+	}
+
+	data := &Schema{Nullable: true}
+	if op.data != nil {
+		data = schemas.schemaOf(reflect.TypeOf(op.data))
+	}
+	return &Response{
+		Description: "OK",
+		Content:     map[string]MediaType{"application/json": {Schema: wrapperSchema(data)}},
+	}
+}
+
+// errorResponse has an example for each of the operation's errors with the code. A zero AppError
+// stands for a failed binding, whose message comes from the validator.
+func errorResponse(code int, appErrs []apperror.AppError) *Response {
+	examples := map[string]*Example{}
+	var messages []string
+	for _, appErr := range appErrs {
+		if appErr.Code == 0 {
+			messages = append(messages, "invalid request")
+			continue
+		}
+		name := appErrorNames[appErr.Message]
+		examples[name] = &Example{Ref: "#/components/examples/" + name}
+		messages = append(messages, appErr.Message)
+	}
+	sort.Strings(messages)
+	return &Response{
+		Description: fmt.Sprintf("%s: %s", http.StatusText(code), strings.Join(messages, "; ")),
+		Content:     map[string]MediaType{"application/json": {Schema: wrapperSchema(&Schema{Nullable: true}), Examples: examples}},
+	}
+}
+
+func wrapperSchema(data *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"Data": data,
+			"Err":  {Ref: "#/components/schemas/AppError"},
+		},
+		Required: []string{"Data", "Err"},
+	}
+}
+
+// appErrorSchema is written by hand, as Message is one of the apperror messages (or a binding
+// error) and Details depends on the error.
+func appErrorSchema() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"Code":    {Type: "integer", Format: "int32", Description: "http status code; 0 on success"},
+			"Message": {Type: "string", Description: "one of the messages of the components' examples, or a request validation error"},
+			"Details": {Description: "machine-readable context, e.g. Window, Limit, Remaining, Currency and ResetsAt for spending limit exceeded"},
+		},
+		Required: []string{"Code", "Message"},
+	}
+}
+
+func errorBody(appErr apperror.AppError) map[string]any {
+	return map[string]any{"Data": nil, "Err": appErr}
+}
@@ -0,0 +1,4 @@
+// Package walletpb holds the protobuf messages and gRPC stubs of the wallet API.
+package walletpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative wallet.proto
@@ -0,0 +1,1116 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: wallet.proto
+
+package walletpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WalletService_CreateWallet_FullMethodName           = "/wallet.v1.WalletService/CreateWallet"
+	WalletService_GetWalletsByUserId_FullMethodName     = "/wallet.v1.WalletService/GetWalletsByUserId"
+	WalletService_DepositMoney_FullMethodName           = "/wallet.v1.WalletService/DepositMoney"
+	WalletService_WithdrawMoney_FullMethodName          = "/wallet.v1.WalletService/WithdrawMoney"
+	WalletService_TransferMoney_FullMethodName          = "/wallet.v1.WalletService/TransferMoney"
+	WalletService_QuoteTransfer_FullMethodName          = "/wallet.v1.WalletService/QuoteTransfer"
+	WalletService_PreviewFees_FullMethodName            = "/wallet.v1.WalletService/PreviewFees"
+	WalletService_ReverseTransfer_FullMethodName        = "/wallet.v1.WalletService/ReverseTransfer"
+	WalletService_GetBalance_FullMethodName             = "/wallet.v1.WalletService/GetBalance"
+	WalletService_GetTransactions_FullMethodName        = "/wallet.v1.WalletService/GetTransactions"
+	WalletService_StreamTransactions_FullMethodName     = "/wallet.v1.WalletService/StreamTransactions"
+	WalletService_ExportStatement_FullMethodName        = "/wallet.v1.WalletService/ExportStatement"
+	WalletService_CreateHold_FullMethodName             = "/wallet.v1.WalletService/CreateHold"
+	WalletService_CaptureHold_FullMethodName            = "/wallet.v1.WalletService/CaptureHold"
+	WalletService_ReleaseHold_FullMethodName            = "/wallet.v1.WalletService/ReleaseHold"
+	WalletService_GetHolds_FullMethodName               = "/wallet.v1.WalletService/GetHolds"
+	WalletService_ExpireHolds_FullMethodName            = "/wallet.v1.WalletService/ExpireHolds"
+	WalletService_GetSpendingLimits_FullMethodName      = "/wallet.v1.WalletService/GetSpendingLimits"
+	WalletService_SetSpendingLimits_FullMethodName      = "/wallet.v1.WalletService/SetSpendingLimits"
+	WalletService_DeleteSpendingLimits_FullMethodName   = "/wallet.v1.WalletService/DeleteSpendingLimits"
+	WalletService_ChangeWalletStatus_FullMethodName     = "/wallet.v1.WalletService/ChangeWalletStatus"
+	WalletService_GetWalletStatusChanges_FullMethodName = "/wallet.v1.WalletService/GetWalletStatusChanges"
+	WalletService_AdjustBalance_FullMethodName          = "/wallet.v1.WalletService/AdjustBalance"
+	WalletService_GetBalanceAdjustments_FullMethodName  = "/wallet.v1.WalletService/GetBalanceAdjustments"
+	WalletService_ReconcileWallets_FullMethodName       = "/wallet.v1.WalletService/ReconcileWallets"
+	WalletService_DeleteAll_FullMethodName              = "/wallet.v1.WalletService/DeleteAll"
+	WalletService_GetAllTrxs_FullMethodName             = "/wallet.v1.WalletService/GetAllTrxs"
+)
+
+// WalletServiceClient is the client API for WalletService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WalletServiceClient interface {
+	CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	GetWalletsByUserId(ctx context.Context, in *GetWalletsByUserIdRequest, opts ...grpc.CallOption) (*WalletList, error)
+	DepositMoney(ctx context.Context, in *TrxRequest, opts ...grpc.CallOption) (*Trx, error)
+	WithdrawMoney(ctx context.Context, in *TrxRequest, opts ...grpc.CallOption) (*Trx, error)
+	TransferMoney(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*Trx, error)
+	QuoteTransfer(ctx context.Context, in *TransferQuoteRequest, opts ...grpc.CallOption) (*TransferQuote, error)
+	PreviewFees(ctx context.Context, in *FeePreviewRequest, opts ...grpc.CallOption) (*FeePreview, error)
+	ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*Trx, error)
+	GetBalance(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*Wallet, error)
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionPage, error)
+	StreamTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transaction], error)
+	ExportStatement(ctx context.Context, in *ExportStatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error)
+	CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*Hold, error)
+	CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*Trx, error)
+	ReleaseHold(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*Hold, error)
+	GetHolds(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*HoldList, error)
+	ExpireHolds(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExpireHoldsResponse, error)
+	GetSpendingLimits(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*SpendingLimits, error)
+	SetSpendingLimits(ctx context.Context, in *SetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimits, error)
+	DeleteSpendingLimits(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*SpendingLimits, error)
+	ChangeWalletStatus(ctx context.Context, in *ChangeWalletStatusRequest, opts ...grpc.CallOption) (*WalletStatusChange, error)
+	GetWalletStatusChanges(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*WalletStatusChangeList, error)
+	AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error)
+	GetBalanceAdjustments(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*BalanceAdjustmentList, error)
+	ReconcileWallets(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error)
+	DeleteAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	GetAllTrxs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TransactionList, error)
+}
+
+type walletServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWalletServiceClient(cc grpc.ClientConnInterface) WalletServiceClient {
+	return &walletServiceClient{cc}
+}
+
+func (c *walletServiceClient) CreateWallet(ctx context.Context, in *CreateWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, WalletService_CreateWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetWalletsByUserId(ctx context.Context, in *GetWalletsByUserIdRequest, opts ...grpc.CallOption) (*WalletList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletList)
+	err := c.cc.Invoke(ctx, WalletService_GetWalletsByUserId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DepositMoney(ctx context.Context, in *TrxRequest, opts ...grpc.CallOption) (*Trx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trx)
+	err := c.cc.Invoke(ctx, WalletService_DepositMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) WithdrawMoney(ctx context.Context, in *TrxRequest, opts ...grpc.CallOption) (*Trx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trx)
+	err := c.cc.Invoke(ctx, WalletService_WithdrawMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) TransferMoney(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*Trx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trx)
+	err := c.cc.Invoke(ctx, WalletService_TransferMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) QuoteTransfer(ctx context.Context, in *TransferQuoteRequest, opts ...grpc.CallOption) (*TransferQuote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferQuote)
+	err := c.cc.Invoke(ctx, WalletService_QuoteTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) PreviewFees(ctx context.Context, in *FeePreviewRequest, opts ...grpc.CallOption) (*FeePreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeePreview)
+	err := c.cc.Invoke(ctx, WalletService_PreviewFees_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReverseTransfer(ctx context.Context, in *ReverseTransferRequest, opts ...grpc.CallOption) (*Trx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trx)
+	err := c.cc.Invoke(ctx, WalletService_ReverseTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalance(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, WalletService_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*TransactionPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionPage)
+	err := c.cc.Invoke(ctx, WalletService_GetTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) StreamTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Transaction], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], WalletService_StreamTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTransactionsRequest, Transaction]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_StreamTransactionsClient = grpc.ServerStreamingClient[Transaction]
+
+func (c *walletServiceClient) ExportStatement(ctx context.Context, in *ExportStatementRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatementChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[1], WalletService_ExportStatement_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportStatementRequest, StatementChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_ExportStatementClient = grpc.ServerStreamingClient[StatementChunk]
+
+func (c *walletServiceClient) CreateHold(ctx context.Context, in *CreateHoldRequest, opts ...grpc.CallOption) (*Hold, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, WalletService_CreateHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) CaptureHold(ctx context.Context, in *CaptureHoldRequest, opts ...grpc.CallOption) (*Trx, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Trx)
+	err := c.cc.Invoke(ctx, WalletService_CaptureHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReleaseHold(ctx context.Context, in *HoldIdRequest, opts ...grpc.CallOption) (*Hold, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Hold)
+	err := c.cc.Invoke(ctx, WalletService_ReleaseHold_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetHolds(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*HoldList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldList)
+	err := c.cc.Invoke(ctx, WalletService_GetHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExpireHolds(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ExpireHoldsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExpireHoldsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ExpireHolds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetSpendingLimits(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*SpendingLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingLimits)
+	err := c.cc.Invoke(ctx, WalletService_GetSpendingLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetSpendingLimits(ctx context.Context, in *SetSpendingLimitsRequest, opts ...grpc.CallOption) (*SpendingLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingLimits)
+	err := c.cc.Invoke(ctx, WalletService_SetSpendingLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeleteSpendingLimits(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*SpendingLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingLimits)
+	err := c.cc.Invoke(ctx, WalletService_DeleteSpendingLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ChangeWalletStatus(ctx context.Context, in *ChangeWalletStatusRequest, opts ...grpc.CallOption) (*WalletStatusChange, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletStatusChange)
+	err := c.cc.Invoke(ctx, WalletService_ChangeWalletStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetWalletStatusChanges(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*WalletStatusChangeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WalletStatusChangeList)
+	err := c.cc.Invoke(ctx, WalletService_GetWalletStatusChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*BalanceAdjustment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceAdjustment)
+	err := c.cc.Invoke(ctx, WalletService_AdjustBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetBalanceAdjustments(ctx context.Context, in *WalletIdRequest, opts ...grpc.CallOption) (*BalanceAdjustmentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceAdjustmentList)
+	err := c.cc.Invoke(ctx, WalletService_GetBalanceAdjustments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReconcileWallets(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconciliationReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconciliationReport)
+	err := c.cc.Invoke(ctx, WalletService_ReconcileWallets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) DeleteAll(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, WalletService_DeleteAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetAllTrxs(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*TransactionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransactionList)
+	err := c.cc.Invoke(ctx, WalletService_GetAllTrxs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WalletServiceServer is the server API for WalletService service.
+// All implementations must embed UnimplementedWalletServiceServer
+// for forward compatibility.
+type WalletServiceServer interface {
+	CreateWallet(context.Context, *CreateWalletRequest) (*Wallet, error)
+	GetWalletsByUserId(context.Context, *GetWalletsByUserIdRequest) (*WalletList, error)
+	DepositMoney(context.Context, *TrxRequest) (*Trx, error)
+	WithdrawMoney(context.Context, *TrxRequest) (*Trx, error)
+	TransferMoney(context.Context, *TransferRequest) (*Trx, error)
+	QuoteTransfer(context.Context, *TransferQuoteRequest) (*TransferQuote, error)
+	PreviewFees(context.Context, *FeePreviewRequest) (*FeePreview, error)
+	ReverseTransfer(context.Context, *ReverseTransferRequest) (*Trx, error)
+	GetBalance(context.Context, *WalletIdRequest) (*Wallet, error)
+	GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionPage, error)
+	StreamTransactions(*GetTransactionsRequest, grpc.ServerStreamingServer[Transaction]) error
+	ExportStatement(*ExportStatementRequest, grpc.ServerStreamingServer[StatementChunk]) error
+	CreateHold(context.Context, *CreateHoldRequest) (*Hold, error)
+	CaptureHold(context.Context, *CaptureHoldRequest) (*Trx, error)
+	ReleaseHold(context.Context, *HoldIdRequest) (*Hold, error)
+	GetHolds(context.Context, *WalletIdRequest) (*HoldList, error)
+	ExpireHolds(context.Context, *Empty) (*ExpireHoldsResponse, error)
+	GetSpendingLimits(context.Context, *WalletIdRequest) (*SpendingLimits, error)
+	SetSpendingLimits(context.Context, *SetSpendingLimitsRequest) (*SpendingLimits, error)
+	DeleteSpendingLimits(context.Context, *WalletIdRequest) (*SpendingLimits, error)
+	ChangeWalletStatus(context.Context, *ChangeWalletStatusRequest) (*WalletStatusChange, error)
+	GetWalletStatusChanges(context.Context, *WalletIdRequest) (*WalletStatusChangeList, error)
+	AdjustBalance(context.Context, *AdjustBalanceRequest) (*BalanceAdjustment, error)
+	GetBalanceAdjustments(context.Context, *WalletIdRequest) (*BalanceAdjustmentList, error)
+	ReconcileWallets(context.Context, *ReconcileRequest) (*ReconciliationReport, error)
+	DeleteAll(context.Context, *Empty) (*Empty, error)
+	GetAllTrxs(context.Context, *Empty) (*TransactionList, error)
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+// UnimplementedWalletServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWalletServiceServer struct{}
+
+func (UnimplementedWalletServiceServer) CreateWallet(context.Context, *CreateWalletRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWallet not implemented")
+}
+func (UnimplementedWalletServiceServer) GetWalletsByUserId(context.Context, *GetWalletsByUserIdRequest) (*WalletList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletsByUserId not implemented")
+}
+func (UnimplementedWalletServiceServer) DepositMoney(context.Context, *TrxRequest) (*Trx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DepositMoney not implemented")
+}
+func (UnimplementedWalletServiceServer) WithdrawMoney(context.Context, *TrxRequest) (*Trx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawMoney not implemented")
+}
+func (UnimplementedWalletServiceServer) TransferMoney(context.Context, *TransferRequest) (*Trx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferMoney not implemented")
+}
+func (UnimplementedWalletServiceServer) QuoteTransfer(context.Context, *TransferQuoteRequest) (*TransferQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) PreviewFees(context.Context, *FeePreviewRequest) (*FeePreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewFees not implemented")
+}
+func (UnimplementedWalletServiceServer) ReverseTransfer(context.Context, *ReverseTransferRequest) (*Trx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseTransfer not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalance(context.Context, *WalletIdRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) GetTransactions(context.Context, *GetTransactionsRequest) (*TransactionPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) StreamTransactions(*GetTransactionsRequest, grpc.ServerStreamingServer[Transaction]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportStatement(*ExportStatementRequest, grpc.ServerStreamingServer[StatementChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStatement not implemented")
+}
+func (UnimplementedWalletServiceServer) CreateHold(context.Context, *CreateHoldRequest) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHold not implemented")
+}
+func (UnimplementedWalletServiceServer) CaptureHold(context.Context, *CaptureHoldRequest) (*Trx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHold not implemented")
+}
+func (UnimplementedWalletServiceServer) ReleaseHold(context.Context, *HoldIdRequest) (*Hold, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedWalletServiceServer) GetHolds(context.Context, *WalletIdRequest) (*HoldList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHolds not implemented")
+}
+func (UnimplementedWalletServiceServer) ExpireHolds(context.Context, *Empty) (*ExpireHoldsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireHolds not implemented")
+}
+func (UnimplementedWalletServiceServer) GetSpendingLimits(context.Context, *WalletIdRequest) (*SpendingLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpendingLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) SetSpendingLimits(context.Context, *SetSpendingLimitsRequest) (*SpendingLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpendingLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) DeleteSpendingLimits(context.Context, *WalletIdRequest) (*SpendingLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSpendingLimits not implemented")
+}
+func (UnimplementedWalletServiceServer) ChangeWalletStatus(context.Context, *ChangeWalletStatusRequest) (*WalletStatusChange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeWalletStatus not implemented")
+}
+func (UnimplementedWalletServiceServer) GetWalletStatusChanges(context.Context, *WalletIdRequest) (*WalletStatusChangeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWalletStatusChanges not implemented")
+}
+func (UnimplementedWalletServiceServer) AdjustBalance(context.Context, *AdjustBalanceRequest) (*BalanceAdjustment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) GetBalanceAdjustments(context.Context, *WalletIdRequest) (*BalanceAdjustmentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceAdjustments not implemented")
+}
+func (UnimplementedWalletServiceServer) ReconcileWallets(context.Context, *ReconcileRequest) (*ReconciliationReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileWallets not implemented")
+}
+func (UnimplementedWalletServiceServer) DeleteAll(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAll not implemented")
+}
+func (UnimplementedWalletServiceServer) GetAllTrxs(context.Context, *Empty) (*TransactionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllTrxs not implemented")
+}
+func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
+func (UnimplementedWalletServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeWalletServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WalletServiceServer will
+// result in compilation errors.
+type UnsafeWalletServiceServer interface {
+	mustEmbedUnimplementedWalletServiceServer()
+}
+
+func RegisterWalletServiceServer(s grpc.ServiceRegistrar, srv WalletServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWalletServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WalletService_ServiceDesc, srv)
+}
+
+func _WalletService_CreateWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateWallet(ctx, req.(*CreateWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetWalletsByUserId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletsByUserIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetWalletsByUserId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetWalletsByUserId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetWalletsByUserId(ctx, req.(*GetWalletsByUserIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DepositMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DepositMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DepositMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DepositMoney(ctx, req.(*TrxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_WithdrawMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).WithdrawMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_WithdrawMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).WithdrawMoney(ctx, req.(*TrxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_TransferMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).TransferMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_TransferMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).TransferMoney(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_QuoteTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).QuoteTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_QuoteTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).QuoteTransfer(ctx, req.(*TransferQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_PreviewFees_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeePreviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).PreviewFees(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_PreviewFees_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).PreviewFees(ctx, req.(*FeePreviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReverseTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReverseTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReverseTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReverseTransfer(ctx, req.(*ReverseTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalance(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_StreamTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).StreamTransactions(m, &grpc.GenericServerStream[GetTransactionsRequest, Transaction]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_StreamTransactionsServer = grpc.ServerStreamingServer[Transaction]
+
+func _WalletService_ExportStatement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStatementRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).ExportStatement(m, &grpc.GenericServerStream[ExportStatementRequest, StatementChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_ExportStatementServer = grpc.ServerStreamingServer[StatementChunk]
+
+func _WalletService_CreateHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CreateHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CreateHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CreateHold(ctx, req.(*CreateHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_CaptureHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).CaptureHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_CaptureHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).CaptureHold(ctx, req.(*CaptureHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReleaseHold_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReleaseHold(ctx, req.(*HoldIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetHolds(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExpireHolds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ExpireHolds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ExpireHolds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ExpireHolds(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetSpendingLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetSpendingLimits(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSpendingLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_SetSpendingLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetSpendingLimits(ctx, req.(*SetSpendingLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeleteSpendingLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeleteSpendingLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DeleteSpendingLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeleteSpendingLimits(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ChangeWalletStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeWalletStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ChangeWalletStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ChangeWalletStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ChangeWalletStatus(ctx, req.(*ChangeWalletStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetWalletStatusChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetWalletStatusChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetWalletStatusChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetWalletStatusChanges(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_AdjustBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).AdjustBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_AdjustBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).AdjustBalance(ctx, req.(*AdjustBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetBalanceAdjustments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetBalanceAdjustments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetBalanceAdjustments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetBalanceAdjustments(ctx, req.(*WalletIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReconcileWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReconcileWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReconcileWallets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReconcileWallets(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_DeleteAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).DeleteAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_DeleteAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).DeleteAll(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAllTrxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAllTrxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetAllTrxs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAllTrxs(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wallet.v1.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWallet",
+			Handler:    _WalletService_CreateWallet_Handler,
+		},
+		{
+			MethodName: "GetWalletsByUserId",
+			Handler:    _WalletService_GetWalletsByUserId_Handler,
+		},
+		{
+			MethodName: "DepositMoney",
+			Handler:    _WalletService_DepositMoney_Handler,
+		},
+		{
+			MethodName: "WithdrawMoney",
+			Handler:    _WalletService_WithdrawMoney_Handler,
+		},
+		{
+			MethodName: "TransferMoney",
+			Handler:    _WalletService_TransferMoney_Handler,
+		},
+		{
+			MethodName: "QuoteTransfer",
+			Handler:    _WalletService_QuoteTransfer_Handler,
+		},
+		{
+			MethodName: "PreviewFees",
+			Handler:    _WalletService_PreviewFees_Handler,
+		},
+		{
+			MethodName: "ReverseTransfer",
+			Handler:    _WalletService_ReverseTransfer_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _WalletService_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _WalletService_GetTransactions_Handler,
+		},
+		{
+			MethodName: "CreateHold",
+			Handler:    _WalletService_CreateHold_Handler,
+		},
+		{
+			MethodName: "CaptureHold",
+			Handler:    _WalletService_CaptureHold_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _WalletService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "GetHolds",
+			Handler:    _WalletService_GetHolds_Handler,
+		},
+		{
+			MethodName: "ExpireHolds",
+			Handler:    _WalletService_ExpireHolds_Handler,
+		},
+		{
+			MethodName: "GetSpendingLimits",
+			Handler:    _WalletService_GetSpendingLimits_Handler,
+		},
+		{
+			MethodName: "SetSpendingLimits",
+			Handler:    _WalletService_SetSpendingLimits_Handler,
+		},
+		{
+			MethodName: "DeleteSpendingLimits",
+			Handler:    _WalletService_DeleteSpendingLimits_Handler,
+		},
+		{
+			MethodName: "ChangeWalletStatus",
+			Handler:    _WalletService_ChangeWalletStatus_Handler,
+		},
+		{
+			MethodName: "GetWalletStatusChanges",
+			Handler:    _WalletService_GetWalletStatusChanges_Handler,
+		},
+		{
+			MethodName: "AdjustBalance",
+			Handler:    _WalletService_AdjustBalance_Handler,
+		},
+		{
+			MethodName: "GetBalanceAdjustments",
+			Handler:    _WalletService_GetBalanceAdjustments_Handler,
+		},
+		{
+			MethodName: "ReconcileWallets",
+			Handler:    _WalletService_ReconcileWallets_Handler,
+		},
+		{
+			MethodName: "DeleteAll",
+			Handler:    _WalletService_DeleteAll_Handler,
+		},
+		{
+			MethodName: "GetAllTrxs",
+			Handler:    _WalletService_GetAllTrxs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTransactions",
+			Handler:       _WalletService_StreamTransactions_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportStatement",
+			Handler:       _WalletService_ExportStatement_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "wallet.proto",
+}
@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IAdjustmentRepo interface {
+	FindBalanceAdjustmentsByWalletId(walletId string) []entity.BalanceAdjustmentEntity
+	SaveBalanceAdjustmentWithTx(adjustment entity.BalanceAdjustmentEntity, tx *gorm.DB) error
+}
+
+type AdjustmentRepo struct {
+	db *gorm.DB
+}
+
+func NewAdjustmentRepo(db *gorm.DB) IAdjustmentRepo {
+	return &AdjustmentRepo{db: db}
+}
+
+func (a *AdjustmentRepo) FindBalanceAdjustmentsByWalletId(walletId string) []entity.BalanceAdjustmentEntity {
+	var adjustments []entity.BalanceAdjustmentEntity
+	a.db.Where("wallet_id = ?", walletId).Order("created_at DESC").Find(&adjustments)
+	return adjustments
+}
+
+func (a *AdjustmentRepo) SaveBalanceAdjustmentWithTx(adjustment entity.BalanceAdjustmentEntity, tx *gorm.DB) error {
+	return tx.Create(&adjustment).Error
+}
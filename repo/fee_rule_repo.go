@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IFeeRuleRepo interface {
+	FindFeeRuleById(id string) (entity.FeeRuleEntity, error)
+	FindFeeRules() []entity.FeeRuleEntity
+	FindActiveFeeRules(operations []common.FeeOperation, currency string) ([]entity.FeeRuleEntity, error)
+	FindActiveFeeRulesWithTx(operations []common.FeeOperation, currency string, tx *gorm.DB) ([]entity.FeeRuleEntity, error)
+	SaveFeeRule(rule entity.FeeRuleEntity) error
+	DeleteFeeRule(id string) error
+}
+
+type FeeRuleRepo struct {
+	db *gorm.DB
+}
+
+func NewFeeRuleRepo(db *gorm.DB) IFeeRuleRepo {
+	return &FeeRuleRepo{db: db}
+}
+
+func (f *FeeRuleRepo) FindFeeRuleById(id string) (entity.FeeRuleEntity, error) {
+	var rule entity.FeeRuleEntity
+	err := f.db.First(&rule, "id = ?", id).Error
+	return rule, err
+}
+
+func (f *FeeRuleRepo) FindFeeRules() []entity.FeeRuleEntity {
+	var rules []entity.FeeRuleEntity
+	f.db.Order("operation, currency, created_at").Find(&rules)
+	return rules
+}
+
+func (f *FeeRuleRepo) FindActiveFeeRules(operations []common.FeeOperation, currency string) ([]entity.FeeRuleEntity, error) {
+	return f.FindActiveFeeRulesWithTx(operations, currency, f.db)
+}
+
+// FindActiveFeeRulesWithTx returns the active rules for the operations in the currency, in the order
+// of operations.
+func (f *FeeRuleRepo) FindActiveFeeRulesWithTx(operations []common.FeeOperation, currency string, tx *gorm.DB) ([]entity.FeeRuleEntity, error) {
+	var rules []entity.FeeRuleEntity
+	if err := tx.Where("operation IN ? AND currency = ? AND active = ?", operations, currency, true).Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	ordered := make([]entity.FeeRuleEntity, 0, len(rules))
+	for _, operation := range operations {
+		for _, rule := range rules {
+			if rule.Operation == operation {
+				ordered = append(ordered, rule)
+			}
+		}
+	}
+	return ordered, nil
+}
+
+func (f *FeeRuleRepo) SaveFeeRule(rule entity.FeeRuleEntity) error {
+	return f.db.Save(&rule).Error
+}
+
+func (f *FeeRuleRepo) DeleteFeeRule(id string) error {
+	return f.db.Where("id = ?", id).Delete(&entity.FeeRuleEntity{}).Error
+}
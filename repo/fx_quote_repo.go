@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IFxQuoteRepo interface {
+	FindFxQuoteByIdWithTx(quoteId string, tx *gorm.DB) (entity.FxQuoteEntity, error)
+	SaveFxQuote(quote entity.FxQuoteEntity) error
+	SaveFxQuoteWithTx(quote entity.FxQuoteEntity, tx *gorm.DB) error
+}
+
+type FxQuoteRepo struct {
+	db *gorm.DB
+}
+
+func NewFxQuoteRepo(db *gorm.DB) IFxQuoteRepo {
+	return &FxQuoteRepo{db: db}
+}
+
+func (f *FxQuoteRepo) FindFxQuoteByIdWithTx(quoteId string, tx *gorm.DB) (entity.FxQuoteEntity, error) {
+	var quote entity.FxQuoteEntity
+	err := tx.Where("id = ?", quoteId).First(&quote).Error
+	return quote, err
+}
+
+func (f *FxQuoteRepo) SaveFxQuote(quote entity.FxQuoteEntity) error {
+	return f.db.Save(&quote).Error
+}
+
+func (f *FxQuoteRepo) SaveFxQuoteWithTx(quote entity.FxQuoteEntity, tx *gorm.DB) error {
+	return tx.Save(&quote).Error
+}
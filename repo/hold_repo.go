@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IHoldRepo interface {
+	FindHoldById(holdId string) (entity.HoldEntity, error)
+	FindHoldByIdWithTx(holdId string, tx *gorm.DB) (entity.HoldEntity, error)
+	FindHoldsByWalletId(walletId string) []entity.HoldEntity
+	FindExpiredHolds(now time.Time, limit int) ([]entity.HoldEntity, error)
+	SumActiveHoldAmount(walletId string, now time.Time) (uint, error)
+	SumActiveHoldAmountWithTx(walletId string, now time.Time, tx *gorm.DB) (uint, error)
+	SaveHoldWithTx(hold entity.HoldEntity, tx *gorm.DB) error
+}
+
+type HoldRepo struct {
+	db *gorm.DB
+}
+
+func NewHoldRepo(db *gorm.DB) IHoldRepo {
+	return &HoldRepo{db: db}
+}
+
+func (h *HoldRepo) FindHoldById(holdId string) (entity.HoldEntity, error) {
+	return h.FindHoldByIdWithTx(holdId, h.db)
+}
+
+func (h *HoldRepo) FindHoldByIdWithTx(holdId string, tx *gorm.DB) (entity.HoldEntity, error) {
+	var hold entity.HoldEntity
+	err := tx.Where("id = ?", holdId).First(&hold).Error
+	return hold, err
+}
+
+func (h *HoldRepo) FindHoldsByWalletId(walletId string) []entity.HoldEntity {
+	var holds []entity.HoldEntity
+	h.db.Where("wallet_id = ?", walletId).Order("created_at DESC").Find(&holds)
+	return holds
+}
+
+// FindExpiredHolds returns holds that are still marked active but whose expiry has passed.
+func (h *HoldRepo) FindExpiredHolds(now time.Time, limit int) ([]entity.HoldEntity, error) {
+	var holds []entity.HoldEntity
+	err := h.db.Where("status = ? AND expires_at <= ?", common.HoldStatusActive, now).Order("expires_at").Limit(limit).Find(&holds).Error
+	return holds, err
+}
+
+func (h *HoldRepo) SumActiveHoldAmount(walletId string, now time.Time) (uint, error) {
+	return h.SumActiveHoldAmountWithTx(walletId, now, h.db)
+}
+
+func (h *HoldRepo) SumActiveHoldAmountWithTx(walletId string, now time.Time, tx *gorm.DB) (uint, error) {
+	var sum uint
+	err := tx.Model(&entity.HoldEntity{}).
+		Where("wallet_id = ? AND status = ? AND expires_at > ?", walletId, common.HoldStatusActive, now).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&sum).Error
+	return sum, err
+}
+
+func (h *HoldRepo) SaveHoldWithTx(hold entity.HoldEntity, tx *gorm.DB) error {
+	return tx.Save(&hold).Error
+}
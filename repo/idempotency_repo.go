@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IIdempotencyRepo interface {
+	FindIdempotencyKeyWithTx(userId string, walletId string, key string, tx *gorm.DB) (entity.IdempotencyKeyEntity, error)
+	CreateIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error
+	DeleteIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error
+	DeleteExpiredIdempotencyKeys(now time.Time) error
+}
+
+type IdempotencyRepo struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepo(db *gorm.DB) IIdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+func (i *IdempotencyRepo) FindIdempotencyKeyWithTx(userId string, walletId string, key string, tx *gorm.DB) (entity.IdempotencyKeyEntity, error) {
+	var record entity.IdempotencyKeyEntity
+	err := tx.Where("user_id = ? AND wallet_id = ? AND idempotency_key = ?", userId, walletId, key).First(&record).Error
+	return record, err
+}
+
+// CreateIdempotencyKeyWithTx inserts the record, failing with a unique violation when the key was
+// already used (see db.IsUniqueViolation).
+func (i *IdempotencyRepo) CreateIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error {
+	return tx.Create(&record).Error
+}
+
+func (i *IdempotencyRepo) DeleteIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error {
+	return tx.Where("user_id = ? AND wallet_id = ? AND idempotency_key = ?", record.UserId, record.WalletId, record.Key).
+		Delete(&entity.IdempotencyKeyEntity{}).Error
+}
+
+func (i *IdempotencyRepo) DeleteExpiredIdempotencyKeys(now time.Time) error {
+	return i.db.Where("expires_at < ?", now).Delete(&entity.IdempotencyKeyEntity{}).Error
+}
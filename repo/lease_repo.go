@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ILeaseRepo interface {
+	TryAcquireLease(name string, holder string, ttl time.Duration, now time.Time) (bool, error)
+	ReleaseLease(name string, holder string) error
+}
+
+type LeaseRepo struct {
+	db *gorm.DB
+}
+
+func NewLeaseRepo(db *gorm.DB) ILeaseRepo {
+	return &LeaseRepo{db: db}
+}
+
+// TryAcquireLease takes the lease if it is free or expired, or extends it if holder already has it.
+// Both steps are single conditional statements, so two instances can never both succeed.
+func (l *LeaseRepo) TryAcquireLease(name string, holder string, ttl time.Duration, now time.Time) (bool, error) {
+	expiresAt := now.Add(ttl)
+	res := l.db.Model(&entity.LeaseEntity{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{"holder": holder, "expires_at": expiresAt, "updated_at": now})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return true, nil
+	}
+
+	lease := entity.LeaseEntity{Name: name, Holder: holder, ExpiresAt: expiresAt, UpdatedAt: now}
+	res = l.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&lease)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (l *LeaseRepo) ReleaseLease(name string, holder string) error {
+	return l.db.Where("name = ? AND holder = ?", name, holder).Delete(&entity.LeaseEntity{}).Error
+}
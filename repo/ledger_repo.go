@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ILedgerRepo interface {
+	EnsureLedgerAccountWithTx(account entity.LedgerAccountEntity, tx *gorm.DB) (bool, error)
+	SaveLedgerEntriesWithTx(entries []entity.LedgerEntryEntity, tx *gorm.DB) error
+	FindLedgerEntriesByJournalId(journalId string) []entity.LedgerEntryEntity
+	FindLedgerAccountBalance(accountId string) (int64, error)
+}
+
+type LedgerRepo struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepo(db *gorm.DB) ILedgerRepo {
+	return &LedgerRepo{db: db}
+}
+
+// EnsureLedgerAccountWithTx creates the account if missing and reports whether it did.
+func (l *LedgerRepo) EnsureLedgerAccountWithTx(account entity.LedgerAccountEntity, tx *gorm.DB) (bool, error) {
+	res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&account)
+	return res.RowsAffected == 1, res.Error
+}
+
+func (l *LedgerRepo) SaveLedgerEntriesWithTx(entries []entity.LedgerEntryEntity, tx *gorm.DB) error {
+	return tx.Create(&entries).Error
+}
+
+func (l *LedgerRepo) FindLedgerEntriesByJournalId(journalId string) []entity.LedgerEntryEntity {
+	var entries []entity.LedgerEntryEntity
+	l.db.Where("journal_id = ?", journalId).Order("id").Find(&entries)
+	return entries
+}
+
+// FindLedgerAccountBalance returns credits minus debits, the balance of a credit-normal account.
+func (l *LedgerRepo) FindLedgerAccountBalance(accountId string) (int64, error) {
+	var balance int64
+	err := l.db.Model(&entity.LedgerEntryEntity{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", common.EntryDirectionCredit).
+		Where("account_id = ?", accountId).
+		Scan(&balance).Error
+	return balance, err
+}
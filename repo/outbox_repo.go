@@ -0,0 +1,48 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IOutboxRepo interface {
+	FindOutboxEventById(eventId string) (entity.OutboxEventEntity, error)
+	FindUndispatchedOutboxEvents(limit int) ([]entity.OutboxEventEntity, error)
+	SaveOutboxEventWithTx(event entity.OutboxEventEntity, tx *gorm.DB) error
+	MarkOutboxEventDispatchedWithTx(eventId string, now time.Time, tx *gorm.DB) (bool, error)
+}
+
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepo(db *gorm.DB) IOutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+func (o *OutboxRepo) FindOutboxEventById(eventId string) (entity.OutboxEventEntity, error) {
+	var event entity.OutboxEventEntity
+	err := o.db.Where("id = ?", eventId).First(&event).Error
+	return event, err
+}
+
+// FindUndispatchedOutboxEvents returns events in the order they were committed.
+func (o *OutboxRepo) FindUndispatchedOutboxEvents(limit int) ([]entity.OutboxEventEntity, error) {
+	var events []entity.OutboxEventEntity
+	err := o.db.Where("dispatched_at IS NULL").Order("created_at, id").Limit(limit).Find(&events).Error
+	return events, err
+}
+
+func (o *OutboxRepo) SaveOutboxEventWithTx(event entity.OutboxEventEntity, tx *gorm.DB) error {
+	return tx.Create(&event).Error
+}
+
+// MarkOutboxEventDispatchedWithTx reports false when the event had already been dispatched.
+func (o *OutboxRepo) MarkOutboxEventDispatchedWithTx(eventId string, now time.Time, tx *gorm.DB) (bool, error) {
+	result := tx.Model(&entity.OutboxEventEntity{}).
+		Where("id = ? AND dispatched_at IS NULL", eventId).
+		Update("dispatched_at", now)
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IScheduleRepo interface {
+	FindScheduleById(scheduleId string) (entity.ScheduleEntity, error)
+	FindSchedulesByWalletId(walletId string) []entity.ScheduleEntity
+	FindDueSchedules(now time.Time, limit int) ([]entity.ScheduleEntity, error)
+	FindScheduleRuns(scheduleId string, limit int) []entity.ScheduleRunEntity
+	SaveSchedule(schedule entity.ScheduleEntity) error
+	SaveScheduleRunWithTx(schedule entity.ScheduleEntity, run entity.ScheduleRunEntity, tx *gorm.DB) error
+}
+
+type ScheduleRepo struct {
+	db *gorm.DB
+}
+
+func NewScheduleRepo(db *gorm.DB) IScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+func (s *ScheduleRepo) FindScheduleById(scheduleId string) (entity.ScheduleEntity, error) {
+	var schedule entity.ScheduleEntity
+	err := s.db.Where("id = ?", scheduleId).First(&schedule).Error
+	return schedule, err
+}
+
+func (s *ScheduleRepo) FindSchedulesByWalletId(walletId string) []entity.ScheduleEntity {
+	var schedules []entity.ScheduleEntity
+	s.db.Where("wallet_id = ?", walletId).Order("created_at").Find(&schedules)
+	return schedules
+}
+
+func (s *ScheduleRepo) FindDueSchedules(now time.Time, limit int) ([]entity.ScheduleEntity, error) {
+	var schedules []entity.ScheduleEntity
+	err := s.db.Where("status = ? AND attempt_at <= ?", common.ScheduleStatusActive, now).Order("attempt_at").Limit(limit).Find(&schedules).Error
+	return schedules, err
+}
+
+func (s *ScheduleRepo) FindScheduleRuns(scheduleId string, limit int) []entity.ScheduleRunEntity {
+	var runs []entity.ScheduleRunEntity
+	s.db.Where("schedule_id = ?", scheduleId).Order("created_at DESC").Limit(limit).Find(&runs)
+	return runs
+}
+
+func (s *ScheduleRepo) SaveSchedule(schedule entity.ScheduleEntity) error {
+	return s.db.Save(&schedule).Error
+}
+
+// SaveScheduleRunWithTx records a run and moves the schedule on. Only the run bookkeeping columns are
+// written, so a concurrent pause or edit of the schedule is not overwritten.
+func (s *ScheduleRepo) SaveScheduleRunWithTx(schedule entity.ScheduleEntity, run entity.ScheduleRunEntity, tx *gorm.DB) error {
+	if err := tx.Create(&run).Error; err != nil {
+		return err
+	}
+	return tx.Model(&schedule).
+		Select("next_run_at", "attempt_at", "attempt", "last_run_at", "last_outcome", "updated_at").
+		Updates(schedule).Error
+}
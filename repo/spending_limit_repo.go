@@ -0,0 +1,40 @@
+package repo
+
+import (
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type ISpendingLimitRepo interface {
+	FindSpendingLimitByWalletId(walletId string) (entity.SpendingLimitEntity, error)
+	FindSpendingLimitByWalletIdWithTx(walletId string, tx *gorm.DB) (entity.SpendingLimitEntity, error)
+	SaveSpendingLimit(limit entity.SpendingLimitEntity) error
+	DeleteSpendingLimit(walletId string) error
+}
+
+type SpendingLimitRepo struct {
+	db *gorm.DB
+}
+
+func NewSpendingLimitRepo(db *gorm.DB) ISpendingLimitRepo {
+	return &SpendingLimitRepo{db: db}
+}
+
+func (s *SpendingLimitRepo) FindSpendingLimitByWalletId(walletId string) (entity.SpendingLimitEntity, error) {
+	return s.FindSpendingLimitByWalletIdWithTx(walletId, s.db)
+}
+
+func (s *SpendingLimitRepo) FindSpendingLimitByWalletIdWithTx(walletId string, tx *gorm.DB) (entity.SpendingLimitEntity, error) {
+	var limit entity.SpendingLimitEntity
+	err := tx.First(&limit, "wallet_id = ?", walletId).Error
+	return limit, err
+}
+
+func (s *SpendingLimitRepo) SaveSpendingLimit(limit entity.SpendingLimitEntity) error {
+	return s.db.Save(&limit).Error
+}
+
+func (s *SpendingLimitRepo) DeleteSpendingLimit(walletId string) error {
+	return s.db.Where("wallet_id = ?", walletId).Delete(&entity.SpendingLimitEntity{}).Error
+}
@@ -1,19 +1,35 @@
 package repo
 
 import (
+	"context"
+	"time"
+	"wallet-app/common"
 	"wallet-app/entity"
 
 	"gorm.io/gorm"
 )
 
 type ITrxRepo interface {
-	FindAllTrxs() []entity.TrxEntity
-	FindTransactionsByWalletId(walletId string) []entity.TrxEntity
-	SaveTrx(trx entity.TrxEntity) error
+	FindAllTrxs(ctx context.Context) []entity.TrxEntity
+	FindTransactionsByWalletId(ctx context.Context, walletId string) []entity.TrxEntity
+	FindTransactions(ctx context.Context, query TrxQuery) ([]entity.TrxEntity, error)
+	FindTrxsByGroupId(ctx context.Context, groupId string) []entity.TrxEntity
+	SumAmountsByTrxTypeWithTx(walletId string, tx *gorm.DB) ([]TrxTypeTotal, error)
+	SumAmountsByTrxTypeBetween(ctx context.Context, walletId string, from time.Time, to time.Time) ([]TrxTypeTotal, error)
+	StreamTrxs(ctx context.Context, walletId string, from time.Time, to time.Time, fn func(trx entity.TrxEntity) error) error
+	FindTrxsByTypesSinceWithTx(walletId string, trxTypes []common.TrxType, since time.Time, tx *gorm.DB) ([]entity.TrxEntity, error)
+	SaveTrx(ctx context.Context, trx entity.TrxEntity) error
 	SaveTrxWithDbTx(trx entity.TrxEntity, dbTx *gorm.DB) error
-	SaveTrxs(trxs []entity.TrxEntity) error
+	SaveTrxs(ctx context.Context, trxs []entity.TrxEntity) error
 	SaveTrxsWithDbTx(trxs []entity.TrxEntity, dbTx *gorm.DB) error
-	DeleteAllTrxs() error
+
+	// BindTx returns the repo with every method running in tx; see manager.Bind.
+	BindTx(tx *gorm.DB) ITrxRepo
+}
+
+type TrxTypeTotal struct {
+	TrxType common.TrxType
+	Amount  int64
 }
 
 type TransactionRepo struct {
@@ -24,34 +40,127 @@ func NewTransactionRepo(db *gorm.DB) ITrxRepo {
 	return &TransactionRepo{db: db}
 }
 
-func (t *TransactionRepo) FindAllTrxs() []entity.TrxEntity {
+func (t *TransactionRepo) BindTx(tx *gorm.DB) ITrxRepo {
+	return &TransactionRepo{db: tx}
+}
+
+func (t *TransactionRepo) FindAllTrxs(ctx context.Context) []entity.TrxEntity {
 	var trxs []entity.TrxEntity
-	t.db.Find(&trxs)
+	t.db.WithContext(ctx).Find(&trxs)
 	return trxs
 }
 
-func (t *TransactionRepo) FindTransactionsByWalletId(walletId string) []entity.TrxEntity {
+func (t *TransactionRepo) FindTransactionsByWalletId(ctx context.Context, walletId string) []entity.TrxEntity {
 	var transactions []entity.TrxEntity
-	t.db.Where("wallet_id = ? OR counterparty_wallet_id = ?", walletId, walletId).Order("created_at DESC").Find(&transactions)
+	t.db.WithContext(ctx).Where("wallet_id = ? OR counterparty_wallet_id = ?", walletId, walletId).Order("created_at DESC").Find(&transactions)
 	return transactions
 }
 
-func (t *TransactionRepo) SaveTrx(trx entity.TrxEntity) error {
-	return t.db.Save(&trx).Error
+func (t *TransactionRepo) FindTransactions(ctx context.Context, query TrxQuery) ([]entity.TrxEntity, error) {
+	tx := t.db.WithContext(ctx).Where("wallet_id = ?", query.WalletId)
+	if len(query.TrxTypes) > 0 {
+		tx = tx.Where("trx_type IN ?", query.TrxTypes)
+	}
+	if query.MinAmount != nil {
+		tx = tx.Where("amount >= ?", *query.MinAmount)
+	}
+	if query.MaxAmount != nil {
+		tx = tx.Where("amount <= ?", *query.MaxAmount)
+	}
+	if query.From != nil {
+		tx = tx.Where("created_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		tx = tx.Where("created_at < ?", *query.To)
+	}
+	if query.CounterpartyWalletId != "" {
+		tx = tx.Where("counterparty_wallet_id = ?", query.CounterpartyWalletId)
+	}
+	if query.After != nil {
+		tx = tx.Where("created_at < ? OR (created_at = ? AND id < ?)", query.After.CreatedAt, query.After.CreatedAt, query.After.Id)
+	}
+
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
+
+	var transactions []entity.TrxEntity
+	err := tx.Order("created_at DESC").Order("id DESC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (t *TransactionRepo) FindTrxsByGroupId(ctx context.Context, groupId string) []entity.TrxEntity {
+	var trxs []entity.TrxEntity
+	t.db.WithContext(ctx).Where("group_id = ?", groupId).Find(&trxs)
+	return trxs
+}
+
+func (t *TransactionRepo) SaveTrx(ctx context.Context, trx entity.TrxEntity) error {
+	return t.db.WithContext(ctx).Save(&trx).Error
 }
 
 func (t *TransactionRepo) SaveTrxWithDbTx(trx entity.TrxEntity, tx *gorm.DB) error {
 	return tx.Save(&trx).Error
 }
 
-func (t *TransactionRepo) SaveTrxs(trxs []entity.TrxEntity) error {
-	return t.db.Save(&trxs).Error
+func (t *TransactionRepo) SaveTrxs(ctx context.Context, trxs []entity.TrxEntity) error {
+	return t.db.WithContext(ctx).Save(&trxs).Error
 }
 
 func (t *TransactionRepo) SaveTrxsWithDbTx(trxs []entity.TrxEntity, tx *gorm.DB) error {
 	return tx.Save(&trxs).Error
 }
 
-func (t *TransactionRepo) DeleteAllTrxs() error {
-	return t.db.Exec("delete from transactions").Error
+// SumAmountsByTrxTypeWithTx totals the wallet's own rows per trx type.
+func (t *TransactionRepo) SumAmountsByTrxTypeWithTx(walletId string, tx *gorm.DB) ([]TrxTypeTotal, error) {
+	return sumAmountsByTrxType(tx.Where("wallet_id = ?", walletId))
+}
+
+// SumAmountsByTrxTypeBetween totals the wallet's own rows created in [from, to) per trx type.
+// A zero from counts from the first row.
+func (t *TransactionRepo) SumAmountsByTrxTypeBetween(ctx context.Context, walletId string, from time.Time, to time.Time) ([]TrxTypeTotal, error) {
+	return sumAmountsByTrxType(t.db.WithContext(ctx).Where("wallet_id = ? AND created_at >= ? AND created_at < ?", walletId, from, to))
+}
+
+func sumAmountsByTrxType(tx *gorm.DB) ([]TrxTypeTotal, error) {
+	var totals []TrxTypeTotal
+	err := tx.Model(&entity.TrxEntity{}).
+		Select("trx_type, COALESCE(SUM(amount), 0) AS amount").
+		Group("trx_type").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// FindTrxsByTypesSinceWithTx returns the wallet's own rows of the given types created at or after since,
+// oldest first.
+func (t *TransactionRepo) FindTrxsByTypesSinceWithTx(walletId string, trxTypes []common.TrxType, since time.Time, tx *gorm.DB) ([]entity.TrxEntity, error) {
+	var trxs []entity.TrxEntity
+	err := tx.Where("wallet_id = ? AND trx_type IN ? AND created_at >= ?", walletId, trxTypes, since).
+		Order("created_at, id").
+		Find(&trxs).Error
+	return trxs, err
+}
+
+// StreamTrxs calls fn for each of the wallet's own rows created in [from, to), oldest first, one row
+// at a time rather than loading the whole range. It stops at the first error fn returns.
+func (t *TransactionRepo) StreamTrxs(ctx context.Context, walletId string, from time.Time, to time.Time, fn func(trx entity.TrxEntity) error) error {
+	rows, err := t.db.WithContext(ctx).Model(&entity.TrxEntity{}).
+		Where("wallet_id = ? AND created_at >= ? AND created_at < ?", walletId, from, to).
+		Order("created_at, id").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trx entity.TrxEntity
+		if err := t.db.ScanRows(rows, &trx); err != nil {
+			return err
+		}
+		if err := fn(trx); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
 }
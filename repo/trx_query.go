@@ -0,0 +1,24 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+// TrxQuery selects a page of a wallet's transactions, newest first, ordered by (created_at, id).
+type TrxQuery struct {
+	WalletId             string
+	TrxTypes             []common.TrxType
+	MinAmount            *uint
+	MaxAmount            *uint
+	From                 *time.Time // inclusive
+	To                   *time.Time // exclusive
+	CounterpartyWalletId string
+	After                *TrxCursor // only rows strictly after this position are returned
+	Limit                int
+}
+
+type TrxCursor struct {
+	CreatedAt time.Time
+	Id        string
+}
@@ -1,21 +1,31 @@
 package repo
 
 import (
+	"context"
 	"wallet-app/entity"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 type IWalletRepo interface {
-	FindWalletById(walletId string) (entity.WalletEntity, error)
-	FindWalletsByUserId(userId string) []entity.WalletEntity
+	FindWalletById(ctx context.Context, walletId string) (entity.WalletEntity, error)
+	FindWalletsByUserId(ctx context.Context, userId string) []entity.WalletEntity
 	FindWalletByIdWithTx(walletId string, tx *gorm.DB) (entity.WalletEntity, error)
-	FindAllWallets() []entity.WalletEntity
-	SaveWallet(wallet entity.WalletEntity) error
+	FindAllWallets(ctx context.Context) []entity.WalletEntity
+	FindWalletIds(ctx context.Context, afterId string, limit int) ([]string, error)
+	SaveWallet(ctx context.Context, wallet entity.WalletEntity) error
 	SaveWalletWithTx(wallet entity.WalletEntity, tx *gorm.DB) error
-	SaveWallets(wallets []entity.WalletEntity) error
+	SaveWallets(ctx context.Context, wallets []entity.WalletEntity) error
 	SaveWalletsWithTx(wallets []entity.WalletEntity, tx *gorm.DB) error
-	DeleteAllWallets() error
+	// DeleteAll empties every table but schema_migrations.
+	DeleteAll(ctx context.Context) error
+
+	FindWalletStatusChanges(ctx context.Context, walletId string) []entity.WalletStatusChangeEntity
+	SaveWalletStatusChangeWithTx(change entity.WalletStatusChangeEntity, tx *gorm.DB) error
+
+	// BindTx returns the repo with every method running in tx; see manager.Bind.
+	BindTx(tx *gorm.DB) IWalletRepo
 }
 
 type WalletRepo struct {
@@ -26,15 +36,19 @@ func NewWalletRepo(db *gorm.DB) IWalletRepo {
 	return &WalletRepo{db: db}
 }
 
-func (w *WalletRepo) FindWalletById(id string) (entity.WalletEntity, error) {
+func (w *WalletRepo) BindTx(tx *gorm.DB) IWalletRepo {
+	return &WalletRepo{db: tx}
+}
+
+func (w *WalletRepo) FindWalletById(ctx context.Context, id string) (entity.WalletEntity, error) {
 	var wallet entity.WalletEntity
-	err := w.db.Where("id = ?", id).First(&wallet).Error
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&wallet).Error
 	return wallet, err
 }
 
-func (w *WalletRepo) FindWalletsByUserId(userId string) []entity.WalletEntity {
+func (w *WalletRepo) FindWalletsByUserId(ctx context.Context, userId string) []entity.WalletEntity {
 	var wallets []entity.WalletEntity
-	w.db.Where("user_id = ?", userId).Find(&wallets)
+	w.db.WithContext(ctx).Where("user_id = ?", userId).Find(&wallets)
 	return wallets
 }
 
@@ -44,28 +58,58 @@ func (w *WalletRepo) FindWalletByIdWithTx(id string, tx *gorm.DB) (entity.Wallet
 	return wallet, err
 }
 
-func (w *WalletRepo) FindAllWallets() []entity.WalletEntity {
+func (w *WalletRepo) FindAllWallets(ctx context.Context) []entity.WalletEntity {
 	var wallets []entity.WalletEntity
-	w.db.Find(&wallets)
+	w.db.WithContext(ctx).Find(&wallets)
 	return wallets
 }
 
-func (w *WalletRepo) SaveWallet(wallet entity.WalletEntity) error {
-	return w.db.Save(&wallet).Error
+// FindWalletIds pages through every wallet id in order, starting after afterId.
+func (w *WalletRepo) FindWalletIds(ctx context.Context, afterId string, limit int) ([]string, error) {
+	var ids []string
+	err := w.db.WithContext(ctx).Model(&entity.WalletEntity{}).Where("id > ?", afterId).Order("id").Limit(limit).Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (w *WalletRepo) SaveWallet(ctx context.Context, wallet entity.WalletEntity) error {
+	return w.db.WithContext(ctx).Save(&wallet).Error
 }
 
 func (w *WalletRepo) SaveWalletWithTx(wallet entity.WalletEntity, tx *gorm.DB) error {
 	return tx.Save(&wallet).Error
 }
 
-func (w *WalletRepo) SaveWallets(wallets []entity.WalletEntity) error {
-	return w.db.Save(&wallets).Error
+func (w *WalletRepo) SaveWallets(ctx context.Context, wallets []entity.WalletEntity) error {
+	return w.db.WithContext(ctx).Save(&wallets).Error
 }
 
 func (w *WalletRepo) SaveWalletsWithTx(wallets []entity.WalletEntity, tx *gorm.DB) error {
 	return tx.Save(&wallets).Error
 }
 
-func (w *WalletRepo) DeleteAllWallets() error {
-	return w.db.Exec("delete from wallets").Error
+// allTables lists every table the app writes; DeleteAll empties them all, so a new table belongs here.
+var allTables = []schema.Tabler{
+	entity.WalletEntity{}, entity.WalletStatusChangeEntity{}, entity.TrxEntity{}, entity.LedgerAccountEntity{}, entity.LedgerEntryEntity{},
+	entity.HoldEntity{}, entity.IdempotencyKeyEntity{}, entity.FxQuoteEntity{}, entity.BalanceAdjustmentEntity{}, entity.SpendingLimitEntity{},
+	entity.FeeRuleEntity{}, entity.OutboxEventEntity{}, entity.ScheduleEntity{}, entity.ScheduleRunEntity{}, entity.LeaseEntity{},
+	entity.WebhookSubscriptionEntity{}, entity.WebhookDeliveryEntity{},
+}
+
+func (w *WalletRepo) DeleteAll(ctx context.Context) error {
+	for _, table := range allTables {
+		if err := w.db.WithContext(ctx).Exec("delete from " + table.TableName()).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *WalletRepo) FindWalletStatusChanges(ctx context.Context, walletId string) []entity.WalletStatusChangeEntity {
+	var changes []entity.WalletStatusChangeEntity
+	w.db.WithContext(ctx).Where("wallet_id = ?", walletId).Order("created_at DESC").Find(&changes)
+	return changes
+}
+
+func (w *WalletRepo) SaveWalletStatusChangeWithTx(change entity.WalletStatusChangeEntity, tx *gorm.DB) error {
+	return tx.Create(&change).Error
 }
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"time"
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+)
+
+type IWebhookRepo interface {
+	FindWebhookSubscriptionById(subscriptionId string) (entity.WebhookSubscriptionEntity, error)
+	FindWebhookSubscriptions() []entity.WebhookSubscriptionEntity
+	FindActiveWebhookSubscriptions() ([]entity.WebhookSubscriptionEntity, error)
+	SaveWebhookSubscription(subscription entity.WebhookSubscriptionEntity) error
+	DeleteWebhookSubscription(subscriptionId string) error
+
+	FindWebhookDeliveryById(deliveryId string) (entity.WebhookDeliveryEntity, error)
+	FindWebhookDeliveries(subscriptionId string, status common.WebhookDeliveryStatus, limit int) []entity.WebhookDeliveryEntity
+	FindDueWebhookDeliveries(now time.Time, limit int) ([]entity.WebhookDeliveryEntity, error)
+	SaveWebhookDeliveriesWithTx(deliveries []entity.WebhookDeliveryEntity, tx *gorm.DB) error
+	SaveWebhookDelivery(delivery entity.WebhookDeliveryEntity) error
+}
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(db *gorm.DB) IWebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+func (w *WebhookRepo) FindWebhookSubscriptionById(subscriptionId string) (entity.WebhookSubscriptionEntity, error) {
+	var subscription entity.WebhookSubscriptionEntity
+	err := w.db.Where("id = ?", subscriptionId).First(&subscription).Error
+	return subscription, err
+}
+
+func (w *WebhookRepo) FindWebhookSubscriptions() []entity.WebhookSubscriptionEntity {
+	var subscriptions []entity.WebhookSubscriptionEntity
+	w.db.Order("created_at").Find(&subscriptions)
+	return subscriptions
+}
+
+func (w *WebhookRepo) FindActiveWebhookSubscriptions() ([]entity.WebhookSubscriptionEntity, error) {
+	var subscriptions []entity.WebhookSubscriptionEntity
+	err := w.db.Where("active = ?", true).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (w *WebhookRepo) SaveWebhookSubscription(subscription entity.WebhookSubscriptionEntity) error {
+	return w.db.Save(&subscription).Error
+}
+
+// DeleteWebhookSubscription also deletes the subscription's deliveries, so nothing is sent to it any more.
+func (w *WebhookRepo) DeleteWebhookSubscription(subscriptionId string) error {
+	return w.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", subscriptionId).Delete(&entity.WebhookDeliveryEntity{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", subscriptionId).Delete(&entity.WebhookSubscriptionEntity{}).Error
+	})
+}
+
+func (w *WebhookRepo) FindWebhookDeliveryById(deliveryId string) (entity.WebhookDeliveryEntity, error) {
+	var delivery entity.WebhookDeliveryEntity
+	err := w.db.Where("id = ?", deliveryId).First(&delivery).Error
+	return delivery, err
+}
+
+// FindWebhookDeliveries returns the subscription's newest deliveries, of any status when status is empty.
+func (w *WebhookRepo) FindWebhookDeliveries(subscriptionId string, status common.WebhookDeliveryStatus, limit int) []entity.WebhookDeliveryEntity {
+	var deliveries []entity.WebhookDeliveryEntity
+	query := w.db.Where("subscription_id = ?", subscriptionId)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	query.Order("created_at DESC, id DESC").Limit(limit).Find(&deliveries)
+	return deliveries
+}
+
+func (w *WebhookRepo) FindDueWebhookDeliveries(now time.Time, limit int) ([]entity.WebhookDeliveryEntity, error) {
+	var deliveries []entity.WebhookDeliveryEntity
+	err := w.db.Where("status = ? AND next_attempt_at <= ?", common.WebhookDeliveryPending, now).Order("next_attempt_at").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (w *WebhookRepo) SaveWebhookDeliveriesWithTx(deliveries []entity.WebhookDeliveryEntity, tx *gorm.DB) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return tx.Create(&deliveries).Error
+}
+
+func (w *WebhookRepo) SaveWebhookDelivery(delivery entity.WebhookDeliveryEntity) error {
+	return w.db.Save(&delivery).Error
+}
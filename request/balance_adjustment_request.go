@@ -0,0 +1,7 @@
+package request
+
+type BalanceAdjustmentReq struct {
+	Direction string `json:"direction" binding:"required,oneof=credit debit"` // credit raises the balance
+	Amount    uint   `json:"amount" binding:"required"`
+	Reason    string `json:"reason" binding:"required"`
+}
@@ -1,5 +1,6 @@
 package request
 
 type CreateWalletReq struct {
-	UserId string `json:"userId" binding:"required"`
+	UserId   string `json:"userId"` // admins only; otherwise the wallet belongs to the caller
+	Currency string `json:"currency" binding:"required"`
 }
@@ -0,0 +1,25 @@
+package request
+
+type FeeRuleReq struct { // creates a rule, or replaces it on update
+	Operation     string       `json:"operation" binding:"required"` // withdrawal, transfer_out or fx
+	Currency      string       `json:"currency" binding:"required"`
+	Kind          string       `json:"kind" binding:"required"` // flat, percentage or tiered
+	FlatAmount    uint         `json:"flatAmount"`
+	PercentageBps uint         `json:"percentageBps"` // basis points, 100 = 1%
+	MinAmount     uint         `json:"minAmount"`
+	MaxAmount     uint         `json:"maxAmount"` // 0 means no maximum
+	Tiers         []FeeTierReq `json:"tiers"`
+	Active        *bool        `json:"active"` // defaults to true
+}
+
+type FeeTierReq struct {
+	UpTo          uint `json:"upTo"` // inclusive; 0 on the last tier means no upper bound
+	FlatAmount    uint `json:"flatAmount"`
+	PercentageBps uint `json:"percentageBps"`
+}
+
+type FeePreviewReq struct {
+	TrxType              string `json:"trxType" binding:"required,oneof=withdrawal transfer_out"`
+	Amount               uint   `json:"amount" binding:"required"`
+	CounterpartyWalletId string `json:"counterpartyWalletId"` // transfers only; decides whether the fx fee applies
+}
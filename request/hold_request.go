@@ -0,0 +1,12 @@
+package request
+
+type CreateHoldReq struct {
+	Amount           uint `json:"amount" binding:"required"`
+	ExpiresInSeconds uint `json:"expiresInSeconds"` // optional; defaults to holds.defaultTtl
+}
+
+type CaptureHoldReq struct {
+	Amount               uint   `json:"amount"`               // optional; defaults to the full hold, the rest is released
+	CounterpartyWalletId string `json:"counterpartyWalletId"` // capture into a transfer; empty captures into a withdrawal
+	QuoteId              string `json:"quoteId"`              // required when transferring to a wallet in another currency
+}
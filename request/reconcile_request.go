@@ -0,0 +1,7 @@
+package request
+
+type ReconcileReq struct {
+	WalletIds []string `json:"walletIds"` // empty checks every wallet
+	Repair    bool     `json:"repair"`    // rebuild drifted balances from their transactions
+	Reason    string   `json:"reason"`    // recorded on the adjustment of a repair
+}
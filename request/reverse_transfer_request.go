@@ -0,0 +1,6 @@
+package request
+
+type ReverseTransferReq struct {
+	Amount uint `json:"amount"` // in the receiving wallet's currency; optional, defaults to what is left to reverse
+	Force  bool `json:"force"`  // admins only; reverse even if the receiving wallet no longer has the funds
+}
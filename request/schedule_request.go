@@ -0,0 +1,18 @@
+package request
+
+import "time"
+
+type CreateScheduleReq struct {
+	Amount               uint       `json:"amount" binding:"required"`
+	CounterpartyWalletId string     `json:"counterpartyWalletId" binding:"required"`
+	Cron                 string     `json:"cron"`     // 5-field cron in UTC, e.g. "0 0 1 * *"; or
+	Interval             string     `json:"interval"` // a duration, e.g. "168h"
+	StartAt              *time.Time `json:"startAt"`  // optional; first occurrence not before this
+}
+
+type UpdateScheduleReq struct {
+	Amount   *uint   `json:"amount"`
+	Cron     *string `json:"cron"` // setting either replaces the rule
+	Interval *string `json:"interval"`
+	Status   string  `json:"status" binding:"omitempty,oneof=active paused"`
+}
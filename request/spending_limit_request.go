@@ -0,0 +1,8 @@
+package request
+
+type SpendingLimitReq struct { // replaces the wallet's override
+	PerTransaction *uint  `json:"perTransaction"` // minor units; null keeps the configured default, 0 removes the limit
+	Daily          *uint  `json:"daily"`          // rolling 24 hours
+	Weekly         *uint  `json:"weekly"`         // rolling 7 days
+	Reason         string `json:"reason" binding:"required"`
+}
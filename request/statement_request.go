@@ -0,0 +1,9 @@
+package request
+
+import "time"
+
+type StatementReq struct { // query string of GET /wallets/:walletId/statements
+	From   *time.Time `form:"from" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"` // inclusive, RFC 3339
+	To     *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`                      // exclusive, RFC 3339, defaults to now
+	Format string     `form:"format" binding:"omitempty,oneof=csv ndjson camt053"`             // defaults to csv
+}
@@ -0,0 +1,6 @@
+package request
+
+type TransferQuoteReq struct {
+	Amount               uint   `json:"amount" binding:"required"`
+	CounterpartyWalletId string `json:"counterpartyWalletId" binding:"required"`
+}
@@ -3,4 +3,5 @@ package request
 type TransferReq struct {
 	Amount               uint   `json:"amount" binding:"required"`
 	CounterpartyWalletId string `json:"counterpartyWalletId" binding:"required"`
+	QuoteId              string `json:"quoteId"` // required when the wallets hold different currencies
 }
@@ -0,0 +1,14 @@
+package request
+
+import "time"
+
+type TrxHistoryReq struct { // query string of GET /wallets/:walletId/transactions
+	Cursor               string     `form:"cursor"`
+	Limit                int        `form:"limit" binding:"omitempty,min=1,max=500"`
+	TrxTypes             []string   `form:"trxType"` // repeatable
+	MinAmount            *uint      `form:"minAmount"`
+	MaxAmount            *uint      `form:"maxAmount"`
+	From                 *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"` // inclusive, RFC 3339
+	To                   *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`   // exclusive, RFC 3339
+	CounterpartyWalletId string     `form:"counterpartyWalletId"`
+}
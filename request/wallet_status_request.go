@@ -0,0 +1,8 @@
+package request
+
+type WalletStatusReq struct {
+	Status          string `json:"status" binding:"required"`     // active, frozen_debits, frozen or closed
+	ReasonCode      string `json:"reasonCode" binding:"required"` // see common.WalletStatusReason
+	Note            string `json:"note"`                          // required with reason code other
+	SweepToWalletId string `json:"sweepToWalletId"`               // closing only; receives the remaining balance
+}
@@ -0,0 +1,13 @@
+package request
+
+type CreateWebhookSubscriptionReq struct {
+	Url        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"eventTypes"` // empty subscribes to every event type
+	Secret     string   `json:"secret"`     // optional; generated when empty
+}
+
+type UpdateWebhookSubscriptionReq struct {
+	Url        *string   `json:"url"`
+	EventTypes *[]string `json:"eventTypes"`
+	Active     *bool     `json:"active"`
+}
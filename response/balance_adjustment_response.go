@@ -0,0 +1,22 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type BalanceAdjustmentResponse struct {
+	AdjustmentId  string
+	WalletId      string
+	Kind          common.AdjustmentKind
+	Direction     common.EntryDirection
+	Amount        uint
+	Currency      string
+	Exponent      int
+	BalanceBefore uint
+	BalanceAfter  uint
+	Reason        string
+	Actor         string
+	TransactionId string // manual adjustments only
+	CreatedAt     time.Time
+}
@@ -0,0 +1,40 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type FeeRuleResponse struct {
+	FeeRuleId     string
+	Operation     common.FeeOperation
+	Currency      string
+	Exponent      int
+	Kind          common.FeeKind
+	FlatAmount    uint
+	PercentageBps uint
+	MinAmount     uint
+	MaxAmount     uint
+	Tiers         []common.FeeTier
+	Active        bool
+	CreatedBy     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type FeePreviewResponse struct {
+	WalletId   string
+	TrxType    common.TrxType
+	Amount     uint
+	Currency   string
+	Exponent   int
+	Fees       []FeeLineResponse
+	TotalFee   uint
+	TotalDebit uint // Amount plus TotalFee, what the wallet needs available
+}
+
+type FeeLineResponse struct {
+	Operation common.FeeOperation
+	FeeRuleId string
+	Amount    uint
+}
@@ -0,0 +1,19 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type HoldResponse struct {
+	HoldId         string
+	WalletId       string
+	Amount         uint
+	CapturedAmount uint
+	Currency       string
+	Exponent       int
+	Status         common.HoldStatus
+	TransactionId  string // withdrawal or transfer the hold was captured into
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
@@ -0,0 +1,22 @@
+package response
+
+import "time"
+
+type ReconciliationReport struct {
+	WalletsChecked int
+	Drifts         []WalletDriftResponse
+	Repaired       int
+	StartedAt      time.Time
+	FinishedAt     time.Time
+}
+
+type WalletDriftResponse struct {
+	WalletId        string
+	Currency        string
+	Exponent        int
+	Balance         uint  // cached balance when checked
+	ReplayedBalance int64 // sum of the wallet's transactions
+	Delta           int64 // Balance - ReplayedBalance
+	Repaired        bool
+	AdjustmentId    string
+}
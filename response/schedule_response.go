@@ -0,0 +1,34 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type ScheduleResponse struct {
+	ScheduleId           string
+	WalletId             string
+	CounterpartyWalletId string
+	Amount               uint
+	Currency             string
+	Exponent             int
+	Cron                 string
+	Interval             string
+	Status               common.ScheduleStatus
+	NextRunAt            time.Time
+	Attempt              int // failed attempts of the next occurrence so far
+	LastRunAt            *time.Time
+	LastOutcome          common.ScheduleRunOutcome
+	CreatedAt            time.Time
+}
+
+type ScheduleRunResponse struct {
+	RunId         string
+	ScheduleId    string
+	ScheduledFor  time.Time
+	Attempt       int
+	Outcome       common.ScheduleRunOutcome
+	TransactionId string
+	Error         string
+	CreatedAt     time.Time
+}
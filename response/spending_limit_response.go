@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type SpendingLimitsResponse struct {
+	WalletId       string
+	Currency       string
+	Exponent       int
+	PerTransaction uint // 0 means no limit
+	Daily          uint
+	Weekly         uint
+	DailySpent     uint // withdrawals and transfers out in the last 24 hours
+	WeeklySpent    uint // withdrawals and transfers out in the last 7 days
+	Override       *SpendingLimitOverrideResponse // nil when the wallet uses the configured defaults
+}
+
+type SpendingLimitOverrideResponse struct {
+	PerTransaction *uint
+	Daily          *uint
+	Weekly         *uint
+	Reason         string
+	UpdatedBy      string
+	UpdatedAt      time.Time
+}
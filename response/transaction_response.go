@@ -0,0 +1,25 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type TransactionResponse struct { // Transaction history entry
+	TransactionId        string
+	WalletId             string
+	Amount               uint
+	Currency             string
+	Exponent             int
+	CounterpartyWalletId string
+	CounterpartyAmount   uint
+	CounterpartyCurrency string
+	FxRate               string
+	TrxType              common.TrxType
+	GroupId              string
+	ReversedAmount       uint   // transfers only; equal to Amount once fully reversed
+	ReversalOf           string // reversal rows only; GroupId of the reversed transfer
+	Fee                  uint   // charged rows only; total of the fee rows posted on top
+	FeeOf                string // fee rows only; TransactionId of the charged row
+	CreatedAt            time.Time
+}
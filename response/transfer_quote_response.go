@@ -0,0 +1,17 @@
+package response
+
+import "time"
+
+type TransferQuoteResponse struct {
+	QuoteId              string
+	WalletId             string
+	CounterpartyWalletId string
+	SourceAmount         uint
+	SourceCurrency       string
+	SourceExponent       int
+	DestinationAmount    uint
+	DestinationCurrency  string
+	DestinationExponent  int
+	Rate                 string
+	ExpiresAt            time.Time
+}
@@ -0,0 +1,6 @@
+package response
+
+type TrxHistoryResponse struct {
+	Transactions []TransactionResponse
+	NextCursor   string // empty on the last page
+}
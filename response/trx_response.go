@@ -5,4 +5,11 @@ type TrxResponse struct { // Deposit or Withdrawal or Transfer
 	WalletId       string
 	Amount         uint
 	CurrentBalance uint
+	Fee            uint // charged on top of Amount, in the same currency
+	Currency       string
+	Exponent       int
+
+	CounterpartyAmount   uint // set for transfers
+	CounterpartyCurrency string
+	FxRate               string
 }
@@ -1,7 +1,13 @@
 package response
 
+import "wallet-app/common"
+
 type WalletResponse struct {
-	WalletId       string
-	UserId         string
-	CurrentBalance uint
+	WalletId         string
+	UserId           string
+	CurrentBalance   uint
+	AvailableBalance uint // CurrentBalance minus active holds
+	Currency         string
+	Exponent         int
+	Status           common.WalletStatus
 }
@@ -0,0 +1,18 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type WalletStatusChangeResponse struct {
+	ChangeId   string
+	WalletId   string
+	FromStatus common.WalletStatus
+	ToStatus   common.WalletStatus
+	ReasonCode common.WalletStatusReason
+	Note       string
+	Actor      string
+	SweepTrxId string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,38 @@
+package response
+
+import (
+	"time"
+	"wallet-app/common"
+)
+
+type WebhookSubscriptionResponse struct {
+	SubscriptionId string
+	Url            string
+	EventTypes     []string
+	Active         bool
+	Secret         string // only returned when the subscription is created
+	CreatedAt      time.Time
+}
+
+type WebhookDeliveryResponse struct {
+	DeliveryId     string
+	EventId        string
+	SubscriptionId string
+	Status         common.WebhookDeliveryStatus
+	Attempt        int // failed attempts so far
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+}
+
+// WebhookEventPayload is the body posted to webhook endpoints. Transfers and reversals carry
+// both wallets' rows.
+type WebhookEventPayload struct {
+	EventId      string
+	EventType    common.EventType
+	WalletId     string
+	Transactions []TransactionResponse
+	CreatedAt    time.Time
+}
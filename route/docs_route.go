@@ -0,0 +1,13 @@
+package route
+
+import (
+	"wallet-app/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InitDocsRoutes serves the OpenAPI document and Swagger UI without authentication.
+func InitDocsRoutes(r *gin.Engine) {
+	r.GET("/openapi.json", controller.GetOpenApiSpec)
+	r.GET("/docs", controller.GetSwaggerUi)
+}
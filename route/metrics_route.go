@@ -0,0 +1,13 @@
+package route
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InitMetricsRoutes serves Prometheus metrics without authentication; keep /metrics off the public
+// network or in front of the scraper only.
+func InitMetricsRoutes(r *gin.Engine, metricsHandler http.Handler) {
+	r.GET("/metrics", gin.WrapH(metricsHandler))
+}
@@ -6,16 +6,60 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func InitRoutes(r *gin.Engine, controller *controller.WalletController) {
-	r.POST("/wallets", controller.CreateWallet)
-	r.GET("/wallets/user/:userId", controller.GetWalletsByUserId)
+func InitRoutes(r *gin.Engine, authenticate gin.HandlerFunc, controller *controller.WalletController, scheduleController *controller.ScheduleController, webhookController *controller.WebhookController, feeController *controller.FeeController) {
+	api := r.Group("", authenticate)
 
-	walletRoute := r.Group("/wallets/:walletId")
+	api.POST("/wallets", controller.CreateWallet)
+	api.GET("/wallets/user/:userId", controller.GetWalletsByUserId)
+
+	walletRoute := api.Group("/wallets/:walletId")
 	walletRoute.POST("/deposit", controller.DepositMoney)
 	walletRoute.POST("/withdraw", controller.WithdrawMoney)
 	walletRoute.POST("/transfer", controller.TransferMoney)
+	walletRoute.POST("/transfer/quote", controller.QuoteTransfer)
+	walletRoute.POST("/fees/preview", controller.PreviewFees)
 	walletRoute.GET("/balance", controller.GetBalance)
 	walletRoute.GET("/transactions", controller.GetTransactions)
+	walletRoute.GET("/statements", controller.ExportStatement)
+	walletRoute.POST("/holds", controller.CreateHold)
+	walletRoute.GET("/holds", controller.GetHolds)
+	walletRoute.GET("/limits", controller.GetSpendingLimits)
+
+	api.PUT("/admin/wallets/:walletId/limits", controller.SetSpendingLimits)
+	api.DELETE("/admin/wallets/:walletId/limits", controller.DeleteSpendingLimits)
+	api.POST("/admin/wallets/:walletId/status", controller.ChangeWalletStatus)
+	api.GET("/admin/wallets/:walletId/status", controller.GetWalletStatusChanges)
+
+	scheduleRoute := walletRoute.Group("/schedules")
+	scheduleRoute.POST("", scheduleController.CreateSchedule)
+	scheduleRoute.GET("", scheduleController.GetSchedules)
+	scheduleRoute.GET("/:scheduleId", scheduleController.GetSchedule)
+	scheduleRoute.PUT("/:scheduleId", scheduleController.UpdateSchedule)
+	scheduleRoute.DELETE("/:scheduleId", scheduleController.CancelSchedule)
+	scheduleRoute.GET("/:scheduleId/runs", scheduleController.GetScheduleRuns)
+
+	api.POST("/transfers/:groupId/reverse", controller.ReverseTransfer)
+
+	holdRoute := api.Group("/holds/:holdId")
+	holdRoute.POST("/capture", controller.CaptureHold)
+	holdRoute.POST("/release", controller.ReleaseHold)
+
+	webhookRoute := api.Group("/webhooks")
+	webhookRoute.POST("", webhookController.CreateWebhookSubscription)
+	webhookRoute.GET("", webhookController.GetWebhookSubscriptions)
+	webhookRoute.GET("/:subscriptionId", webhookController.GetWebhookSubscription)
+	webhookRoute.PUT("/:subscriptionId", webhookController.UpdateWebhookSubscription)
+	webhookRoute.DELETE("/:subscriptionId", webhookController.DeleteWebhookSubscription)
+	webhookRoute.GET("/:subscriptionId/deliveries", webhookController.GetWebhookDeliveries)
+	api.POST("/webhook-deliveries/:deliveryId/redeliver", webhookController.RedeliverWebhook)
+
+	feeRuleRoute := api.Group("/admin/fee-rules")
+	feeRuleRoute.POST("", feeController.CreateFeeRule)
+	feeRuleRoute.GET("", feeController.GetFeeRules)
+	feeRuleRoute.GET("/:feeRuleId", feeController.GetFeeRule)
+	feeRuleRoute.PUT("/:feeRuleId", feeController.UpdateFeeRule)
+	feeRuleRoute.DELETE("/:feeRuleId", feeController.DeleteFeeRule)
 
-	r.DELETE("/delete-all", controller.DeleteAll)
+	api.POST("/admin/reconciliation", controller.ReconcileWallets)
+	api.DELETE("/delete-all", controller.DeleteAll)
 }
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"time"
+	"wallet-app/repo"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LeasedJob runs a background job on at most one app instance at a time. Before every tick the instance
+// takes or renews a lease row; the others skip the tick until the lease expires without being renewed.
+type LeasedJob struct {
+	log       *logrus.Logger
+	leaseRepo repo.ILeaseRepo
+	name      string
+	holder    string
+	interval  time.Duration
+	leaseTtl  time.Duration
+	run       func(now time.Time)
+}
+
+func NewLeasedJob(log *logrus.Logger, leaseRepo repo.ILeaseRepo, name string, holder string, interval time.Duration, leaseTtl time.Duration, run func(now time.Time)) *LeasedJob {
+	return &LeasedJob{log: log, leaseRepo: leaseRepo, name: name, holder: holder, interval: interval, leaseTtl: leaseTtl, run: run}
+}
+
+func (j *LeasedJob) Start() {
+	for range time.Tick(j.interval) {
+		j.Tick(time.Now().UTC())
+	}
+}
+
+// Tick runs the job once if this instance holds the lease, and reports whether it did.
+func (j *LeasedJob) Tick(now time.Time) bool {
+	acquired, err := j.leaseRepo.TryAcquireLease(j.name, j.holder, j.leaseTtl, now)
+	if err != nil {
+		j.log.Errorf("Err acquiring lease; name:%s %v", j.name, err)
+		return false
+	}
+	if !acquired {
+		return false
+	}
+	j.run(now)
+	return true
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdjustBalance corrects a wallet's balance by hand. It posts a journal against the adjustment system
+// account and writes an adjustment_in or adjustment_out transaction row, so reconciliation replays it,
+// and audits the change in balance_adjustments. Frozen wallets can be adjusted, closed ones cannot.
+func (w *WalletService) AdjustBalance(ctx context.Context, principal auth.Principal, walletId string, req request.BalanceAdjustmentReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("AdjustBalance; walletId:%s direction:%s amount:%d", walletId, req.Direction, req.Amount)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	direction := common.EntryDirection(req.Direction)
+	if req.Amount == 0 || req.Reason == "" || (direction != common.EntryDirectionCredit && direction != common.EntryDirectionDebit) {
+		w.log.Errorf("Invalid balance adjustment; walletId:%s", walletId)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidBalanceAdjustment}
+	}
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		locked, err := w.lockWallets(dbTx, walletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		if wallet.Status == common.WalletStatusClosed {
+			w.log.Errorf("Wallet closed; walletId:%s", walletId)
+			return nil, apperror.ErrWalletClosed
+		}
+		if direction == common.EntryDirectionDebit && wallet.Balance < req.Amount {
+			w.log.Errorf("Insufficient amount; walletId:%s balance:%d amount:%d", walletId, wallet.Balance, req.Amount)
+			return nil, apperror.ErrInsufficientAmount
+		}
+
+		now := time.Now().UTC()
+		adjustment := entity.BalanceAdjustmentEntity{
+			ID:            uuid.New().String(),
+			WalletId:      wallet.ID,
+			Kind:          common.AdjustmentKindManual,
+			Direction:     direction,
+			Amount:        req.Amount,
+			Currency:      wallet.Currency,
+			BalanceBefore: wallet.Balance,
+			Reason:        req.Reason,
+			Actor:         principal.UserId,
+			JournalId:     uuid.New().String(),
+			TrxId:         uuid.New().String(),
+			CreatedAt:     now,
+		}
+		adjustmentAccount := SystemAccount(common.SystemAccountAdjustment, wallet.Currency)
+		trx := entity.TrxEntity{ID: adjustment.TrxId, WalletId: wallet.ID, Amount: req.Amount, Currency: wallet.Currency, GroupId: adjustment.ID, CreatedAt: now}
+		var legs []LedgerLeg
+		if direction == common.EntryDirectionCredit {
+			trx.TrxType = common.TrxTypeAdjustmentIn
+			legs = []LedgerLeg{Debit(adjustmentAccount, req.Amount), Credit(WalletAccount(wallet), req.Amount)}
+		} else {
+			trx.TrxType = common.TrxTypeAdjustmentOut
+			legs = []LedgerLeg{Debit(WalletAccount(wallet), req.Amount), Credit(adjustmentAccount, req.Amount)}
+		}
+		if err := w.postJournal(adjustment.JournalId, legs, []*entity.WalletEntity{&wallet}, dbTx); err != nil {
+			return nil, err
+		}
+		if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
+			w.log.Errorf("Err saving wallet; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
+			w.log.Errorf("Err saving trx; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		adjustment.BalanceAfter = wallet.Balance
+		if err := w.adjustmentRepo.SaveBalanceAdjustmentWithTx(adjustment, dbTx); err != nil {
+			w.log.Errorf("Err saving balance adjustment; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		if err := w.writeOutboxEvent(common.EventTypeAdjustment, walletId, []entity.TrxEntity{trx}, dbTx); err != nil {
+			w.log.Errorf("Err saving outbox event; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+
+		w.log.Infof("Balance adjusted; walletId:%s balance:%d adjustmentId:%s actor:%s", walletId, wallet.Balance, adjustment.ID, principal.UserId)
+		return w.mapper.ToBalanceAdjustmentResponse(adjustment), nil
+	})
+}
+
+func (w *WalletService) GetBalanceAdjustments(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("GetBalanceAdjustments; walletId:%s", walletId)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	if _, err := w.walletRepo.FindWalletById(ctx, walletId); errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	} else if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: w.mapper.ToBalanceAdjustmentResponses(w.adjustmentRepo.FindBalanceAdjustmentsByWalletId(walletId))}
+}
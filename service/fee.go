@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// feeCharge is one fee to post on top of a withdrawal or transfer.
+type feeCharge struct {
+	Operation common.FeeOperation
+	RuleId    string
+	Amount    uint
+}
+
+func totalFee(fees []feeCharge) uint {
+	total := uint(0)
+	for _, fee := range fees {
+		total += fee.Amount
+	}
+	return total
+}
+
+// feeOperations are the rules that apply to a withdrawal or a transfer; a transfer between currencies
+// pays the fx fee on top of the transfer fee.
+func feeOperations(trxType common.TrxType, crossCurrency bool) []common.FeeOperation {
+	if trxType == common.TrxTypeWithdrawal {
+		return []common.FeeOperation{common.FeeOperationWithdrawal}
+	}
+	if crossCurrency {
+		return []common.FeeOperation{common.FeeOperationTransferOut, common.FeeOperationFx}
+	}
+	return []common.FeeOperation{common.FeeOperationTransferOut}
+}
+
+func FeeSchedule(rule entity.FeeRuleEntity) (common.FeeSchedule, error) {
+	schedule := common.FeeSchedule{
+		Kind:          rule.Kind,
+		FlatAmount:    rule.FlatAmount,
+		PercentageBps: rule.PercentageBps,
+		MinAmount:     rule.MinAmount,
+		MaxAmount:     rule.MaxAmount,
+	}
+	if rule.Tiers != "" {
+		if err := json.Unmarshal([]byte(rule.Tiers), &schedule.Tiers); err != nil {
+			return schedule, err
+		}
+	}
+	return schedule, schedule.Validate()
+}
+
+// findFees computes the fees on amount from the active rules. The house revenue wallet pays no fees.
+// A nil dbTx reads outside a transaction, for previews.
+func (w *WalletService) findFees(wallet entity.WalletEntity, operations []common.FeeOperation, amount uint, dbTx *gorm.DB) ([]feeCharge, apperror.AppError) {
+	if revenueWalletId, ok := w.revenueWalletId(wallet.Currency); ok && revenueWalletId == wallet.ID {
+		return nil, apperror.AppError{}
+	}
+	var rules []entity.FeeRuleEntity
+	var err error
+	if dbTx == nil {
+		rules, err = w.feeRuleRepo.FindActiveFeeRules(operations, wallet.Currency)
+	} else {
+		rules, err = w.feeRuleRepo.FindActiveFeeRulesWithTx(operations, wallet.Currency, dbTx)
+	}
+	if err != nil {
+		w.log.Errorf("Err finding fee rules; walletId:%s %v", wallet.ID, err)
+		return nil, apperror.ErrInternalServer
+	}
+
+	var fees []feeCharge
+	for _, rule := range rules {
+		schedule, err := FeeSchedule(rule)
+		if err != nil {
+			w.log.Errorf("Invalid fee rule; feeRuleId:%s %v", rule.ID, err)
+			return nil, apperror.ErrInternalServer
+		}
+		if fee := schedule.Calculate(amount); fee > 0 {
+			fees = append(fees, feeCharge{Operation: rule.Operation, RuleId: rule.ID, Amount: fee})
+		}
+	}
+	return fees, apperror.AppError{}
+}
+
+func (w *WalletService) revenueWalletId(currency string) (string, bool) {
+	walletId, ok := w.cfg.Fees.RevenueWallets[lowerCurrency(currency)]
+	return walletId, ok && walletId != ""
+}
+
+// lowerCurrency is the key of a currency in config maps, which viper lower-cases.
+func lowerCurrency(currency string) string {
+	return strings.ToLower(currency)
+}
+
+// feeRevenueWalletId is the house revenue wallet that fees paid by the wallet are credited to, for the
+// caller to lock together with the other wallets (see lockWallets). The wallet is read without a lock,
+// as its currency never changes. It is empty when the wallet does not exist or no revenue wallet is set
+// for its currency.
+func (w *WalletService) feeRevenueWalletId(walletId string, dbTx *gorm.DB) (string, error) {
+	if len(w.cfg.Fees.RevenueWallets) == 0 {
+		return "", nil
+	}
+	wallet, err := w.walletRepo.FindWalletByIdWithTx(walletId, dbTx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return "", dbErr(err)
+	}
+	revenueWalletId, _ := w.revenueWalletId(wallet.Currency)
+	return revenueWalletId, nil
+}
+
+// postFees debits the fees from the locked wallet on top of the charged row and credits them to the
+// house revenue wallet of the currency, which the caller locked with the wallet (see
+// feeRevenueWalletId), so taking its lock again here does not wait. It returns the fee and fee_in rows.
+func (w *WalletService) postFees(wallet *entity.WalletEntity, charged entity.TrxEntity, fees []feeCharge, dbTx *gorm.DB) ([]entity.TrxEntity, error) {
+	if len(fees) == 0 {
+		return nil, nil
+	}
+	revenueWalletId, ok := w.revenueWalletId(wallet.Currency)
+	if !ok {
+		w.log.Errorf("Fee revenue wallet not configured; currency:%s", wallet.Currency)
+		return nil, apperror.ErrFeeRevenueWalletNotConfigured
+	}
+	revenueWallet, err := w.walletRepo.FindWalletByIdWithTx(revenueWalletId, forUpdate(dbTx))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Err locking wallet; walletId:%s %v", revenueWalletId, err)
+		return nil, dbErr(err)
+	}
+	if err != nil || revenueWallet.Currency != wallet.Currency {
+		w.log.Errorf("Fee revenue wallet not usable; walletId:%s currency:%s %v", revenueWalletId, wallet.Currency, err)
+		return nil, apperror.ErrFeeRevenueWalletNotConfigured
+	}
+
+	groupId := uuid.New().String()
+	legs := make([]LedgerLeg, 0, 2*len(fees))
+	for _, fee := range fees {
+		legs = append(legs, Debit(WalletAccount(*wallet), fee.Amount), Credit(WalletAccount(revenueWallet), fee.Amount))
+	}
+	if err := w.postJournal(groupId, legs, []*entity.WalletEntity{wallet, &revenueWallet}, dbTx); err != nil {
+		return nil, err
+	}
+	if err := w.walletRepo.SaveWalletsWithTx([]entity.WalletEntity{*wallet, revenueWallet}, dbTx); err != nil {
+		w.log.Error("Err saving wallets; ", err)
+		return nil, dbErr(err)
+	}
+
+	now := time.Now().UTC()
+	trxs := make([]entity.TrxEntity, 0, 2*len(fees))
+	for _, fee := range fees {
+		trxs = append(trxs,
+			entity.TrxEntity{ID: uuid.New().String(), WalletId: wallet.ID, Amount: fee.Amount, Currency: wallet.Currency, CounterpartyWalletId: revenueWallet.ID, TrxType: common.TrxTypeFee, GroupId: groupId, FeeOf: charged.ID, FeeRuleId: fee.RuleId, CreatedAt: now},
+			entity.TrxEntity{ID: uuid.New().String(), WalletId: revenueWallet.ID, Amount: fee.Amount, Currency: wallet.Currency, CounterpartyWalletId: wallet.ID, TrxType: common.TrxTypeFeeIn, GroupId: groupId, FeeOf: charged.ID, FeeRuleId: fee.RuleId, CreatedAt: now},
+		)
+	}
+	if err := w.trxRepo.SaveTrxsWithDbTx(trxs, dbTx); err != nil {
+		w.log.Error("Err saving trxs; ", err)
+		return nil, dbErr(err)
+	}
+	return trxs, nil
+}
+
+// PreviewFees is a dry run of the fees a withdrawal or transfer of the amount would pay right now.
+func (w *WalletService) PreviewFees(ctx context.Context, principal auth.Principal, walletId string, req request.FeePreviewReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("PreviewFees; walletId:%s", walletId)
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+
+	trxType := common.TrxType(req.TrxType)
+	crossCurrency := false
+	if trxType == common.TrxTypeTransferOut && req.CounterpartyWalletId != "" {
+		counterpartyWallet, err := w.walletRepo.FindWalletById(ctx, req.CounterpartyWalletId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.log.Errorf("CounterpartyWallet not found; walletId:%s %v", walletId, err)
+			return response.ResonseWrapper{Err: apperror.ErrCounterpartyWalletNotFound}
+		}
+		if err != nil {
+			w.log.Errorf("Err finding wallet; walletId:%s %v", req.CounterpartyWalletId, err)
+			return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+		}
+		crossCurrency = counterpartyWallet.Currency != wallet.Currency
+	}
+
+	fees, appErr := w.findFees(wallet, feeOperations(trxType, crossCurrency), req.Amount, nil)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	res := response.FeePreviewResponse{
+		WalletId: walletId,
+		TrxType:  trxType,
+		Amount:   req.Amount,
+		Currency: wallet.Currency,
+		Exponent: common.CurrencyExponent(wallet.Currency),
+		Fees:     make([]response.FeeLineResponse, 0, len(fees)),
+		TotalFee: totalFee(fees),
+	}
+	for _, fee := range fees {
+		res.Fees = append(res.Fees, response.FeeLineResponse{Operation: fee.Operation, FeeRuleId: fee.RuleId, Amount: fee.Amount})
+	}
+	res.TotalDebit = res.Amount + res.TotalFee
+	return response.ResonseWrapper{Data: res}
+}
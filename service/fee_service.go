@@ -0,0 +1,173 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// IFeeService manages the fee rules WalletService charges by. All operations are admin only.
+type IFeeService interface {
+	CreateFeeRule(principal auth.Principal, req request.FeeRuleReq) response.ResonseWrapper
+	GetFeeRules(principal auth.Principal) response.ResonseWrapper
+	GetFeeRule(principal auth.Principal, feeRuleId string) response.ResonseWrapper
+	UpdateFeeRule(principal auth.Principal, feeRuleId string, req request.FeeRuleReq) response.ResonseWrapper
+	DeleteFeeRule(principal auth.Principal, feeRuleId string) response.ResonseWrapper
+}
+
+type FeeService struct {
+	log         *logrus.Logger
+	cfg         *config.AppConfig
+	feeRuleRepo repo.IFeeRuleRepo
+	mapper      *mapper.AppMapper
+}
+
+func NewFeeService(log *logrus.Logger, cfg *config.AppConfig, feeRuleRepo repo.IFeeRuleRepo, mapper *mapper.AppMapper) IFeeService {
+	return &FeeService{log: log, cfg: cfg, feeRuleRepo: feeRuleRepo, mapper: mapper}
+}
+
+func (s *FeeService) CreateFeeRule(principal auth.Principal, req request.FeeRuleReq) response.ResonseWrapper {
+	s.log.Infof("CreateFeeRule; operation:%s currency:%s", req.Operation, req.Currency)
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	now := time.Now().UTC()
+	rule := entity.FeeRuleEntity{ID: uuid.New().String(), CreatedBy: principal.UserId, CreatedAt: now}
+	return s.saveFeeRule(rule, req, now)
+}
+
+func (s *FeeService) GetFeeRules(principal auth.Principal) response.ResonseWrapper {
+	s.log.Info("GetFeeRules")
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToFeeRuleResponses(s.feeRuleRepo.FindFeeRules())}
+}
+
+func (s *FeeService) GetFeeRule(principal auth.Principal, feeRuleId string) response.ResonseWrapper {
+	s.log.Infof("GetFeeRule; feeRuleId:%s", feeRuleId)
+	rule, appErr := s.findFeeRule(principal, feeRuleId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToFeeRuleResponse(rule)}
+}
+
+// UpdateFeeRule replaces the rule with the request; fees already charged keep their amount.
+func (s *FeeService) UpdateFeeRule(principal auth.Principal, feeRuleId string, req request.FeeRuleReq) response.ResonseWrapper {
+	s.log.Infof("UpdateFeeRule; feeRuleId:%s", feeRuleId)
+	rule, appErr := s.findFeeRule(principal, feeRuleId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return s.saveFeeRule(rule, req, time.Now().UTC())
+}
+
+func (s *FeeService) DeleteFeeRule(principal auth.Principal, feeRuleId string) response.ResonseWrapper {
+	s.log.Infof("DeleteFeeRule; feeRuleId:%s", feeRuleId)
+	if _, appErr := s.findFeeRule(principal, feeRuleId); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if err := s.feeRuleRepo.DeleteFeeRule(feeRuleId); err != nil {
+		s.log.Errorf("Err deleting fee rule; feeRuleId:%s %v", feeRuleId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{}
+}
+
+func (s *FeeService) saveFeeRule(rule entity.FeeRuleEntity, req request.FeeRuleReq, now time.Time) response.ResonseWrapper {
+	rule.Operation = common.FeeOperation(req.Operation)
+	rule.Currency = common.NormalizeCurrency(req.Currency)
+	rule.Kind = common.FeeKind(req.Kind)
+	rule.FlatAmount = req.FlatAmount
+	rule.PercentageBps = req.PercentageBps
+	rule.MinAmount = req.MinAmount
+	rule.MaxAmount = req.MaxAmount
+	rule.Active = req.Active == nil || *req.Active
+	rule.UpdatedAt = now
+
+	tiers := make([]common.FeeTier, 0, len(req.Tiers))
+	for _, tier := range req.Tiers {
+		tiers = append(tiers, common.FeeTier{UpTo: tier.UpTo, FlatAmount: tier.FlatAmount, PercentageBps: tier.PercentageBps})
+	}
+	rule.Tiers = ""
+	if len(tiers) > 0 {
+		body, _ := json.Marshal(tiers)
+		rule.Tiers = string(body)
+	}
+
+	if !rule.Operation.IsValid() {
+		s.log.Errorf("Invalid fee operation; operation:%s", req.Operation)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidFeeRule}
+	}
+	if !common.IsSupportedCurrency(rule.Currency) {
+		s.log.Errorf("Unsupported currency; currency:%s", req.Currency)
+		return response.ResonseWrapper{Err: apperror.ErrUnsupportedCurrency}
+	}
+	if _, err := FeeSchedule(rule); err != nil {
+		s.log.Errorf("Invalid fee rule; %v", err)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidFeeRule.WithDetails(err.Error())}
+	}
+
+	if rule.Active {
+		if walletId, ok := s.cfg.Fees.RevenueWallets[lowerCurrency(rule.Currency)]; !ok || walletId == "" {
+			s.log.Errorf("Fee revenue wallet not configured; currency:%s", rule.Currency)
+			return response.ResonseWrapper{Err: apperror.ErrFeeRevenueWalletNotConfigured}
+		}
+		active, err := s.feeRuleRepo.FindActiveFeeRules([]common.FeeOperation{rule.Operation}, rule.Currency)
+		if err != nil {
+			s.log.Error("Err finding fee rules; ", err)
+			return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+		}
+		for _, other := range active {
+			if other.ID != rule.ID {
+				s.log.Errorf("Active fee rule exists; operation:%s currency:%s feeRuleId:%s", rule.Operation, rule.Currency, other.ID)
+				return response.ResonseWrapper{Err: apperror.ErrFeeRuleConflict}
+			}
+		}
+	}
+
+	if err := s.feeRuleRepo.SaveFeeRule(rule); err != nil {
+		s.log.Errorf("Err saving fee rule; feeRuleId:%s %v", rule.ID, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToFeeRuleResponse(rule)}
+}
+
+func (s *FeeService) authorize(principal auth.Principal) apperror.AppError {
+	if !principal.IsAdmin() {
+		s.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return apperror.ErrForbidden
+	}
+	return apperror.AppError{}
+}
+
+func (s *FeeService) findFeeRule(principal auth.Principal, feeRuleId string) (entity.FeeRuleEntity, apperror.AppError) {
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return entity.FeeRuleEntity{}, appErr
+	}
+	rule, err := s.feeRuleRepo.FindFeeRuleById(feeRuleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Errorf("Fee rule not found; feeRuleId:%s", feeRuleId)
+		return rule, apperror.ErrFeeRuleNotFound
+	}
+	if err != nil {
+		s.log.Errorf("Err finding fee rule; feeRuleId:%s %v", feeRuleId, err)
+		return rule, apperror.ErrInternalServer
+	}
+	return rule, apperror.AppError{}
+}
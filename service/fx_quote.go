@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/entity"
+	"wallet-app/request"
+
+	"gorm.io/gorm"
+)
+
+// consumeFxQuote validates the quote against the locked wallets and marks it used within dbTx,
+// so a quote can back at most one committed transfer.
+func (w *WalletService) consumeFxQuote(req request.TransferReq, wallet entity.WalletEntity, counterpartyWallet entity.WalletEntity, dbTx *gorm.DB) (entity.FxQuoteEntity, error) {
+	quote, err := w.fxQuoteRepo.FindFxQuoteByIdWithTx(req.QuoteId, forUpdate(dbTx))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Fx quote not found; quoteId:%s", req.QuoteId)
+		return quote, apperror.ErrFxQuoteNotFound
+	}
+	if err != nil {
+		w.log.Errorf("Err finding fx quote; quoteId:%s %v", req.QuoteId, err)
+		return quote, dbErr(err)
+	}
+	w.log.Info("Quote ", quote)
+
+	if quote.WalletId != wallet.ID || quote.CounterpartyWalletId != counterpartyWallet.ID || quote.SourceAmount != req.Amount ||
+		quote.SourceCurrency != wallet.Currency || quote.DestinationCurrency != counterpartyWallet.Currency {
+		w.log.Errorf("Fx quote does not match transfer; quoteId:%s", req.QuoteId)
+		return quote, apperror.ErrFxQuoteMismatch
+	}
+	if quote.ConsumedAt != nil {
+		w.log.Errorf("Fx quote already used; quoteId:%s", req.QuoteId)
+		return quote, apperror.ErrFxQuoteAlreadyUsed
+	}
+	now := time.Now()
+	if quote.ExpiresAt.Before(now) {
+		w.log.Errorf("Fx quote expired; quoteId:%s expiresAt:%v", req.QuoteId, quote.ExpiresAt)
+		return quote, apperror.ErrFxQuoteExpired
+	}
+
+	quote.ConsumedAt = &now
+	if err := w.fxQuoteRepo.SaveFxQuoteWithTx(quote, dbTx); err != nil {
+		w.log.Errorf("Err saving fx quote; quoteId:%s %v", req.QuoteId, err)
+		return quote, dbErr(err)
+	}
+	return quote, nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const expiredHoldBatchSize = 100
+
+func (w *WalletService) CreateHold(ctx context.Context, principal auth.Principal, walletId string, req request.CreateHoldReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("CreateHold; walletId:%s", walletId)
+
+	ttl := w.cfg.Holds.DefaultTtl
+	if req.ExpiresInSeconds > 0 {
+		ttl = time.Duration(req.ExpiresInSeconds) * time.Second
+	}
+	if ttl <= 0 || (w.cfg.Holds.MaxTtl > 0 && ttl > w.cfg.Holds.MaxTtl) {
+		w.log.Errorf("Invalid hold expiry; walletId:%s ttl:%s", walletId, ttl)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidHoldExpiry}
+	}
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		locked, err := w.lockWallets(dbTx, walletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkCanDebit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkAvailableBalance(wallet, req.Amount, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		now := time.Now().UTC()
+		hold := entity.HoldEntity{
+			ID:        uuid.New().String(),
+			WalletId:  walletId,
+			Amount:    req.Amount,
+			Currency:  wallet.Currency,
+			Status:    common.HoldStatusActive,
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := w.holdRepo.SaveHoldWithTx(hold, dbTx); err != nil {
+			w.log.Errorf("Err saving hold; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		trx := entity.TrxEntity{ID: uuid.New().String(), WalletId: walletId, Amount: req.Amount, Currency: wallet.Currency, TrxType: common.TrxTypeHold, HoldId: hold.ID, CreatedAt: now}
+		if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
+			w.log.Errorf("Err saving trx; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+
+		w.log.Info("Hold ", hold)
+
+		return w.mapper.ToHoldResponse(hold), nil
+	})
+}
+
+// CaptureHold settles the hold into a withdrawal, or into a transfer when a counterparty is given.
+// A hold is captured once; capturing less than the held amount releases the remainder.
+func (w *WalletService) CaptureHold(ctx context.Context, principal auth.Principal, holdId string, req request.CaptureHoldReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("CaptureHold; holdId:%s", holdId)
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+		locked, hold, err := w.lockHold(principal, holdId, req.CounterpartyWalletId, true, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		wallet := locked[hold.WalletId]
+		if !hold.IsActive(time.Now().UTC()) {
+			w.log.Errorf("Hold expired; holdId:%s expiresAt:%s", holdId, hold.ExpiresAt)
+			return nil, apperror.ErrHoldExpired
+		}
+
+		amount := req.Amount
+		if amount == 0 {
+			amount = hold.Amount
+		}
+		if amount > hold.Amount {
+			w.log.Errorf("Capture exceeds hold; holdId:%s amount:%d held:%d", holdId, amount, hold.Amount)
+			return nil, apperror.ErrHoldCaptureExceedsAmount
+		}
+		if appErr := w.checkCanDebit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkSpendingLimits(wallet, amount, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		var counterpartyWallet entity.WalletEntity
+		operations := feeOperations(common.TrxTypeWithdrawal, false)
+		if req.CounterpartyWalletId != "" {
+			if req.CounterpartyWalletId == wallet.ID {
+				w.log.Errorf("CounterpartyWalletId same as walletId; walletId:%s counterpartyWalletId:%s", wallet.ID, req.CounterpartyWalletId)
+				return nil, apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet
+			}
+			var ok bool
+			counterpartyWallet, ok = locked[req.CounterpartyWalletId]
+			if !ok {
+				w.log.Errorf("CounterpartyWallet not found; walletId:%s counterpartyWalletId:%s", wallet.ID, req.CounterpartyWalletId)
+				return nil, apperror.ErrCounterpartyWalletNotFound
+			}
+			if appErr := w.checkCounterpartyCanCredit(counterpartyWallet); appErr.Code != 0 {
+				return nil, appErr
+			}
+			operations = feeOperations(common.TrxTypeTransferOut, wallet.Currency != counterpartyWallet.Currency)
+		}
+
+		fees, appErr := w.findFees(wallet, operations, amount, dbTx)
+		if appErr.Code != 0 {
+			return nil, appErr
+		}
+		// the hold covers the amount; what the fees need on top of it must be free of other holds
+		if debit := amount + totalFee(fees); debit > hold.Amount {
+			if appErr := w.checkAvailableBalance(wallet, debit-hold.Amount, dbTx); appErr.Code != 0 {
+				return nil, appErr
+			}
+		}
+
+		var trx entity.TrxEntity
+		if req.CounterpartyWalletId == "" {
+			trx, err = w.postWithdrawal(&wallet, amount, fees, hold.ID, dbTx)
+		} else {
+			transferReq := request.TransferReq{Amount: amount, CounterpartyWalletId: req.CounterpartyWalletId, QuoteId: req.QuoteId}
+			trx, err = w.postTransfer(&wallet, &counterpartyWallet, transferReq, fees, hold.ID, dbTx)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := w.closeHold(&hold, common.HoldStatusCaptured, amount, trx.ID, dbTx); err != nil {
+			w.log.Errorf("Err closing hold; holdId:%s %v", holdId, err)
+			return nil, dbErr(err)
+		}
+		return w.mapper.ToTrxResponse(trx, wallet.Balance), nil
+	})
+}
+
+func (w *WalletService) ReleaseHold(ctx context.Context, principal auth.Principal, holdId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("ReleaseHold; holdId:%s", holdId)
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+		_, hold, err := w.lockHold(principal, holdId, "", false, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		if !hold.IsActive(time.Now().UTC()) {
+			w.log.Errorf("Hold expired; holdId:%s expiresAt:%s", holdId, hold.ExpiresAt)
+			return nil, apperror.ErrHoldExpired
+		}
+
+		if err := w.closeHold(&hold, common.HoldStatusReleased, 0, "", dbTx); err != nil {
+			w.log.Errorf("Err closing hold; holdId:%s %v", holdId, err)
+			return nil, dbErr(err)
+		}
+		return w.mapper.ToHoldResponse(hold), nil
+	})
+}
+
+func (w *WalletService) GetHolds(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("GetHolds; walletId:%s", walletId)
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	holds := w.holdRepo.FindHoldsByWalletId(walletId)
+	return response.ResonseWrapper{Data: w.mapper.ToHoldResponses(holds)}
+}
+
+// ExpireHolds releases holds whose expiry has passed. Expired holds already stop counting against the
+// available balance; this records the release and frees the hold row. Data is the number expired.
+func (w *WalletService) ExpireHolds(ctx context.Context) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	holds, err := w.holdRepo.FindExpiredHolds(time.Now().UTC(), expiredHoldBatchSize)
+	if err != nil {
+		w.log.Error("Err finding expired holds; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	expired := 0
+	for _, hold := range holds {
+		if err := w.expireHold(ctx, hold.ID); err != nil {
+			w.log.Errorf("Err expiring hold; holdId:%s %v", hold.ID, err)
+			continue
+		}
+		expired++
+	}
+	if expired > 0 {
+		w.log.Infof("Expired holds; count:%d", expired)
+	}
+	return response.ResonseWrapper{Data: expired}
+}
+
+func (w *WalletService) expireHold(ctx context.Context, holdId string) error {
+	err := w.dbTxManager.InTx(ctx, func(uow *manager.UnitOfWork) error {
+		_, hold, err := w.lockHold(auth.System, holdId, "", false, uow.Tx())
+		if err != nil {
+			return err
+		}
+		if hold.IsActive(time.Now().UTC()) {
+			return nil
+		}
+		return w.closeHold(&hold, common.HoldStatusExpired, 0, "", uow.Tx())
+	})
+	if errors.Is(err, apperror.ErrHoldNotActive) {
+		return nil // captured or released since it was listed
+	}
+	return err
+}
+
+// closeHold settles the hold and records the part that was not captured as a hold_release row.
+func (w *WalletService) closeHold(hold *entity.HoldEntity, status common.HoldStatus, capturedAmount uint, trxId string, dbTx *gorm.DB) error {
+	now := time.Now().UTC()
+	hold.Status = status
+	hold.CapturedAmount = capturedAmount
+	hold.TrxId = trxId
+	hold.UpdatedAt = now
+	if err := w.holdRepo.SaveHoldWithTx(*hold, dbTx); err != nil {
+		return err
+	}
+
+	released := hold.Amount - capturedAmount
+	if released == 0 {
+		return nil
+	}
+	trx := entity.TrxEntity{ID: uuid.New().String(), WalletId: hold.WalletId, Amount: released, Currency: hold.Currency, TrxType: common.TrxTypeHoldRelease, HoldId: hold.ID, CreatedAt: now}
+	return w.trxRepo.SaveTrxWithDbTx(trx, dbTx)
+}
+
+// lockHold locks the hold's wallet, with counterpartyWalletId when a capture moves the money to
+// another wallet and the house revenue wallet when a capture may pay fees, and re-reads the hold under
+// that lock, so capture, release and expiry of the same hold are serialized. It returns the locked
+// wallets by id; the error is an AppError unless the lock may be retried.
+func (w *WalletService) lockHold(principal auth.Principal, holdId string, counterpartyWalletId string, capture bool, dbTx *gorm.DB) (map[string]entity.WalletEntity, entity.HoldEntity, error) {
+	hold, err := w.holdRepo.FindHoldByIdWithTx(holdId, dbTx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Hold not found; holdId:%s %v", holdId, err)
+		return nil, hold, apperror.ErrHoldNotFound
+	}
+	if err != nil {
+		w.log.Errorf("Err finding hold; holdId:%s %v", holdId, err)
+		return nil, hold, apperror.ErrInternalServer
+	}
+
+	revenueWalletId := ""
+	if capture {
+		if revenueWalletId, err = w.feeRevenueWalletId(hold.WalletId, dbTx); err != nil {
+			return nil, hold, err
+		}
+	}
+	locked, err := w.lockWallets(dbTx, hold.WalletId, counterpartyWalletId, revenueWalletId)
+	if err != nil {
+		return nil, hold, err
+	}
+	wallet, ok := locked[hold.WalletId]
+	if !ok {
+		w.log.Errorf("Wallet not found; walletId:%s", hold.WalletId)
+		return nil, hold, apperror.ErrWalletNotFound
+	}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return nil, hold, appErr
+	}
+
+	hold, err = w.holdRepo.FindHoldByIdWithTx(holdId, dbTx)
+	if err != nil {
+		w.log.Errorf("Err finding hold; holdId:%s %v", holdId, err)
+		return nil, hold, apperror.ErrInternalServer
+	}
+	if hold.Status != common.HoldStatusActive {
+		w.log.Errorf("Hold not active; holdId:%s status:%s", holdId, hold.Status)
+		return nil, hold, apperror.ErrHoldNotActive
+	}
+	return locked, hold, nil
+}
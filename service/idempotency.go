@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/common"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/response"
+
+	"gorm.io/gorm"
+)
+
+// requestFingerprint identifies the operation a key was first used for, so a retry
+// can be told apart from a different request that reuses the same key.
+func requestFingerprint(trxType common.TrxType, walletId string, req interface{}) string {
+	body, _ := json.Marshal(req)
+	sum := sha256.Sum256([]byte(string(trxType) + "|" + walletId + "|" + string(body)))
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotentRequest is a request sent with an idempotency key. The key is scoped to the user sending it
+// and the wallet it is sent for.
+type idempotentRequest struct {
+	Key         string
+	UserId      string
+	WalletId    string
+	Fingerprint string
+}
+
+// errIdempotencyKeyTaken fails a unit of work whose key was saved by a concurrent request first.
+var errIdempotencyKeyTaken = errors.New("idempotency key taken")
+
+// inIdempotentTx runs fn like inTx. When fn loses the race to save its key to a concurrent request with
+// the same key, its work is rolled back and the request is answered as a retry of that one.
+func (w *WalletService) inIdempotentTx(ctx context.Context, req idempotentRequest, fn func(uow *manager.UnitOfWork) (any, error)) response.ResonseWrapper {
+	var data any
+	err := w.dbTxManager.InTx(ctx, func(uow *manager.UnitOfWork) error {
+		var err error
+		data, err = fn(uow)
+		return err
+	})
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+			replay, appErr := w.findIdempotentResponse(req, uow.Tx())
+			if appErr.Code != 0 {
+				return nil, appErr
+			}
+			if replay == nil {
+				w.log.Errorf("Idempotency key taken but not found; key:%s walletId:%s", req.Key, req.WalletId)
+				return nil, apperror.ErrInternalServer
+			}
+			return *replay, nil
+		})
+	}
+	if appErr := w.toAppError(err); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: data}
+}
+
+// findIdempotentResponse must be called while holding the wallet lock, which serializes retries of the same key.
+// It returns nil when the key is empty, unknown or expired; an expired key is deleted so it can be used again.
+func (w *WalletService) findIdempotentResponse(req idempotentRequest, dbTx *gorm.DB) (*response.TrxResponse, apperror.AppError) {
+	if req.Key == "" {
+		return nil, apperror.AppError{}
+	}
+
+	record, err := w.idempotencyRepo.FindIdempotencyKeyWithTx(req.UserId, req.WalletId, req.Key, dbTx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.AppError{}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding idempotency key; key:%s %v", req.Key, err)
+		return nil, apperror.ErrInternalServer
+	}
+	if record.ExpiresAt.Before(time.Now()) {
+		w.log.Infof("Idempotency key expired; key:%s", req.Key)
+		if err := w.idempotencyRepo.DeleteIdempotencyKeyWithTx(record, dbTx); err != nil {
+			w.log.Errorf("Err deleting idempotency key; key:%s %v", req.Key, err)
+			return nil, apperror.ErrInternalServer
+		}
+		return nil, apperror.AppError{}
+	}
+	if record.Fingerprint != req.Fingerprint {
+		w.log.Errorf("Idempotency key reused with a different request; key:%s", req.Key)
+		return nil, apperror.ErrIdempotencyKeyConflict
+	}
+
+	var trxRes response.TrxResponse
+	if err := json.Unmarshal([]byte(record.Response), &trxRes); err != nil {
+		w.log.Errorf("Err reading stored response; key:%s %v", req.Key, err)
+		return nil, apperror.ErrInternalServer
+	}
+	w.log.Infof("Replaying idempotent response; key:%s", req.Key)
+	return &trxRes, apperror.AppError{}
+}
+
+// saveIdempotentResponse inserts the key with the response, failing with errIdempotencyKeyTaken when a
+// concurrent request saved the same key first.
+func (w *WalletService) saveIdempotentResponse(req idempotentRequest, trxRes response.TrxResponse, dbTx *gorm.DB) error {
+	if req.Key == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(trxRes)
+	if err != nil {
+		w.log.Errorf("Err writing stored response; key:%s %v", req.Key, err)
+		return apperror.ErrInternalServer
+	}
+	now := time.Now()
+	record := entity.IdempotencyKeyEntity{
+		UserId:      req.UserId,
+		WalletId:    req.WalletId,
+		Key:         req.Key,
+		Fingerprint: req.Fingerprint,
+		Response:    string(body),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(w.cfg.Idempotency.KeyTtl),
+	}
+	err = w.idempotencyRepo.CreateIdempotencyKeyWithTx(record, dbTx)
+	if appdb.IsUniqueViolation(err) {
+		w.log.Infof("Idempotency key taken by a concurrent request; key:%s", req.Key)
+		return errIdempotencyKeyTaken
+	}
+	if err != nil {
+		w.log.Errorf("Err saving idempotency key; walletId:%s %v", req.WalletId, err)
+		return dbErr(err)
+	}
+	return nil
+}
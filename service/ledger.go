@@ -0,0 +1,151 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LedgerLeg struct {
+	Account   entity.LedgerAccountEntity
+	Direction common.EntryDirection
+	Amount    uint
+}
+
+func WalletAccount(wallet entity.WalletEntity) entity.LedgerAccountEntity {
+	return entity.LedgerAccountEntity{ID: "wallet:" + wallet.ID, Type: common.LedgerAccountTypeWallet, WalletId: wallet.ID, Currency: wallet.Currency}
+}
+
+func SystemAccount(kind common.SystemAccount, currency string) entity.LedgerAccountEntity {
+	return entity.LedgerAccountEntity{ID: fmt.Sprintf("system:%s:%s", kind, currency), Type: common.LedgerAccountTypeSystem, Currency: currency}
+}
+
+func Debit(account entity.LedgerAccountEntity, amount uint) LedgerLeg {
+	return LedgerLeg{Account: account, Direction: common.EntryDirectionDebit, Amount: amount}
+}
+
+func Credit(account entity.LedgerAccountEntity, amount uint) LedgerLeg {
+	return LedgerLeg{Account: account, Direction: common.EntryDirectionCredit, Amount: amount}
+}
+
+// ValidateLedgerLegs is the ledger invariant: within each currency, debits and credits sum to zero.
+func ValidateLedgerLegs(legs []LedgerLeg) error {
+	if len(legs) < 2 {
+		return fmt.Errorf("a journal needs at least two legs; got %d", len(legs))
+	}
+	sums := map[string]int64{}
+	for _, leg := range legs {
+		switch leg.Direction {
+		case common.EntryDirectionDebit:
+			sums[leg.Account.Currency] += int64(leg.Amount)
+		case common.EntryDirectionCredit:
+			sums[leg.Account.Currency] -= int64(leg.Amount)
+		default:
+			return fmt.Errorf("unknown direction %q on account %s", leg.Direction, leg.Account.ID)
+		}
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			return fmt.Errorf("journal does not balance; currency:%s difference:%d", currency, sum)
+		}
+	}
+	return nil
+}
+
+// postJournal is the only place wallet balances change. It checks the legs balance, stores them as
+// ledger entries and projects them onto the locked wallets, whose Balance is a cache of their account.
+func (w *WalletService) postJournal(journalId string, legs []LedgerLeg, wallets []*entity.WalletEntity, dbTx *gorm.DB) error {
+	if err := ValidateLedgerLegs(legs); err != nil {
+		w.log.Errorf("Rejected journal; journalId:%s %v", journalId, err)
+		return apperror.ErrUnbalancedJournal
+	}
+
+	for _, wallet := range wallets {
+		if err := w.ensureWalletAccount(*wallet, dbTx); err != nil {
+			w.log.Errorf("Err creating ledger account; walletId:%s %v", wallet.ID, err)
+			return dbErr(err)
+		}
+	}
+
+	now := time.Now()
+	entries := make([]entity.LedgerEntryEntity, 0, len(legs))
+	for _, leg := range legs {
+		if leg.Account.Type == common.LedgerAccountTypeSystem {
+			if _, err := w.ledgerRepo.EnsureLedgerAccountWithTx(leg.Account, dbTx); err != nil {
+				w.log.Errorf("Err creating ledger account; accountId:%s %v", leg.Account.ID, err)
+				return dbErr(err)
+			}
+		}
+		entries = append(entries, entity.LedgerEntryEntity{
+			ID:        uuid.New().String(),
+			JournalId: journalId,
+			AccountId: leg.Account.ID,
+			Direction: leg.Direction,
+			Amount:    leg.Amount,
+			Currency:  leg.Account.Currency,
+			CreatedAt: now,
+		})
+	}
+
+	for _, wallet := range wallets {
+		if err := projectLegs(wallet, legs); err != nil {
+			w.log.Errorf("Err projecting journal; journalId:%s walletId:%s %v", journalId, wallet.ID, err)
+			return apperror.ErrInsufficientAmount
+		}
+	}
+
+	if err := w.ledgerRepo.SaveLedgerEntriesWithTx(entries, dbTx); err != nil {
+		w.log.Errorf("Err saving ledger entries; journalId:%s %v", journalId, err)
+		return dbErr(err)
+	}
+	return nil
+}
+
+// ensureWalletAccount opens the wallet's ledger account on first use. Wallets that already held a balance
+// before the ledger existed get an opening-balance journal so that the account matches the cached balance.
+func (w *WalletService) ensureWalletAccount(wallet entity.WalletEntity, dbTx *gorm.DB) error {
+	account := WalletAccount(wallet)
+	created, err := w.ledgerRepo.EnsureLedgerAccountWithTx(account, dbTx)
+	if err != nil || !created || wallet.Balance == 0 {
+		return err
+	}
+
+	openingAccount := SystemAccount(common.SystemAccountOpeningBalance, wallet.Currency)
+	if _, err := w.ledgerRepo.EnsureLedgerAccountWithTx(openingAccount, dbTx); err != nil {
+		return err
+	}
+	journalId := uuid.New().String()
+	now := time.Now()
+	entries := []entity.LedgerEntryEntity{
+		{ID: uuid.New().String(), JournalId: journalId, AccountId: openingAccount.ID, Direction: common.EntryDirectionDebit, Amount: wallet.Balance, Currency: wallet.Currency, CreatedAt: now},
+		{ID: uuid.New().String(), JournalId: journalId, AccountId: account.ID, Direction: common.EntryDirectionCredit, Amount: wallet.Balance, Currency: wallet.Currency, CreatedAt: now},
+	}
+	w.log.Infof("Opening ledger balance; walletId:%s balance:%d", wallet.ID, wallet.Balance)
+	return w.ledgerRepo.SaveLedgerEntriesWithTx(entries, dbTx)
+}
+
+func projectLegs(wallet *entity.WalletEntity, legs []LedgerLeg) error {
+	accountId := WalletAccount(*wallet).ID
+	balance := int64(wallet.Balance)
+	for _, leg := range legs {
+		if leg.Account.ID != accountId {
+			continue
+		}
+		if leg.Direction == common.EntryDirectionCredit {
+			balance += int64(leg.Amount)
+		} else {
+			balance -= int64(leg.Amount)
+		}
+	}
+	if balance < 0 {
+		return fmt.Errorf("balance would become negative; balance:%d", balance)
+	}
+	wallet.Balance = uint(balance)
+	return nil
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"wallet-app/apperror"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// forUpdate locks the rows read through the returned db until dbTx ends. SQLite has no row locks, so
+// no clause is added there: its transactions begin IMMEDIATE (see db.OpenSqlite) and already hold the
+// database write lock, which serializes writers at least as strictly.
+func forUpdate(dbTx *gorm.DB) *gorm.DB {
+	if appdb.IsSqlite(dbTx) {
+		return dbTx
+	}
+	return dbTx.Clauses(clause.Locking{Strength: "UPDATE"})
+}
+
+// readSnapshot makes every read of dbTx see the db as of its first read, for reports that read a
+// wallet more than once. On Postgres it must be the first statement of the transaction, which becomes
+// REPEATABLE READ and READ ONLY. A SQLite transaction holds the database write lock from its start
+// (see forUpdate), so nothing commits while it reads.
+func readSnapshot(dbTx *gorm.DB) error {
+	if appdb.IsSqlite(dbTx) {
+		return nil
+	}
+	return dbTx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY").Error
+}
+
+// lockWallets locks the wallets with the given ids in ascending id order, whatever order the caller
+// goes on to use them in, so two transactions locking the same wallets (e.g. opposing transfers) queue
+// on the first lock instead of deadlocking. Every wallet a transaction locks must be locked in this one
+// call, including the house revenue wallet of a transaction that may pay fees. Wallets that do not exist
+// are left out of the result; empty ids are skipped.
+func (w *WalletService) lockWallets(dbTx *gorm.DB, ids ...string) (map[string]entity.WalletEntity, error) {
+	ids = slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return id == "" })
+	slices.Sort(ids)
+	locked := make(map[string]entity.WalletEntity, len(ids))
+	for _, id := range slices.Compact(ids) {
+		wallet, err := w.walletRepo.FindWalletByIdWithTx(id, forUpdate(dbTx))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			w.log.Errorf("Err locking wallet; walletId:%s %v", id, err)
+			return nil, dbErr(err)
+		}
+		locked[id] = wallet
+	}
+	return locked, nil
+}
+
+// dbErr is what a unit of work fails with after a db error, e.g. taking a lock or saving a row. Errors
+// the transaction may get past when it is run again, such as a deadlock, are passed on as they are for
+// the tx manager to retry (see manager.RetryingDbTxManager); anything else is an internal error.
+func dbErr(err error) error {
+	if appdb.IsRetryable(err) {
+		return err
+	}
+	return apperror.ErrInternalServer
+}
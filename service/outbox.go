@@ -0,0 +1,37 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// writeOutboxEvent records a committed-to-be money movement for webhook delivery. It must use the dbTx
+// that posts the movement, so a rollback drops the event as well.
+func (w *WalletService) writeOutboxEvent(eventType common.EventType, walletId string, trxs []entity.TrxEntity, dbTx *gorm.DB) error {
+	payload := response.WebhookEventPayload{
+		EventId:      uuid.New().String(),
+		EventType:    eventType,
+		WalletId:     walletId,
+		Transactions: w.mapper.ToTransactionResponses(trxs),
+		CreatedAt:    time.Now().UTC(),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	event := entity.OutboxEventEntity{
+		ID:        payload.EventId,
+		EventType: eventType,
+		WalletId:  walletId,
+		Payload:   string(body),
+		CreatedAt: payload.CreatedAt,
+	}
+	return w.outboxRepo.SaveOutboxEventWithTx(event, dbTx)
+}
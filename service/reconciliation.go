@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+)
+
+const reconciliationBatchSize = 500
+const defaultRepairReason = "balance rebuilt from transactions"
+
+// ReconcileWallets replays each wallet's transactions and reports every wallet whose cached balance differs
+// from the replayed one. With req.Repair the balance is set to the replayed value through a ledger journal
+// against the adjustment system account, audited in balance_adjustments. Data is a ReconciliationReport.
+func (w *WalletService) ReconcileWallets(ctx context.Context, principal auth.Principal, req request.ReconcileReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("ReconcileWallets; wallets:%d repair:%t", len(req.WalletIds), req.Repair)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = defaultRepairReason
+	}
+
+	report := response.ReconciliationReport{Drifts: []response.WalletDriftResponse{}, StartedAt: time.Now().UTC()}
+	reconcile := func(walletId string) apperror.AppError {
+		drift, appErr := w.reconcileWallet(ctx, principal, walletId, req.Repair, reason)
+		if appErr.Code != 0 {
+			return appErr
+		}
+		report.WalletsChecked++
+		if drift != nil {
+			report.Drifts = append(report.Drifts, *drift)
+			if drift.Repaired {
+				report.Repaired++
+			}
+		}
+		return apperror.AppError{}
+	}
+
+	if len(req.WalletIds) > 0 {
+		for _, walletId := range req.WalletIds {
+			if appErr := reconcile(walletId); appErr.Code != 0 {
+				return response.ResonseWrapper{Err: appErr}
+			}
+		}
+	} else {
+		for afterId := ""; ; {
+			walletIds, err := w.walletRepo.FindWalletIds(ctx, afterId, reconciliationBatchSize)
+			if err != nil {
+				w.log.Error("Err finding wallets; ", err)
+				return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+			}
+			for _, walletId := range walletIds {
+				if appErr := reconcile(walletId); appErr.Code != 0 {
+					return response.ResonseWrapper{Err: appErr}
+				}
+			}
+			if len(walletIds) < reconciliationBatchSize {
+				break
+			}
+			afterId = walletIds[len(walletIds)-1]
+		}
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	w.log.Infof("Reconciled; wallets:%d drifts:%d repaired:%d", report.WalletsChecked, len(report.Drifts), report.Repaired)
+	return response.ResonseWrapper{Data: report}
+}
+
+// reconcileWallet compares one wallet under its lock, so no money operation can land between reading the
+// balance and summing the transactions. It returns nil when there is no drift.
+func (w *WalletService) reconcileWallet(ctx context.Context, principal auth.Principal, walletId string, repair bool, reason string) (*response.WalletDriftResponse, apperror.AppError) {
+	var drift *response.WalletDriftResponse
+	err := w.dbTxManager.InTx(ctx, func(uow *manager.UnitOfWork) error {
+		dbTx := uow.Tx()
+		drift = nil // left over from a run that was rolled back and retried
+
+		locked, err := w.lockWallets(dbTx, walletId)
+		if err != nil {
+			return err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return apperror.ErrWalletNotFound
+		}
+		totals, err := w.trxRepo.SumAmountsByTrxTypeWithTx(walletId, dbTx)
+		if err != nil {
+			w.log.Errorf("Err summing trxs; walletId:%s %v", walletId, err)
+			return apperror.ErrInternalServer
+		}
+		replayed := int64(0)
+		for _, total := range totals {
+			replayed += int64(total.TrxType.Sign()) * total.Amount
+		}
+		if replayed == int64(wallet.Balance) {
+			return nil
+		}
+
+		drift = &response.WalletDriftResponse{
+			WalletId:        wallet.ID,
+			Currency:        wallet.Currency,
+			Exponent:        common.CurrencyExponent(wallet.Currency),
+			Balance:         wallet.Balance,
+			ReplayedBalance: replayed,
+			Delta:           int64(wallet.Balance) - replayed,
+		}
+		w.log.Errorf("Balance drift; walletId:%s balance:%d replayed:%d delta:%d", wallet.ID, wallet.Balance, replayed, drift.Delta)
+		if !repair {
+			return nil
+		}
+		if replayed < 0 {
+			w.log.Errorf("Cannot repair a negative replayed balance; walletId:%s replayed:%d", wallet.ID, replayed)
+			return nil
+		}
+
+		adjustment := entity.BalanceAdjustmentEntity{
+			ID:            uuid.New().String(),
+			WalletId:      wallet.ID,
+			Kind:          common.AdjustmentKindReconciliation,
+			Currency:      wallet.Currency,
+			BalanceBefore: wallet.Balance,
+			Reason:        reason,
+			Actor:         principal.UserId,
+			JournalId:     uuid.New().String(),
+			CreatedAt:     time.Now().UTC(),
+		}
+		adjustmentAccount := SystemAccount(common.SystemAccountAdjustment, wallet.Currency)
+		var legs []LedgerLeg
+		if drift.Delta < 0 {
+			adjustment.Direction, adjustment.Amount = common.EntryDirectionCredit, uint(-drift.Delta)
+			legs = []LedgerLeg{Debit(adjustmentAccount, adjustment.Amount), Credit(WalletAccount(wallet), adjustment.Amount)}
+		} else {
+			adjustment.Direction, adjustment.Amount = common.EntryDirectionDebit, uint(drift.Delta)
+			legs = []LedgerLeg{Debit(WalletAccount(wallet), adjustment.Amount), Credit(adjustmentAccount, adjustment.Amount)}
+		}
+		if err := w.postJournal(adjustment.JournalId, legs, []*entity.WalletEntity{&wallet}, dbTx); err != nil {
+			return err
+		}
+		if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
+			w.log.Errorf("Err saving wallet; walletId:%s %v", wallet.ID, err)
+			return apperror.ErrInternalServer
+		}
+		adjustment.BalanceAfter = wallet.Balance
+		if err := w.adjustmentRepo.SaveBalanceAdjustmentWithTx(adjustment, dbTx); err != nil {
+			w.log.Errorf("Err saving balance adjustment; walletId:%s %v", wallet.ID, err)
+			return apperror.ErrInternalServer
+		}
+
+		w.log.Infof("Balance repaired; walletId:%s balance:%d adjustmentId:%s", wallet.ID, wallet.Balance, adjustment.ID)
+		drift.Repaired = true
+		drift.AdjustmentId = adjustment.ID
+		return nil
+	})
+	return drift, w.toAppError(err)
+}
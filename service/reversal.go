@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+)
+
+// ReverseTransfer sends a transfer back from the wallet that received it to the sender, in full or in part,
+// at the rate the transfer was made. Reversals add up on the original rows, so a transfer cannot be
+// reversed for more than it moved.
+func (w *WalletService) ReverseTransfer(ctx context.Context, principal auth.Principal, groupId string, req request.ReverseTransferReq, idempotencyKey string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("ReverseTransfer; groupId:%s", groupId)
+
+	if req.Force && !principal.IsAdmin() {
+		w.log.Errorf("Forbidden to force a reversal; caller:%s groupId:%s", principal.UserId, groupId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	transferOut, transferIn, found := findTransferRows(w.trxRepo.FindTrxsByGroupId(ctx, groupId))
+	if !found {
+		w.log.Errorf("Transfer not found; groupId:%s", groupId)
+		return response.ResonseWrapper{Err: apperror.ErrTransferNotFound}
+	}
+
+	idem := idempotentRequest{Key: idempotencyKey, UserId: principal.UserId, WalletId: transferIn.WalletId, Fingerprint: requestFingerprint(common.TrxTypeReversalOut, groupId, req)}
+	return w.inIdempotentTx(ctx, idem, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		// the wallet giving the money back is debited, like the source of a transfer
+		locked, err := w.lockWallets(dbTx, transferIn.WalletId, transferOut.WalletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[transferIn.WalletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", transferIn.WalletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		w.log.Info("Wallet ", wallet)
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		if replay, appErr := w.findIdempotentResponse(idem, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		} else if replay != nil {
+			return *replay, nil
+		}
+		if appErr := w.checkCanDebit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		transferOut, transferIn, _ := findTransferRows(manager.Bind[repo.ITrxRepo](uow, w.trxRepo).FindTrxsByGroupId(ctx, groupId))
+		counterpartyWallet, ok := locked[transferOut.WalletId]
+		if !ok {
+			w.log.Errorf("CounterpartyWallet not found; walletId:%s", transferOut.WalletId)
+			return nil, apperror.ErrCounterpartyWalletNotFound
+		}
+		w.log.Info("CounterpartyWallet ", counterpartyWallet)
+		if appErr := w.checkCounterpartyCanCredit(counterpartyWallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		remaining := transferIn.Amount - transferIn.ReversedAmount
+		if remaining == 0 {
+			w.log.Errorf("Transfer already reversed; groupId:%s", groupId)
+			return nil, apperror.ErrTransferAlreadyReversed
+		}
+		amount := req.Amount
+		if amount == 0 {
+			amount = remaining
+		}
+		if amount > remaining {
+			w.log.Errorf("Reversal exceeds transfer; groupId:%s amount:%d remaining:%d", groupId, amount, remaining)
+			return nil, apperror.ErrReversalExceedsTransfer
+		}
+
+		// the sender gets back its side at the transfer's rate; the last reversal returns exactly what is left
+		creditAmount := transferOut.Amount - transferOut.ReversedAmount
+		if amount < remaining {
+			creditAmount = uint(uint64(amount) * uint64(transferOut.Amount) / uint64(transferIn.Amount))
+		}
+		if creditAmount == 0 {
+			w.log.Errorf("Amount too small to convert; groupId:%s amount:%d", groupId, amount)
+			return nil, apperror.ErrFxAmountTooSmall
+		}
+
+		held, err := w.holdRepo.SumActiveHoldAmountWithTx(wallet.ID, time.Now().UTC(), dbTx)
+		if err != nil {
+			w.log.Errorf("Err summing holds; walletId:%s %v", wallet.ID, err)
+			return nil, apperror.ErrInternalServer
+		}
+		debitAmount := amount
+		if availableBalance(wallet.Balance, held) < amount {
+			if !req.Force {
+				w.log.Errorf("Insufficient amount to reverse; walletId:%s balance:%d held:%d amount:%d", wallet.ID, wallet.Balance, held, amount)
+				return nil, apperror.ErrReversalInsufficientFunds
+			}
+			debitAmount = min(amount, wallet.Balance)
+			w.log.Infof("Forcing reversal; groupId:%s amount:%d shortfall:%d", groupId, amount, amount-debitAmount)
+		}
+
+		reversalGroupId := uuid.New().String()
+		legs := []LedgerLeg{Credit(WalletAccount(counterpartyWallet), creditAmount)}
+		if debitAmount > 0 {
+			legs = append(legs, Debit(WalletAccount(wallet), debitAmount))
+		}
+		if shortfall := amount - debitAmount; shortfall > 0 {
+			legs = append(legs, Debit(SystemAccount(common.SystemAccountReversalLoss, wallet.Currency), shortfall))
+		}
+		if wallet.Currency != counterpartyWallet.Currency {
+			legs = append(legs,
+				Credit(SystemAccount(common.SystemAccountFx, wallet.Currency), amount),
+				Debit(SystemAccount(common.SystemAccountFx, counterpartyWallet.Currency), creditAmount),
+			)
+		}
+		if err := w.postJournal(reversalGroupId, legs, []*entity.WalletEntity{&wallet, &counterpartyWallet}, dbTx); err != nil {
+			return nil, err
+		}
+
+		wallets := []entity.WalletEntity{wallet, counterpartyWallet}
+		if err := w.walletRepo.SaveWalletsWithTx(wallets, dbTx); err != nil {
+			w.log.Error("Err saving wallets; ", err)
+			return nil, dbErr(err)
+		}
+
+		transferOut.ReversedAmount += creditAmount
+		transferIn.ReversedAmount += amount
+		trx := entity.TrxEntity{
+			ID:                   uuid.New().String(),
+			WalletId:             wallet.ID,
+			Amount:               debitAmount,
+			Currency:             wallet.Currency,
+			CounterpartyWalletId: counterpartyWallet.ID,
+			CounterpartyAmount:   creditAmount,
+			CounterpartyCurrency: counterpartyWallet.Currency,
+			FxRate:               transferIn.FxRate,
+			TrxType:              common.TrxTypeReversalOut,
+			GroupId:              reversalGroupId,
+			ReversalOf:           groupId,
+			CreatedAt:            time.Now().UTC(),
+		}
+		counterpartyTrx := entity.TrxEntity{
+			ID:                   uuid.New().String(),
+			WalletId:             counterpartyWallet.ID,
+			Amount:               creditAmount,
+			Currency:             counterpartyWallet.Currency,
+			CounterpartyWalletId: wallet.ID,
+			CounterpartyAmount:   amount,
+			CounterpartyCurrency: wallet.Currency,
+			FxRate:               transferOut.FxRate,
+			TrxType:              common.TrxTypeReversalIn,
+			GroupId:              reversalGroupId,
+			ReversalOf:           groupId,
+			CreatedAt:            time.Now().UTC(),
+		}
+		trxs := []entity.TrxEntity{transferOut, transferIn, trx, counterpartyTrx}
+		if err := w.trxRepo.SaveTrxsWithDbTx(trxs, dbTx); err != nil {
+			w.log.Error("Err saving trxs; ", err)
+			return nil, dbErr(err)
+		}
+		if err := w.writeOutboxEvent(common.EventTypeReversal, wallet.ID, []entity.TrxEntity{trx, counterpartyTrx}, dbTx); err != nil {
+			w.log.Errorf("Err saving outbox event; walletId:%s %v", wallet.ID, err)
+			return nil, dbErr(err)
+		}
+		w.log.Info("Trxs ", trxs)
+
+		trxRes := w.mapper.ToTrxResponse(trx, wallet.Balance)
+		if err := w.saveIdempotentResponse(idem, trxRes, dbTx); err != nil {
+			return nil, err
+		}
+
+		return trxRes, nil
+	})
+}
+
+func findTransferRows(trxs []entity.TrxEntity) (transferOut entity.TrxEntity, transferIn entity.TrxEntity, found bool) {
+	var hasOut, hasIn bool
+	for _, trx := range trxs {
+		switch trx.TrxType {
+		case common.TrxTypeTransferOut:
+			transferOut, hasOut = trx, true
+		case common.TrxTypeTransferIn:
+			transferIn, hasIn = trx, true
+		}
+	}
+	return transferOut, transferIn, hasOut && hasIn
+}
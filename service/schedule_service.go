@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const dueScheduleBatchSize = 100
+const scheduleRunHistoryLimit = 100
+
+type IScheduleService interface {
+	CreateSchedule(principal auth.Principal, walletId string, req request.CreateScheduleReq) response.ResonseWrapper
+	GetSchedules(principal auth.Principal, walletId string) response.ResonseWrapper
+	GetSchedule(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper
+	UpdateSchedule(principal auth.Principal, walletId string, scheduleId string, req request.UpdateScheduleReq) response.ResonseWrapper
+	CancelSchedule(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper
+	GetScheduleRuns(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper
+
+	RunDueSchedules(now time.Time) response.ResonseWrapper
+}
+
+type ScheduleService struct {
+	log           *logrus.Logger
+	cfg           *config.AppConfig
+	dbTxManager   manager.IDbTxManager
+	walletService IWalletService
+	walletRepo    repo.IWalletRepo
+	scheduleRepo  repo.IScheduleRepo
+	mapper        *mapper.AppMapper
+}
+
+func NewScheduleService(log *logrus.Logger, cfg *config.AppConfig, walletService IWalletService, walletRepo repo.IWalletRepo, scheduleRepo repo.IScheduleRepo, mapper *mapper.AppMapper, dbTxManager manager.IDbTxManager) IScheduleService {
+	return &ScheduleService{log: log, cfg: cfg, walletService: walletService, walletRepo: walletRepo, scheduleRepo: scheduleRepo, mapper: mapper, dbTxManager: dbTxManager}
+}
+
+func (s *ScheduleService) CreateSchedule(principal auth.Principal, walletId string, req request.CreateScheduleReq) response.ResonseWrapper {
+	s.log.Infof("CreateSchedule; walletId:%s", walletId)
+
+	wallet, appErr := s.findWallet(principal, walletId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if walletId == req.CounterpartyWalletId {
+		s.log.Errorf("CounterpartyWalletId same as walletId; walletId:%s counterpartyWalletId:%s", walletId, req.CounterpartyWalletId)
+		return response.ResonseWrapper{Err: apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet}
+	}
+	counterpartyWallet, err := s.walletRepo.FindWalletById(context.Background(), req.CounterpartyWalletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Errorf("CounterpartyWallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrCounterpartyWalletNotFound}
+	}
+	if err != nil {
+		s.log.Errorf("Err finding wallet; walletId:%s %v", req.CounterpartyWalletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if wallet.Currency != counterpartyWallet.Currency {
+		s.log.Errorf("Schedule across currencies; walletId:%s counterpartyWalletId:%s", walletId, req.CounterpartyWalletId)
+		return response.ResonseWrapper{Err: apperror.ErrScheduleCrossCurrency}
+	}
+
+	rule, appErr := s.parseRule(req.Cron, req.Interval)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+
+	now := time.Now().UTC()
+	firstRunAt := rule.First(now, req.StartAt)
+	schedule := entity.ScheduleEntity{
+		ID:                   uuid.New().String(),
+		WalletId:             walletId,
+		CounterpartyWalletId: req.CounterpartyWalletId,
+		Amount:               req.Amount,
+		Currency:             wallet.Currency,
+		Cron:                 rule.Cron,
+		IntervalSeconds:      int64(rule.Interval / time.Second),
+		Status:               common.ScheduleStatusActive,
+		NextRunAt:            firstRunAt,
+		AttemptAt:            firstRunAt,
+		CreatedBy:            principal.UserId,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if err := s.scheduleRepo.SaveSchedule(schedule); err != nil {
+		s.log.Error("Err saving schedule; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	s.log.Info("Schedule ", schedule)
+
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleResponse(schedule)}
+}
+
+func (s *ScheduleService) GetSchedules(principal auth.Principal, walletId string) response.ResonseWrapper {
+	s.log.Infof("GetSchedules; walletId:%s", walletId)
+	if _, appErr := s.findWallet(principal, walletId); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	schedules := s.scheduleRepo.FindSchedulesByWalletId(walletId)
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleResponses(schedules)}
+}
+
+func (s *ScheduleService) GetSchedule(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper {
+	s.log.Infof("GetSchedule; walletId:%s scheduleId:%s", walletId, scheduleId)
+	schedule, appErr := s.findSchedule(principal, walletId, scheduleId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleResponse(schedule)}
+}
+
+func (s *ScheduleService) UpdateSchedule(principal auth.Principal, walletId string, scheduleId string, req request.UpdateScheduleReq) response.ResonseWrapper {
+	s.log.Infof("UpdateSchedule; walletId:%s scheduleId:%s", walletId, scheduleId)
+	schedule, appErr := s.findSchedule(principal, walletId, scheduleId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if schedule.Status == common.ScheduleStatusCancelled {
+		s.log.Errorf("Schedule cancelled; scheduleId:%s", scheduleId)
+		return response.ResonseWrapper{Err: apperror.ErrScheduleCancelled}
+	}
+
+	now := time.Now().UTC()
+	reschedule := false
+	if req.Amount != nil {
+		if *req.Amount == 0 {
+			s.log.Errorf("Invalid schedule amount; scheduleId:%s", scheduleId)
+			return response.ResonseWrapper{Err: apperror.ErrIncompatibleRequest}
+		}
+		schedule.Amount = *req.Amount
+	}
+	if req.Cron != nil || req.Interval != nil {
+		rule, appErr := s.parseRule(valueOrEmpty(req.Cron), valueOrEmpty(req.Interval))
+		if appErr.Code != 0 {
+			return response.ResonseWrapper{Err: appErr}
+		}
+		schedule.Cron = rule.Cron
+		schedule.IntervalSeconds = int64(rule.Interval / time.Second)
+		reschedule = true
+	}
+	if req.Status != "" && common.ScheduleStatus(req.Status) != schedule.Status {
+		schedule.Status = common.ScheduleStatus(req.Status)
+		reschedule = schedule.Status == common.ScheduleStatusActive || reschedule
+	}
+	if reschedule {
+		// a new rule or a resume starts from the next occurrence rather than catching up
+		rule, _ := schedule.Rule()
+		schedule.NextRunAt = rule.First(now, nil)
+		schedule.AttemptAt = schedule.NextRunAt
+		schedule.Attempt = 0
+	}
+	schedule.UpdatedAt = now
+
+	if err := s.scheduleRepo.SaveSchedule(schedule); err != nil {
+		s.log.Errorf("Err saving schedule; scheduleId:%s %v", scheduleId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleResponse(schedule)}
+}
+
+// CancelSchedule stops the schedule for good; the row and its runs are kept for history.
+func (s *ScheduleService) CancelSchedule(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper {
+	s.log.Infof("CancelSchedule; walletId:%s scheduleId:%s", walletId, scheduleId)
+	schedule, appErr := s.findSchedule(principal, walletId, scheduleId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	schedule.Status = common.ScheduleStatusCancelled
+	schedule.UpdatedAt = time.Now().UTC()
+	if err := s.scheduleRepo.SaveSchedule(schedule); err != nil {
+		s.log.Errorf("Err saving schedule; scheduleId:%s %v", scheduleId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleResponse(schedule)}
+}
+
+func (s *ScheduleService) GetScheduleRuns(principal auth.Principal, walletId string, scheduleId string) response.ResonseWrapper {
+	s.log.Infof("GetScheduleRuns; walletId:%s scheduleId:%s", walletId, scheduleId)
+	if _, appErr := s.findSchedule(principal, walletId, scheduleId); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	runs := s.scheduleRepo.FindScheduleRuns(scheduleId, scheduleRunHistoryLimit)
+	return response.ResonseWrapper{Data: s.mapper.ToScheduleRunResponses(runs)}
+}
+
+// RunDueSchedules executes every schedule whose attempt time has come. It is meant to be called by a
+// single instance at a time (see scheduler.LeasedJob); each occurrence also carries its own idempotency
+// key, so a run repeated after a lost lease cannot move the money twice. Data is the number of runs.
+func (s *ScheduleService) RunDueSchedules(now time.Time) response.ResonseWrapper {
+	now = now.UTC()
+	schedules, err := s.scheduleRepo.FindDueSchedules(now, dueScheduleBatchSize)
+	if err != nil {
+		s.log.Error("Err finding due schedules; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	runs := 0
+	for _, schedule := range schedules {
+		if err := s.runSchedule(schedule, now); err != nil {
+			s.log.Errorf("Err recording schedule run; scheduleId:%s %v", schedule.ID, err)
+			continue
+		}
+		runs++
+	}
+	return response.ResonseWrapper{Data: runs}
+}
+
+func (s *ScheduleService) runSchedule(schedule entity.ScheduleEntity, now time.Time) error {
+	s.log.Infof("Run schedule; scheduleId:%s scheduledFor:%s attempt:%d", schedule.ID, schedule.NextRunAt, schedule.Attempt+1)
+
+	idempotencyKey := fmt.Sprintf("schedule:%s:%d", schedule.ID, schedule.NextRunAt.Unix())
+	req := request.TransferReq{Amount: schedule.Amount, CounterpartyWalletId: schedule.CounterpartyWalletId}
+	res := s.walletService.TransferMoney(context.Background(), auth.System, schedule.WalletId, req, idempotencyKey)
+
+	run := entity.ScheduleRunEntity{
+		ID:           uuid.New().String(),
+		ScheduleId:   schedule.ID,
+		ScheduledFor: schedule.NextRunAt,
+		Attempt:      schedule.Attempt + 1,
+		Error:        res.Err.Message,
+		CreatedAt:    now,
+	}
+	switch {
+	case res.Err.Code == 0:
+		run.Outcome = common.ScheduleRunSucceeded
+		run.TrxId = res.Data.(response.TrxResponse).TransactionId
+	case res.Err == apperror.ErrInsufficientAmount && run.Attempt < s.cfg.Schedules.MaxAttempts:
+		run.Outcome = common.ScheduleRunInsufficientFunds
+	case res.Err.Code >= 500 && run.Attempt < s.cfg.Schedules.MaxAttempts:
+		run.Outcome = common.ScheduleRunFailedRetrying
+	default:
+		run.Outcome = common.ScheduleRunFailed
+	}
+
+	schedule.LastRunAt = &now
+	schedule.LastOutcome = run.Outcome
+	schedule.UpdatedAt = now
+	if run.Outcome == common.ScheduleRunInsufficientFunds || run.Outcome == common.ScheduleRunFailedRetrying {
+		schedule.Attempt = run.Attempt
+		schedule.AttemptAt = now.Add(common.Backoff(s.cfg.Schedules.RetryBackoff, s.cfg.Schedules.MaxRetryBackoff, run.Attempt))
+	} else {
+		rule, err := schedule.Rule()
+		if err != nil {
+			return err
+		}
+		schedule.NextRunAt = rule.NextAfter(schedule.NextRunAt, now)
+		schedule.AttemptAt = schedule.NextRunAt
+		schedule.Attempt = 0
+	}
+
+	return s.dbTxManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		return s.scheduleRepo.SaveScheduleRunWithTx(schedule, run, uow.Tx())
+	})
+}
+
+func (s *ScheduleService) parseRule(cronExpr string, interval string) (common.ScheduleRule, apperror.AppError) {
+	var intervalDuration time.Duration
+	if interval != "" {
+		var err error
+		if intervalDuration, err = time.ParseDuration(interval); err != nil {
+			s.log.Errorf("Invalid schedule interval; interval:%s %v", interval, err)
+			return common.ScheduleRule{}, apperror.ErrInvalidScheduleRule
+		}
+	}
+	rule, err := common.ParseScheduleRule(cronExpr, intervalDuration)
+	if err != nil {
+		s.log.Errorf("Invalid schedule rule; cron:%s interval:%s %v", cronExpr, interval, err)
+		return common.ScheduleRule{}, apperror.ErrInvalidScheduleRule
+	}
+	return rule, apperror.AppError{}
+}
+
+func (s *ScheduleService) findWallet(principal auth.Principal, walletId string) (entity.WalletEntity, apperror.AppError) {
+	wallet, err := s.walletRepo.FindWalletById(context.Background(), walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return wallet, apperror.ErrWalletNotFound
+	}
+	if err != nil {
+		s.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return wallet, apperror.ErrInternalServer
+	}
+	if !principal.CanAccess(wallet.UserId) {
+		s.log.Errorf("Forbidden; caller:%s walletId:%s", principal.UserId, wallet.ID)
+		return wallet, apperror.ErrForbidden
+	}
+	return wallet, apperror.AppError{}
+}
+
+func (s *ScheduleService) findSchedule(principal auth.Principal, walletId string, scheduleId string) (entity.ScheduleEntity, apperror.AppError) {
+	if _, appErr := s.findWallet(principal, walletId); appErr.Code != 0 {
+		return entity.ScheduleEntity{}, appErr
+	}
+	schedule, err := s.scheduleRepo.FindScheduleById(scheduleId)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && schedule.WalletId != walletId) {
+		s.log.Errorf("Schedule not found; walletId:%s scheduleId:%s", walletId, scheduleId)
+		return schedule, apperror.ErrScheduleNotFound
+	}
+	if err != nil {
+		s.log.Errorf("Err finding schedule; scheduleId:%s %v", scheduleId, err)
+		return schedule, apperror.ErrInternalServer
+	}
+	return schedule, apperror.AppError{}
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
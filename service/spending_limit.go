@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SpendingLimitPerTransaction = "per_transaction"
+	SpendingLimitDaily          = "daily"
+	SpendingLimitWeekly         = "weekly"
+
+	dailyLimitWindow  = 24 * time.Hour
+	weeklyLimitWindow = 7 * 24 * time.Hour
+)
+
+// spendingTrxTypes are the rows that count against the daily and weekly limits.
+var spendingTrxTypes = []common.TrxType{common.TrxTypeWithdrawal, common.TrxTypeTransferOut}
+
+func (w *WalletService) GetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("GetSpendingLimits; walletId:%s", walletId)
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+
+	override, err := w.spendingLimitRepo.FindSpendingLimitByWalletId(walletId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Err finding spending limit; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	hasOverride := err == nil
+	now := time.Now().UTC()
+	trxs, err := w.trxRepo.FindTrxsByTypesSinceWithTx(walletId, spendingTrxTypes, now.Add(-weeklyLimitWindow), w.dbTxManager.GetTx(ctx))
+	if err != nil {
+		w.log.Errorf("Err finding spending; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	limits := w.spendingLimits(wallet.Currency, override)
+	dailySpent, _ := spentSince(trxs, now.Add(-dailyLimitWindow))
+	weeklySpent, _ := spentSince(trxs, now.Add(-weeklyLimitWindow))
+	res := response.SpendingLimitsResponse{
+		WalletId:       walletId,
+		Currency:       wallet.Currency,
+		Exponent:       common.CurrencyExponent(wallet.Currency),
+		PerTransaction: limits.PerTransaction,
+		Daily:          limits.Daily,
+		Weekly:         limits.Weekly,
+		DailySpent:     dailySpent,
+		WeeklySpent:    weeklySpent,
+	}
+	if hasOverride {
+		res.Override = w.mapper.ToSpendingLimitOverrideResponse(override)
+	}
+	return response.ResonseWrapper{Data: res}
+}
+
+func (w *WalletService) SetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string, req request.SpendingLimitReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("SetSpendingLimits; walletId:%s", walletId)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	now := time.Now().UTC()
+	override := entity.SpendingLimitEntity{
+		WalletId:       walletId,
+		PerTransaction: req.PerTransaction,
+		Daily:          req.Daily,
+		Weekly:         req.Weekly,
+		Reason:         req.Reason,
+		UpdatedBy:      principal.UserId,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if existing, err := w.spendingLimitRepo.FindSpendingLimitByWalletId(walletId); err == nil {
+		override.CreatedAt = existing.CreatedAt
+	}
+	limits := w.spendingLimits(wallet.Currency, override)
+	if limits.Daily > 0 && limits.Weekly > 0 && limits.Daily > limits.Weekly {
+		w.log.Errorf("Daily limit above weekly limit; walletId:%s daily:%d weekly:%d", walletId, limits.Daily, limits.Weekly)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidSpendingLimit}
+	}
+	if err := w.spendingLimitRepo.SaveSpendingLimit(override); err != nil {
+		w.log.Errorf("Err saving spending limit; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	w.log.Infof("Spending limit set; walletId:%s by:%s reason:%s", walletId, principal.UserId, req.Reason)
+	return w.GetSpendingLimits(ctx, principal, walletId)
+}
+
+// DeleteSpendingLimits puts the wallet back on the configured defaults.
+func (w *WalletService) DeleteSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("DeleteSpendingLimits; walletId:%s", walletId)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	if err := w.spendingLimitRepo.DeleteSpendingLimit(walletId); err != nil {
+		w.log.Errorf("Err deleting spending limit; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return w.GetSpendingLimits(ctx, principal, walletId)
+}
+
+// checkSpendingLimits must be called while holding the wallet lock, so that concurrent debits of the
+// same wallet see each other's rows.
+func (w *WalletService) checkSpendingLimits(wallet entity.WalletEntity, amount uint, dbTx *gorm.DB) apperror.AppError {
+	override, err := w.spendingLimitRepo.FindSpendingLimitByWalletIdWithTx(wallet.ID, dbTx)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Err finding spending limit; walletId:%s %v", wallet.ID, err)
+		return apperror.ErrInternalServer
+	}
+	limits := w.spendingLimits(wallet.Currency, override)
+	if limits.PerTransaction > 0 && amount > limits.PerTransaction {
+		w.log.Errorf("Per-transaction limit exceeded; walletId:%s amount:%d limit:%d", wallet.ID, amount, limits.PerTransaction)
+		return apperror.ErrSpendingLimitExceeded.WithDetails(apperror.SpendingLimitDetails{
+			Window:    SpendingLimitPerTransaction,
+			Limit:     limits.PerTransaction,
+			Remaining: limits.PerTransaction,
+			Currency:  wallet.Currency,
+		})
+	}
+	if limits.Daily == 0 && limits.Weekly == 0 {
+		return apperror.AppError{}
+	}
+
+	now := time.Now().UTC()
+	trxs, err := w.trxRepo.FindTrxsByTypesSinceWithTx(wallet.ID, spendingTrxTypes, now.Add(-weeklyLimitWindow), dbTx)
+	if err != nil {
+		w.log.Errorf("Err finding spending; walletId:%s %v", wallet.ID, err)
+		return apperror.ErrInternalServer
+	}
+	windows := []struct {
+		name   string
+		limit  uint
+		length time.Duration
+	}{
+		{SpendingLimitDaily, limits.Daily, dailyLimitWindow},
+		{SpendingLimitWeekly, limits.Weekly, weeklyLimitWindow},
+	}
+	for _, window := range windows {
+		if window.limit == 0 {
+			continue
+		}
+		spent, lastSpentAt := spentSince(trxs, now.Add(-window.length))
+		if uint64(spent)+uint64(amount) <= uint64(window.limit) {
+			continue
+		}
+		w.log.Errorf("Spending limit exceeded; walletId:%s window:%s amount:%d spent:%d limit:%d", wallet.ID, window.name, amount, spent, window.limit)
+		details := apperror.SpendingLimitDetails{Window: window.name, Limit: window.limit, Currency: wallet.Currency}
+		if spent < window.limit {
+			details.Remaining = window.limit - spent
+		}
+		// the whole allowance is back once the latest debit leaves the window
+		resetsAt := now
+		if !lastSpentAt.IsZero() {
+			resetsAt = lastSpentAt.Add(window.length)
+		}
+		details.ResetsAt = &resetsAt
+		return apperror.ErrSpendingLimitExceeded.WithDetails(details)
+	}
+	return apperror.AppError{}
+}
+
+// spendingLimits resolves the wallet's limits: the override where it sets one, otherwise the
+// configured default for the currency, otherwise the global default.
+func (w *WalletService) spendingLimits(currency string, override entity.SpendingLimitEntity) config.SpendingLimits {
+	limits := w.cfg.Limits.SpendingLimits
+	if byCurrency, ok := w.cfg.Limits.Currencies[lowerCurrency(currency)]; ok {
+		limits = byCurrency
+	}
+	if override.PerTransaction != nil {
+		limits.PerTransaction = *override.PerTransaction
+	}
+	if override.Daily != nil {
+		limits.Daily = *override.Daily
+	}
+	if override.Weekly != nil {
+		limits.Weekly = *override.Weekly
+	}
+	return limits
+}
+
+// spentSince sums the rows created at or after since and returns the time of the latest one.
+func spentSince(trxs []entity.TrxEntity, since time.Time) (uint, time.Time) {
+	spent := uint(0)
+	var lastSpentAt time.Time
+	for _, trx := range trxs {
+		if trx.CreatedAt.Before(since) {
+			continue
+		}
+		spent += trx.Amount
+		lastSpentAt = trx.CreatedAt
+	}
+	return spent, lastSpentAt
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/statement"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ExportStatement writes the wallet's statement for [from, to) to out in the requested format. The
+// opening and closing balances are computed before any byte is written, then the transactions are
+// streamed row by row, all from one snapshot of the db, so the rows add up to the closing balance.
+// An error returned after the first write means the output is truncated.
+// Hold and hold_release rows do not move the balance and are left out.
+func (w *WalletService) ExportStatement(ctx context.Context, principal auth.Principal, walletId string, req request.StatementReq, out io.Writer) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("ExportStatement; walletId:%s", walletId)
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		if err := readSnapshot(uow.Tx()); err != nil {
+			w.log.Errorf("Err starting statement snapshot; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		trxRepo := manager.Bind[repo.ITrxRepo](uow, w.trxRepo)
+
+		wallet, err := manager.Bind[repo.IWalletRepo](uow, w.walletRepo).FindWalletById(ctx, walletId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+			return nil, apperror.ErrWalletNotFound
+		}
+		if err != nil {
+			w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		now := time.Now().UTC()
+		format := req.Format
+		if format == "" {
+			format = statement.FormatCsv
+		}
+		if !statement.IsSupportedFormat(format) {
+			return nil, apperror.ErrInvalidStatementFormat
+		}
+		if req.From == nil {
+			return nil, apperror.ErrInvalidStatementRange
+		}
+		from, to := req.From.UTC(), now
+		if req.To != nil {
+			to = req.To.UTC()
+		}
+		if !from.Before(to) {
+			w.log.Errorf("Invalid statement range; walletId:%s from:%v to:%v", walletId, from, to)
+			return nil, apperror.ErrInvalidStatementRange
+		}
+
+		opening, err := netAmountBetween(ctx, trxRepo, walletId, time.Time{}, from)
+		if err != nil {
+			w.log.Errorf("Err computing opening balance; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		movement, err := netAmountBetween(ctx, trxRepo, walletId, from, to)
+		if err != nil {
+			w.log.Errorf("Err computing closing balance; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		closing := opening + movement
+
+		// from the first write on, errors are not retried: the output cannot be taken back
+		writer, _ := statement.NewStatementWriter(format, out)
+		header := statement.Header{
+			StatementId:    uuid.NewString(),
+			WalletId:       walletId,
+			Currency:       wallet.Currency,
+			Exponent:       common.CurrencyExponent(wallet.Currency),
+			From:           from,
+			To:             to,
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+			CreatedAt:      now,
+		}
+		if err := writer.WriteHeader(header); err != nil {
+			w.log.Errorf("Err writing statement; walletId:%s %v", walletId, err)
+			return nil, apperror.ErrInternalServer
+		}
+
+		footer := statement.Footer{}
+		running := opening
+		err = trxRepo.StreamTrxs(ctx, walletId, from, to, func(trx entity.TrxEntity) error {
+			sign := trx.TrxType.Sign()
+			if sign == 0 {
+				return nil
+			}
+			direction := common.EntryDirectionCredit
+			if sign < 0 {
+				direction = common.EntryDirectionDebit
+				footer.TotalDebits += uint64(trx.Amount)
+			} else {
+				footer.TotalCredits += uint64(trx.Amount)
+			}
+			running += int64(sign) * int64(trx.Amount)
+			footer.EntryCount++
+			return writer.WriteEntry(statement.Entry{
+				TransactionId:        trx.ID,
+				TrxType:              trx.TrxType,
+				Direction:            direction,
+				Amount:               trx.Amount,
+				RunningBalance:       running,
+				CounterpartyWalletId: trx.CounterpartyWalletId,
+				GroupId:              trx.GroupId,
+				BookedAt:             trx.CreatedAt,
+			})
+		})
+		if err != nil {
+			w.log.Errorf("Err streaming statement; walletId:%s %v", walletId, err)
+			return nil, apperror.ErrInternalServer
+		}
+		if running != closing {
+			w.log.Errorf("Statement running balance differs from closing; walletId:%s running:%d closing:%d", walletId, running, closing)
+			return nil, apperror.ErrInternalServer
+		}
+		footer.ClosingBalance = closing
+		if err := writer.WriteFooter(footer); err != nil {
+			w.log.Errorf("Err writing statement; walletId:%s %v", walletId, err)
+			return nil, apperror.ErrInternalServer
+		}
+		return nil, nil
+	})
+}
+
+// netAmountBetween is the signed sum of the wallet's rows created in [from, to).
+func netAmountBetween(ctx context.Context, trxRepo repo.ITrxRepo, walletId string, from time.Time, to time.Time) (int64, error) {
+	totals, err := trxRepo.SumAmountsByTrxTypeBetween(ctx, walletId, from, to)
+	if err != nil {
+		return 0, err
+	}
+	net := int64(0)
+	for _, total := range totals {
+		net += int64(total.TrxType.Sign()) * total.Amount
+	}
+	return net, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+)
+
+type trxCursorToken struct {
+	CreatedAt string `json:"t"`
+	Id        string `json:"id"`
+}
+
+// encodeTrxCursor returns an opaque token pointing just after trx in the history order.
+func encodeTrxCursor(trx entity.TrxEntity) string {
+	body, _ := json.Marshal(trxCursorToken{CreatedAt: trx.CreatedAt.UTC().Format(time.RFC3339Nano), Id: trx.ID})
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+func decodeTrxCursor(token string) (*repo.TrxCursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var cursor trxCursorToken
+	if err := json.Unmarshal(body, &cursor); err != nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, cursor.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Id == "" {
+		return nil, errors.New("cursor has no id")
+	}
+	return &repo.TrxCursor{CreatedAt: createdAt, Id: cursor.Id}, nil
+}
+
+const defaultTrxPageSize = 50
+
+func toTrxQuery(walletId string, req request.TrxHistoryReq) (repo.TrxQuery, apperror.AppError) {
+	query := repo.TrxQuery{
+		WalletId:             walletId,
+		MinAmount:            req.MinAmount,
+		MaxAmount:            req.MaxAmount,
+		From:                 req.From,
+		To:                   req.To,
+		CounterpartyWalletId: req.CounterpartyWalletId,
+		Limit:                req.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = defaultTrxPageSize
+	}
+	for _, trxType := range req.TrxTypes {
+		if !common.TrxType(trxType).IsValid() {
+			return query, apperror.ErrInvalidTrxFilter
+		}
+		query.TrxTypes = append(query.TrxTypes, common.TrxType(trxType))
+	}
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		return query, apperror.ErrInvalidTrxFilter
+	}
+	// created_at is written in UTC, keep the bounds comparable on backends that store time as text
+	if query.From != nil {
+		from := query.From.UTC()
+		query.From = &from
+	}
+	if query.To != nil {
+		to := query.To.UTC()
+		query.To = &to
+	}
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return query, apperror.ErrInvalidTrxFilter
+	}
+	if req.Cursor != "" {
+		cursor, err := decodeTrxCursor(req.Cursor)
+		if err != nil {
+			return query, apperror.ErrInvalidCursor
+		}
+		query.After = cursor
+	}
+	return query, apperror.AppError{}
+}
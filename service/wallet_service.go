@@ -1,12 +1,18 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"io"
 	"time"
 
 	"wallet-app/apperror"
+	"wallet-app/auth"
 	"wallet-app/common"
+	"wallet-app/config"
+	appdb "wallet-app/db"
 	"wallet-app/entity"
+	"wallet-app/fx"
 	"wallet-app/manager"
 	"wallet-app/mapper"
 	"wallet-app/repo"
@@ -16,270 +22,674 @@ import (
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 type IWalletService interface {
-	CreateWallet(req request.CreateWalletReq) response.ResonseWrapper
-	GetWalletsByUserId(userId string) response.ResonseWrapper
-
-	DepositMoney(walletId string, req request.TrxReq) response.ResonseWrapper
-	WithdrawMoney(walletId string, req request.TrxReq) response.ResonseWrapper
-	TransferMoney(walletId string, req request.TransferReq) response.ResonseWrapper
-
-	GetBalance(walletId string) response.ResonseWrapper
-	GetTransactions(walletId string) response.ResonseWrapper
-
-	DeleteAll() response.ResonseWrapper
-	GetAllTrxs() response.ResonseWrapper
+	CreateWallet(ctx context.Context, principal auth.Principal, req request.CreateWalletReq) response.ResonseWrapper
+	GetWalletsByUserId(ctx context.Context, principal auth.Principal, userId string) response.ResonseWrapper
+
+	DepositMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper
+	WithdrawMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper
+	TransferMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TransferReq, idempotencyKey string) response.ResonseWrapper
+	QuoteTransfer(ctx context.Context, principal auth.Principal, walletId string, req request.TransferQuoteReq) response.ResonseWrapper
+	PreviewFees(ctx context.Context, principal auth.Principal, walletId string, req request.FeePreviewReq) response.ResonseWrapper
+	ReverseTransfer(ctx context.Context, principal auth.Principal, groupId string, req request.ReverseTransferReq, idempotencyKey string) response.ResonseWrapper
+
+	GetBalance(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+	GetTransactions(ctx context.Context, principal auth.Principal, walletId string, req request.TrxHistoryReq) response.ResonseWrapper
+	ExportStatement(ctx context.Context, principal auth.Principal, walletId string, req request.StatementReq, out io.Writer) response.ResonseWrapper
+
+	CreateHold(ctx context.Context, principal auth.Principal, walletId string, req request.CreateHoldReq) response.ResonseWrapper
+	CaptureHold(ctx context.Context, principal auth.Principal, holdId string, req request.CaptureHoldReq) response.ResonseWrapper
+	ReleaseHold(ctx context.Context, principal auth.Principal, holdId string) response.ResonseWrapper
+	GetHolds(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+	ExpireHolds(ctx context.Context) response.ResonseWrapper
+
+	GetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+	SetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string, req request.SpendingLimitReq) response.ResonseWrapper
+	DeleteSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+
+	ChangeWalletStatus(ctx context.Context, principal auth.Principal, walletId string, req request.WalletStatusReq) response.ResonseWrapper
+	GetWalletStatusChanges(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+
+	ReconcileWallets(ctx context.Context, principal auth.Principal, req request.ReconcileReq) response.ResonseWrapper
+	AdjustBalance(ctx context.Context, principal auth.Principal, walletId string, req request.BalanceAdjustmentReq) response.ResonseWrapper
+	GetBalanceAdjustments(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper
+
+	DeleteAll(ctx context.Context, principal auth.Principal) response.ResonseWrapper
+	GetAllTrxs(ctx context.Context) response.ResonseWrapper
 }
 
 type WalletService struct {
-	log         *logrus.Logger
-	dbTxManager manager.IDbTxManager
-	walletRepo  repo.IWalletRepo
-	trxRepo     repo.ITrxRepo
-	mapper      *mapper.AppMapper
+	log               *logrus.Logger
+	cfg               *config.AppConfig
+	dbTxManager       manager.IDbTxManager
+	walletRepo        repo.IWalletRepo
+	trxRepo           repo.ITrxRepo
+	ledgerRepo        repo.ILedgerRepo
+	idempotencyRepo   repo.IIdempotencyRepo
+	fxQuoteRepo       repo.IFxQuoteRepo
+	holdRepo          repo.IHoldRepo
+	outboxRepo        repo.IOutboxRepo
+	adjustmentRepo    repo.IAdjustmentRepo
+	spendingLimitRepo repo.ISpendingLimitRepo
+	feeRuleRepo       repo.IFeeRuleRepo
+	fxProvider        fx.IFxProvider
+	mapper            *mapper.AppMapper
 }
 
-func NewWalletService(log *logrus.Logger, walletRepo repo.IWalletRepo, trxRepo repo.ITrxRepo, mapper *mapper.AppMapper, dbTxManager manager.IDbTxManager) IWalletService {
-	return &WalletService{log: log, walletRepo: walletRepo, trxRepo: trxRepo, mapper: mapper, dbTxManager: dbTxManager}
+func NewWalletService(log *logrus.Logger, cfg *config.AppConfig, walletRepo repo.IWalletRepo, trxRepo repo.ITrxRepo, ledgerRepo repo.ILedgerRepo, idempotencyRepo repo.IIdempotencyRepo, fxQuoteRepo repo.IFxQuoteRepo, holdRepo repo.IHoldRepo, outboxRepo repo.IOutboxRepo, adjustmentRepo repo.IAdjustmentRepo, spendingLimitRepo repo.ISpendingLimitRepo, feeRuleRepo repo.IFeeRuleRepo, fxProvider fx.IFxProvider, mapper *mapper.AppMapper, dbTxManager manager.IDbTxManager) IWalletService {
+	return &WalletService{log: log, cfg: cfg, walletRepo: walletRepo, trxRepo: trxRepo, ledgerRepo: ledgerRepo, idempotencyRepo: idempotencyRepo, fxQuoteRepo: fxQuoteRepo, holdRepo: holdRepo, outboxRepo: outboxRepo, adjustmentRepo: adjustmentRepo, spendingLimitRepo: spendingLimitRepo, feeRuleRepo: feeRuleRepo, fxProvider: fxProvider, mapper: mapper, dbTxManager: dbTxManager}
 }
 
-func (w *WalletService) CreateWallet(req request.CreateWalletReq) response.ResonseWrapper {
+func (w *WalletService) CreateWallet(ctx context.Context, principal auth.Principal, req request.CreateWalletReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("CreateWallet; req:%v", req)
 
-	wallet := entity.WalletEntity{ID: uuid.New().String(), UserId: req.UserId, Balance: 0, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	userId := principal.UserId
+	if req.UserId != "" && req.UserId != principal.UserId {
+		if !principal.IsAdmin() {
+			w.log.Errorf("Forbidden to create wallet for another user; caller:%s userId:%s", principal.UserId, req.UserId)
+			return response.ResonseWrapper{Err: apperror.ErrForbidden}
+		}
+		userId = req.UserId
+	}
+
+	currency := common.NormalizeCurrency(req.Currency)
+	if !common.IsSupportedCurrency(currency) {
+		w.log.Errorf("Unsupported currency; currency:%s", req.Currency)
+		return response.ResonseWrapper{Err: apperror.ErrUnsupportedCurrency}
+	}
+
+	wallet := entity.WalletEntity{ID: uuid.New().String(), UserId: userId, Balance: 0, Currency: currency, Status: common.WalletStatusActive, CreatedAt: time.Now(), UpdatedAt: time.Now()}
 
-	if err := w.walletRepo.SaveWallet(wallet); err != nil {
+	if err := w.walletRepo.SaveWallet(ctx, wallet); err != nil {
 		w.log.Error("Err saving wallet; ", err)
 		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
 	}
 
-	return response.ResonseWrapper{Data: wallet}
+	return response.ResonseWrapper{Data: w.mapper.ToWalletResponse(wallet, wallet.Balance)}
 }
 
-func (w *WalletService) GetWalletsByUserId(userId string) response.ResonseWrapper {
+func (w *WalletService) GetWalletsByUserId(ctx context.Context, principal auth.Principal, userId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("GetWalletsByUserId; userId:%s", userId)
-	wallets := w.walletRepo.FindWalletsByUserId(userId)
+	if !principal.CanAccess(userId) {
+		w.log.Errorf("Forbidden; caller:%s userId:%s", principal.UserId, userId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	wallets := w.walletRepo.FindWalletsByUserId(ctx, userId)
 	w.log.Info("Wallets ", wallets)
-	return response.ResonseWrapper{Data: wallets}
+	availableBalances := make(map[string]uint, len(wallets))
+	for _, wallet := range wallets {
+		available, appErr := w.findAvailableBalance(wallet)
+		if appErr.Code != 0 {
+			return response.ResonseWrapper{Err: appErr}
+		}
+		availableBalances[wallet.ID] = available
+	}
+	return response.ResonseWrapper{Data: w.mapper.ToWalletResponses(wallets, availableBalances)}
 }
 
-func (w *WalletService) DepositMoney(walletId string, req request.TrxReq) response.ResonseWrapper {
+func (w *WalletService) DepositMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("DepositMoney; walletId:%s", walletId)
 
-	dbTx := w.dbTxManager.GetTx().Begin()
-	if dbTx.Error != nil {
-		w.log.Error("Failed creating dbTrx ", dbTx.Error)
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			dbTx.Rollback()
+	idem := idempotentRequest{Key: idempotencyKey, UserId: principal.UserId, WalletId: walletId, Fingerprint: requestFingerprint(common.TrxTypeDeposit, walletId, req)}
+	return w.inIdempotentTx(ctx, idem, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		locked, err := w.lockWallets(dbTx, walletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		w.log.Info("Wallet ", wallet)
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
 		}
-	}()
 
-	wallet, err := w.walletRepo.FindWalletByIdWithTx(walletId, dbTx.Clauses(clause.Locking{Strength: "UPDATE"}))
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
-	}
-	w.log.Info("Wallet ", wallet)
+		if replay, appErr := w.findIdempotentResponse(idem, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		} else if replay != nil {
+			return *replay, nil
+		}
+		if appErr := w.checkCanCredit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
 
-	wallet.Balance += req.Amount
-	if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
-		w.log.Errorf("Err saving wallet; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
+		trxId := uuid.New().String()
+		legs := []LedgerLeg{
+			Debit(SystemAccount(common.SystemAccountCashIn, wallet.Currency), req.Amount),
+			Credit(WalletAccount(wallet), req.Amount),
+		}
+		if err := w.postJournal(trxId, legs, []*entity.WalletEntity{&wallet}, dbTx); err != nil {
+			return nil, err
+		}
+		if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
+			w.log.Errorf("Err saving wallet; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
 
-	trx := entity.TrxEntity{ID: uuid.New().String(), WalletId: wallet.ID, Amount: req.Amount, TrxType: common.TrxTypeDeposit, CreatedAt: time.Now()}
-	w.log.Info("trx ", trx)
-	if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
-		w.log.Errorf("Err saving trx; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
+		trx := entity.TrxEntity{ID: trxId, WalletId: wallet.ID, Amount: req.Amount, Currency: wallet.Currency, TrxType: common.TrxTypeDeposit, CreatedAt: time.Now().UTC()}
+		w.log.Info("trx ", trx)
+		if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
+			w.log.Errorf("Err saving trx; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		if err := w.writeOutboxEvent(common.EventTypeDeposit, walletId, []entity.TrxEntity{trx}, dbTx); err != nil {
+			w.log.Errorf("Err saving outbox event; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
 
-	if err := dbTx.Commit().Error; err != nil {
-		w.log.Error("Err at commit ", err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
-	w.log.Info("Done committing")
+		trxRes := w.mapper.ToTrxResponse(trx, wallet.Balance)
+		if err := w.saveIdempotentResponse(idem, trxRes, dbTx); err != nil {
+			return nil, err
+		}
 
-	return response.ResonseWrapper{Data: w.mapper.ToTrxResponse(trx, wallet.Balance)}
+		return trxRes, nil
+	})
 }
 
-func (w *WalletService) WithdrawMoney(walletId string, req request.TrxReq) response.ResonseWrapper {
+func (w *WalletService) WithdrawMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("WithdrawMoney; walletId:%s", walletId)
 
-	dbTx := w.dbTxManager.GetTx().Begin()
-	if dbTx.Error != nil {
-		w.log.Error("Failed creating dbTrx ", dbTx.Error)
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			dbTx.Rollback()
-		}
-	}()
-	w.log.Info("DbTrx created")
+	idem := idempotentRequest{Key: idempotencyKey, UserId: principal.UserId, WalletId: walletId, Fingerprint: requestFingerprint(common.TrxTypeWithdrawal, walletId, req)}
+	return w.inIdempotentTx(ctx, idem, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+		w.log.Info("DbTrx created")
 
-	wallet, err := w.walletRepo.FindWalletByIdWithTx(walletId, dbTx.Clauses(clause.Locking{Strength: "UPDATE", Options: "NOWAIT"}))
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
-	}
-	w.log.Info("Wallet ", wallet)
+		revenueWalletId, err := w.feeRevenueWalletId(walletId, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		locked, err := w.lockWallets(dbTx, walletId, revenueWalletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		w.log.Info("Wallet ", wallet)
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
 
-	if wallet.Balance < req.Amount {
-		w.log.Errorf("Insufficient amount; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInsufficientAmount}
-	}
-	wallet.Balance -= req.Amount
-	if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
-		w.log.Errorf("Err saving wallet; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
+		if replay, appErr := w.findIdempotentResponse(idem, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		} else if replay != nil {
+			return *replay, nil
+		}
+		if appErr := w.checkCanDebit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
 
-	trx := entity.TrxEntity{ID: uuid.New().String(), WalletId: wallet.ID, Amount: req.Amount, TrxType: common.TrxTypeWithdrawal, CreatedAt: time.Now()}
-	w.log.Info("trx ", trx)
-	if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
-		w.log.Errorf("Err saving trx; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
+		fees, appErr := w.findFees(wallet, feeOperations(common.TrxTypeWithdrawal, false), req.Amount, dbTx)
+		if appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkAvailableBalance(wallet, req.Amount+totalFee(fees), dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkSpendingLimits(wallet, req.Amount, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+		trx, err := w.postWithdrawal(&wallet, req.Amount, fees, "", dbTx)
+		if err != nil {
+			return nil, err
+		}
 
-	if err := dbTx.Commit().Error; err != nil {
-		w.log.Error("Err at commit ", err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
-	w.log.Info("Done committing")
+		trxRes := w.mapper.ToTrxResponse(trx, wallet.Balance)
+		if err := w.saveIdempotentResponse(idem, trxRes, dbTx); err != nil {
+			return nil, err
+		}
 
-	return response.ResonseWrapper{Data: w.mapper.ToTrxResponse(trx, wallet.Balance)}
+		return trxRes, nil
+	})
 }
 
-func (w *WalletService) TransferMoney(walletId string, req request.TransferReq) response.ResonseWrapper {
+func (w *WalletService) TransferMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TransferReq, idempotencyKey string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("TransferMoney; walletId:%s", walletId)
 
-	dbTx := w.dbTxManager.GetTx().Begin()
-	if dbTx.Error != nil {
-		w.log.Error("Failed creating dbTrx ", dbTx.Error)
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
-	}
-	defer func() {
-		if r := recover(); r != nil {
-			dbTx.Rollback()
+	idem := idempotentRequest{Key: idempotencyKey, UserId: principal.UserId, WalletId: walletId, Fingerprint: requestFingerprint(common.TrxTypeTransferOut, walletId, req)}
+	return w.inIdempotentTx(ctx, idem, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		revenueWalletId, err := w.feeRevenueWalletId(walletId, dbTx)
+		if err != nil {
+			return nil, err
+		}
+		locked, err := w.lockWallets(dbTx, walletId, req.CounterpartyWalletId, revenueWalletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		w.log.Info("Wallet ", wallet)
+		if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+			return nil, appErr
 		}
-	}()
 
-	wallet, err := w.walletRepo.FindWalletByIdWithTx(walletId, dbTx.Clauses(clause.Locking{Strength: "UPDATE"}))
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
-	}
-	w.log.Info("Wallet ", wallet)
-	if wallet.Balance < req.Amount {
-		w.log.Errorf("Insufficient amount; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInsufficientAmount}
-	}
+		if replay, appErr := w.findIdempotentResponse(idem, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		} else if replay != nil {
+			return *replay, nil
+		}
+		if appErr := w.checkCanDebit(wallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkAvailableBalance(wallet, req.Amount, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+		if appErr := w.checkSpendingLimits(wallet, req.Amount, dbTx); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		if walletId == req.CounterpartyWalletId {
+			w.log.Errorf("CounterpartyWalletId same as walletId; walletId:%s counterpartyWalletId:%s", walletId, req.CounterpartyWalletId)
+			return nil, apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet
+		}
+
+		counterpartyWallet, ok := locked[req.CounterpartyWalletId]
+		if !ok {
+			w.log.Errorf("CounterpartyWallet not found; walletId:%s counterpartyWalletId:%s", walletId, req.CounterpartyWalletId)
+			return nil, apperror.ErrCounterpartyWalletNotFound
+		}
+		w.log.Info("CounterpartyWallet ", counterpartyWallet)
+		if appErr := w.checkCounterpartyCanCredit(counterpartyWallet); appErr.Code != 0 {
+			return nil, appErr
+		}
+
+		fees, appErr := w.findFees(wallet, feeOperations(common.TrxTypeTransferOut, wallet.Currency != counterpartyWallet.Currency), req.Amount, dbTx)
+		if appErr.Code != 0 {
+			return nil, appErr
+		}
+		if len(fees) > 0 { // the amount alone was checked above, before the counterparty was known
+			if appErr := w.checkAvailableBalance(wallet, req.Amount+totalFee(fees), dbTx); appErr.Code != 0 {
+				return nil, appErr
+			}
+		}
+
+		trx, err := w.postTransfer(&wallet, &counterpartyWallet, req, fees, "", dbTx)
+		if err != nil {
+			return nil, err
+		}
+
+		trxRes := w.mapper.ToTrxResponse(trx, wallet.Balance)
+		if err := w.saveIdempotentResponse(idem, trxRes, dbTx); err != nil {
+			return nil, err
+		}
+
+		return trxRes, nil
+	})
+}
+
+func (w *WalletService) QuoteTransfer(ctx context.Context, principal auth.Principal, walletId string, req request.TransferQuoteReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("QuoteTransfer; walletId:%s", walletId)
 
 	if walletId == req.CounterpartyWalletId {
 		w.log.Errorf("CounterpartyWalletId same as walletId; walletId:%s counterpartyWalletId:%s", walletId, req.CounterpartyWalletId)
-		dbTx.Rollback()
 		return response.ResonseWrapper{Err: apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet}
 	}
 
-	counterpartyWallet, err := w.walletRepo.FindWalletByIdWithTx(req.CounterpartyWalletId, dbTx.Clauses(clause.Locking{Strength: "UPDATE"}))
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	counterpartyWallet, err := w.walletRepo.FindWalletById(ctx, req.CounterpartyWalletId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		w.log.Errorf("CounterpartyWallet not found; walletId:%s %v", walletId, err)
-		dbTx.Rollback()
 		return response.ResonseWrapper{Err: apperror.ErrCounterpartyWalletNotFound}
 	}
-	w.log.Info("CounterpartyWallet ", counterpartyWallet)
-
-	wallet.Balance -= req.Amount
-	counterpartyWallet.Balance += req.Amount
-	wallets := []entity.WalletEntity{wallet, counterpartyWallet}
-
-	if err := w.walletRepo.SaveWalletsWithTx(wallets, dbTx); err != nil {
-		w.log.Error("Err saving wallets; ", err)
-		dbTx.Rollback()
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", req.CounterpartyWalletId, err)
 		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
 	}
 
-	groupId := uuid.New().String()
-	trx := entity.TrxEntity{ID: uuid.New().String(), WalletId: walletId, Amount: req.Amount, CounterpartyWalletId: req.CounterpartyWalletId, TrxType: common.TrxTypeTransferOut, GroupId: groupId, CreatedAt: time.Now()}
-	counterpartyTrx := entity.TrxEntity{ID: uuid.New().String(), WalletId: req.CounterpartyWalletId, Amount: req.Amount, CounterpartyWalletId: walletId, TrxType: common.TrxTypeTransferIn, GroupId: groupId, CreatedAt: time.Now()}
-	trxs := []entity.TrxEntity{trx, counterpartyTrx}
-	if err := w.trxRepo.SaveTrxsWithDbTx(trxs, dbTx); err != nil {
-		w.log.Error("Err saving trxs; ", err)
-		dbTx.Rollback()
-		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	rate, err := w.fxProvider.GetRate(wallet.Currency, counterpartyWallet.Currency)
+	if err != nil {
+		w.log.Errorf("Fx rate not available; from:%s to:%s %v", wallet.Currency, counterpartyWallet.Currency, err)
+		return response.ResonseWrapper{Err: apperror.ErrFxRateNotAvailable}
+	}
+
+	destinationAmount := fx.Convert(req.Amount, rate, common.CurrencyExponent(wallet.Currency), common.CurrencyExponent(counterpartyWallet.Currency))
+	if destinationAmount == 0 && req.Amount > 0 {
+		w.log.Errorf("Amount too small to convert; walletId:%s amount:%d", walletId, req.Amount)
+		return response.ResonseWrapper{Err: apperror.ErrFxAmountTooSmall}
 	}
-	w.log.Info("Trxs ", trxs)
 
-	if err := dbTx.Commit().Error; err != nil {
-		w.log.Error("Err at commit ", err)
-		dbTx.Rollback()
+	now := time.Now()
+	quote := entity.FxQuoteEntity{
+		ID:                   uuid.New().String(),
+		WalletId:             walletId,
+		CounterpartyWalletId: req.CounterpartyWalletId,
+		SourceCurrency:       wallet.Currency,
+		SourceAmount:         req.Amount,
+		DestinationCurrency:  counterpartyWallet.Currency,
+		DestinationAmount:    destinationAmount,
+		Rate:                 fx.FormatRate(rate),
+		ExpiresAt:            now.Add(w.cfg.Fx.QuoteTtl),
+		CreatedAt:            now,
+	}
+	if err := w.fxQuoteRepo.SaveFxQuote(quote); err != nil {
+		w.log.Error("Err saving fx quote; ", err)
 		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
 	}
-	w.log.Info("Done committing")
+	w.log.Info("Quote ", quote)
 
-	trxRes := w.mapper.ToTrxResponse(trx, wallet.Balance)
-	return response.ResonseWrapper{Data: trxRes}
+	return response.ResonseWrapper{Data: w.mapper.ToTransferQuoteResponse(quote)}
 }
 
-func (w *WalletService) GetBalance(walletId string) response.ResonseWrapper {
+func (w *WalletService) GetBalance(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("GetBalance; walletId:%s", walletId)
-	wallet, err := w.walletRepo.FindWalletById(walletId)
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
 		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
 	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
 	w.log.Info("Wallet ", wallet)
-	return response.ResonseWrapper{Data: wallet}
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	available, appErr := w.findAvailableBalance(wallet)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: w.mapper.ToWalletResponse(wallet, available)}
 }
 
-func (w *WalletService) GetTransactions(walletId string) response.ResonseWrapper {
+func (w *WalletService) GetTransactions(ctx context.Context, principal auth.Principal, walletId string, req request.TrxHistoryReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Infof("GetTransactions; walletId:%s", walletId)
-	wallet, err := w.walletRepo.FindWalletById(walletId)
+	wallet, err := w.walletRepo.FindWalletById(ctx, walletId)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
 		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
 	}
+	if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
 	w.log.Info("Wallet ", wallet)
-	trxs := w.trxRepo.FindTransactionsByWalletId(walletId)
+	if appErr := w.authorize(principal, wallet); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+
+	query, appErr := toTrxQuery(walletId, req)
+	if appErr.Code != 0 {
+		w.log.Errorf("Invalid transaction history request; walletId:%s %v", walletId, appErr.Message)
+		return response.ResonseWrapper{Err: appErr}
+	}
+	pageSize := query.Limit
+	query.Limit = pageSize + 1 // one extra row tells whether there is a next page
+
+	trxs, err := w.trxRepo.FindTransactions(ctx, query)
+	if err != nil {
+		w.log.Errorf("Err finding transactions; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
 	w.log.Info("Trxs ", trxs)
-	return response.ResonseWrapper{Data: trxs}
+
+	nextCursor := ""
+	if len(trxs) > pageSize {
+		trxs = trxs[:pageSize]
+		nextCursor = encodeTrxCursor(trxs[pageSize-1])
+	}
+	return response.ResonseWrapper{Data: response.TrxHistoryResponse{Transactions: w.mapper.ToTransactionResponses(trxs), NextCursor: nextCursor}}
 }
 
-func (w *WalletService) GetAllWallets() response.ResonseWrapper {
+func (w *WalletService) GetAllWallets(ctx context.Context) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Info("GetAllWallets")
-	wallets := w.walletRepo.FindAllWallets()
+	wallets := w.walletRepo.FindAllWallets(ctx)
 	w.log.Info("Wallets ", wallets)
 	return response.ResonseWrapper{Data: wallets}
 }
 
-func (w *WalletService) GetAllTrxs() response.ResonseWrapper {
+func (w *WalletService) GetAllTrxs(ctx context.Context) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Info("GetAllTrxs")
-	trxs := w.trxRepo.FindAllTrxs()
+	trxs := w.trxRepo.FindAllTrxs(ctx)
 	w.log.Info("Trxs ", trxs)
 	return response.ResonseWrapper{Data: trxs}
 }
 
-func (w *WalletService) DeleteAll() response.ResonseWrapper {
+func (w *WalletService) DeleteAll(ctx context.Context, principal auth.Principal) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
 	w.log.Info("DeleteAll")
-	w.walletRepo.DeleteAllWallets()
-	w.trxRepo.DeleteAllTrxs()
-	return response.ResonseWrapper{}
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		if err := manager.Bind(uow, w.walletRepo).DeleteAll(ctx); err != nil {
+			w.log.Errorf("Err deleting all; %v", err)
+			return nil, dbErr(err)
+		}
+		return nil, nil
+	})
+}
+
+func (w *WalletService) authorize(principal auth.Principal, wallet entity.WalletEntity) apperror.AppError {
+	if !principal.CanAccess(wallet.UserId) {
+		w.log.Errorf("Forbidden; caller:%s walletId:%s", principal.UserId, wallet.ID)
+		return apperror.ErrForbidden
+	}
+	return apperror.AppError{}
+}
+
+// checkAvailableBalance must be called while holding the wallet lock, which also serializes hold creation.
+func (w *WalletService) checkAvailableBalance(wallet entity.WalletEntity, amount uint, dbTx *gorm.DB) apperror.AppError {
+	held, err := w.holdRepo.SumActiveHoldAmountWithTx(wallet.ID, time.Now().UTC(), dbTx)
+	if err != nil {
+		w.log.Errorf("Err summing holds; walletId:%s %v", wallet.ID, err)
+		return apperror.ErrInternalServer
+	}
+	if availableBalance(wallet.Balance, held) < amount {
+		w.log.Errorf("Insufficient amount; walletId:%s balance:%d held:%d amount:%d", wallet.ID, wallet.Balance, held, amount)
+		return apperror.ErrInsufficientAmount
+	}
+	return apperror.AppError{}
+}
+
+func (w *WalletService) findAvailableBalance(wallet entity.WalletEntity) (uint, apperror.AppError) {
+	held, err := w.holdRepo.SumActiveHoldAmount(wallet.ID, time.Now().UTC())
+	if err != nil {
+		w.log.Errorf("Err summing holds; walletId:%s %v", wallet.ID, err)
+		return 0, apperror.ErrInternalServer
+	}
+	return availableBalance(wallet.Balance, held), apperror.AppError{}
+}
+
+func availableBalance(balance uint, held uint) uint {
+	if held > balance {
+		return 0
+	}
+	return balance - held
+}
+
+// postWithdrawal moves amount out of the locked wallet and saves it with its withdrawal row, then
+// posts the fees on top.
+func (w *WalletService) postWithdrawal(wallet *entity.WalletEntity, amount uint, fees []feeCharge, holdId string, dbTx *gorm.DB) (entity.TrxEntity, error) {
+	trxId := uuid.New().String()
+	legs := []LedgerLeg{
+		Debit(WalletAccount(*wallet), amount),
+		Credit(SystemAccount(common.SystemAccountCashOut, wallet.Currency), amount),
+	}
+	if err := w.postJournal(trxId, legs, []*entity.WalletEntity{wallet}, dbTx); err != nil {
+		return entity.TrxEntity{}, err
+	}
+	if err := w.walletRepo.SaveWalletWithTx(*wallet, dbTx); err != nil {
+		w.log.Errorf("Err saving wallet; walletId:%s %v", wallet.ID, err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+
+	trx := entity.TrxEntity{ID: trxId, WalletId: wallet.ID, Amount: amount, Currency: wallet.Currency, TrxType: common.TrxTypeWithdrawal, HoldId: holdId, Fee: totalFee(fees), CreatedAt: time.Now().UTC()}
+	w.log.Info("trx ", trx)
+	if err := w.trxRepo.SaveTrxWithDbTx(trx, dbTx); err != nil {
+		w.log.Errorf("Err saving trx; walletId:%s %v", wallet.ID, err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+	feeTrxs, err := w.postFees(wallet, trx, fees, dbTx)
+	if err != nil {
+		return entity.TrxEntity{}, err
+	}
+	if err := w.writeOutboxEvent(common.EventTypeWithdrawal, wallet.ID, append([]entity.TrxEntity{trx}, feeTrxs...), dbTx); err != nil {
+		w.log.Errorf("Err saving outbox event; walletId:%s %v", wallet.ID, err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+	return trx, nil
+}
+
+// postTransfer moves req.Amount between the two locked wallets, converting through the quote when
+// their currencies differ, and saves the transfer_out/transfer_in pair, then posts the fees on top.
+// It returns the transfer_out row.
+func (w *WalletService) postTransfer(wallet *entity.WalletEntity, counterpartyWallet *entity.WalletEntity, req request.TransferReq, fees []feeCharge, holdId string, dbTx *gorm.DB) (entity.TrxEntity, error) {
+	creditAmount := req.Amount
+	fxRate := ""
+	if req.QuoteId != "" {
+		quote, err := w.consumeFxQuote(req, *wallet, *counterpartyWallet, dbTx)
+		if err != nil {
+			return entity.TrxEntity{}, err
+		}
+		creditAmount = quote.DestinationAmount
+		fxRate = quote.Rate
+	} else if wallet.Currency != counterpartyWallet.Currency {
+		w.log.Errorf("Fx quote required; walletId:%s currency:%s counterpartyWalletId:%s counterpartyCurrency:%s", wallet.ID, wallet.Currency, counterpartyWallet.ID, counterpartyWallet.Currency)
+		return entity.TrxEntity{}, apperror.ErrFxQuoteRequired
+	}
+
+	groupId := uuid.New().String()
+	legs := []LedgerLeg{
+		Debit(WalletAccount(*wallet), req.Amount),
+		Credit(WalletAccount(*counterpartyWallet), creditAmount),
+	}
+	if wallet.Currency != counterpartyWallet.Currency {
+		legs = append(legs,
+			Credit(SystemAccount(common.SystemAccountFx, wallet.Currency), req.Amount),
+			Debit(SystemAccount(common.SystemAccountFx, counterpartyWallet.Currency), creditAmount),
+		)
+	}
+	if err := w.postJournal(groupId, legs, []*entity.WalletEntity{wallet, counterpartyWallet}, dbTx); err != nil {
+		return entity.TrxEntity{}, err
+	}
+
+	wallets := []entity.WalletEntity{*wallet, *counterpartyWallet}
+	if err := w.walletRepo.SaveWalletsWithTx(wallets, dbTx); err != nil {
+		w.log.Error("Err saving wallets; ", err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+
+	trx := entity.TrxEntity{
+		ID:                   uuid.New().String(),
+		WalletId:             wallet.ID,
+		Amount:               req.Amount,
+		Currency:             wallet.Currency,
+		CounterpartyWalletId: counterpartyWallet.ID,
+		CounterpartyAmount:   creditAmount,
+		CounterpartyCurrency: counterpartyWallet.Currency,
+		FxRate:               fxRate,
+		FxQuoteId:            req.QuoteId,
+		TrxType:              common.TrxTypeTransferOut,
+		GroupId:              groupId,
+		HoldId:               holdId,
+		Fee:                  totalFee(fees),
+		CreatedAt:            time.Now().UTC(),
+	}
+	counterpartyTrx := entity.TrxEntity{
+		ID:                   uuid.New().String(),
+		WalletId:             counterpartyWallet.ID,
+		Amount:               creditAmount,
+		Currency:             counterpartyWallet.Currency,
+		CounterpartyWalletId: wallet.ID,
+		CounterpartyAmount:   req.Amount,
+		CounterpartyCurrency: wallet.Currency,
+		FxRate:               fxRate,
+		FxQuoteId:            req.QuoteId,
+		TrxType:              common.TrxTypeTransferIn,
+		GroupId:              groupId,
+		CreatedAt:            time.Now().UTC(),
+	}
+	trxs := []entity.TrxEntity{trx, counterpartyTrx}
+	if err := w.trxRepo.SaveTrxsWithDbTx(trxs, dbTx); err != nil {
+		w.log.Error("Err saving trxs; ", err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+	feeTrxs, err := w.postFees(wallet, trx, fees, dbTx)
+	if err != nil {
+		return entity.TrxEntity{}, err
+	}
+	trxs = append(trxs, feeTrxs...)
+	if err := w.writeOutboxEvent(common.EventTypeTransfer, wallet.ID, trxs, dbTx); err != nil {
+		w.log.Errorf("Err saving outbox event; walletId:%s %v", wallet.ID, err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+	w.log.Info("Trxs ", trxs)
+	return trx, nil
+}
+
+// reportTimeout answers a call that failed because its context was cancelled or timed out, e.g. while
+// waiting for a wallet lock, with ErrRequestTimeout rather than an internal error. Its transaction has
+// been rolled back by then, by the service or by database/sql when the context ended.
+func (w *WalletService) reportTimeout(ctx context.Context, res *response.ResonseWrapper) {
+	if res.Err.Code >= 500 && ctx.Err() != nil {
+		w.log.Errorf("Request timed out or was cancelled; %v", context.Cause(ctx))
+		res.Err = apperror.ErrRequestTimeout
+	}
+}
+
+// inTx runs fn in a unit of work and answers with the data it returns. An AppError returned by fn is
+// the answer as it is; a db error the tx manager gave up retrying means the wallet is busy, and any
+// other error, e.g. a failed commit, is an internal error.
+func (w *WalletService) inTx(ctx context.Context, fn func(uow *manager.UnitOfWork) (any, error)) response.ResonseWrapper {
+	var data any
+	err := w.dbTxManager.InTx(ctx, func(uow *manager.UnitOfWork) error {
+		var err error
+		data, err = fn(uow)
+		return err
+	})
+	if appErr := w.toAppError(err); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: data}
+}
+
+func (w *WalletService) toAppError(err error) apperror.AppError {
+	var appErr apperror.AppError
+	switch {
+	case err == nil:
+		return apperror.AppError{}
+	case errors.As(err, &appErr):
+		return appErr
+	case appdb.IsRetryable(err):
+		w.log.Error("Err in dbTx, retries exhausted ", err)
+		return apperror.ErrWalletBusy
+	}
+	w.log.Error("Err in dbTx ", err)
+	return apperror.ErrInternalServer
 }
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ChangeWalletStatus moves the wallet along the status state machine. Closing needs a zero balance,
+// or SweepToWalletId to transfer what is left into first, and no active holds.
+func (w *WalletService) ChangeWalletStatus(ctx context.Context, principal auth.Principal, walletId string, req request.WalletStatusReq) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("ChangeWalletStatus; walletId:%s status:%s", walletId, req.Status)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	status := common.WalletStatus(req.Status)
+	if !status.IsValid() {
+		w.log.Errorf("Invalid wallet status; walletId:%s status:%s", walletId, req.Status)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidWalletStatus}
+	}
+	reasonCode := common.WalletStatusReason(req.ReasonCode)
+	if !reasonCode.IsValid() || (reasonCode == common.WalletStatusReasonOther && req.Note == "") {
+		w.log.Errorf("Invalid wallet status reason; walletId:%s reasonCode:%s", walletId, req.ReasonCode)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidWalletStatusReason}
+	}
+	if req.SweepToWalletId != "" && (status != common.WalletStatusClosed || req.SweepToWalletId == walletId) {
+		w.log.Errorf("Invalid sweep wallet; walletId:%s sweepToWalletId:%s", walletId, req.SweepToWalletId)
+		return response.ResonseWrapper{Err: apperror.ErrInvalidSweepWallet}
+	}
+
+	return w.inTx(ctx, func(uow *manager.UnitOfWork) (any, error) {
+		dbTx := uow.Tx()
+
+		locked, err := w.lockWallets(dbTx, walletId, req.SweepToWalletId)
+		if err != nil {
+			return nil, err
+		}
+		wallet, ok := locked[walletId]
+		if !ok {
+			w.log.Errorf("Wallet not found; walletId:%s", walletId)
+			return nil, apperror.ErrWalletNotFound
+		}
+		w.log.Info("Wallet ", wallet)
+		if !wallet.Status.CanTransitionTo(status) {
+			w.log.Errorf("Wallet status transition not allowed; walletId:%s from:%s to:%s", walletId, wallet.Status, status)
+			return nil, apperror.ErrWalletStatusTransitionNotAllowed
+		}
+
+		change := entity.WalletStatusChangeEntity{
+			ID:         uuid.New().String(),
+			WalletId:   walletId,
+			FromStatus: wallet.Status,
+			ToStatus:   status,
+			ReasonCode: reasonCode,
+			Note:       req.Note,
+			Actor:      principal.UserId,
+			CreatedAt:  time.Now().UTC(),
+		}
+		if status == common.WalletStatusClosed {
+			sweepTrx, err := w.sweepClosingWallet(&wallet, req.SweepToWalletId, locked, dbTx)
+			if err != nil {
+				return nil, err
+			}
+			change.SweepTrxId = sweepTrx.ID
+		}
+
+		wallet.Status = status
+		wallet.UpdatedAt = change.CreatedAt
+		if err := w.walletRepo.SaveWalletWithTx(wallet, dbTx); err != nil {
+			w.log.Errorf("Err saving wallet; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+		if err := w.walletRepo.SaveWalletStatusChangeWithTx(change, dbTx); err != nil {
+			w.log.Errorf("Err saving wallet status change; walletId:%s %v", walletId, err)
+			return nil, dbErr(err)
+		}
+
+		w.log.Infof("Wallet status changed; walletId:%s from:%s to:%s by:%s reasonCode:%s", walletId, change.FromStatus, status, principal.UserId, reasonCode)
+
+		return w.mapper.ToWalletStatusChangeResponse(change), nil
+	})
+}
+
+func (w *WalletService) GetWalletStatusChanges(ctx context.Context, principal auth.Principal, walletId string) (result response.ResonseWrapper) {
+	defer w.reportTimeout(ctx, &result)
+	w.log.Infof("GetWalletStatusChanges; walletId:%s", walletId)
+	if !principal.IsAdmin() {
+		w.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return response.ResonseWrapper{Err: apperror.ErrForbidden}
+	}
+	if _, err := w.walletRepo.FindWalletById(ctx, walletId); errors.Is(err, gorm.ErrRecordNotFound) {
+		w.log.Errorf("Wallet not found; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrWalletNotFound}
+	} else if err != nil {
+		w.log.Errorf("Err finding wallet; walletId:%s %v", walletId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: w.mapper.ToWalletStatusChangeResponses(w.walletRepo.FindWalletStatusChanges(ctx, walletId))}
+}
+
+// sweepClosingWallet empties the locked wallet into sweepToWalletId, locked with it, with a fee-free
+// transfer. A wallet with active holds cannot be closed, since the hold could still be captured.
+func (w *WalletService) sweepClosingWallet(wallet *entity.WalletEntity, sweepToWalletId string, locked map[string]entity.WalletEntity, dbTx *gorm.DB) (entity.TrxEntity, error) {
+	held, err := w.holdRepo.SumActiveHoldAmountWithTx(wallet.ID, time.Now().UTC(), dbTx)
+	if err != nil {
+		w.log.Errorf("Err summing holds; walletId:%s %v", wallet.ID, err)
+		return entity.TrxEntity{}, dbErr(err)
+	}
+	if held > 0 {
+		w.log.Errorf("Wallet has active holds; walletId:%s held:%d", wallet.ID, held)
+		return entity.TrxEntity{}, apperror.ErrWalletHasActiveHolds
+	}
+	if wallet.Balance == 0 {
+		return entity.TrxEntity{}, nil
+	}
+	if sweepToWalletId == "" {
+		w.log.Errorf("Wallet balance not zero; walletId:%s balance:%d", wallet.ID, wallet.Balance)
+		return entity.TrxEntity{}, apperror.ErrWalletBalanceNotZero
+	}
+
+	sweepWallet, ok := locked[sweepToWalletId]
+	if !ok {
+		w.log.Errorf("Sweep wallet not found; walletId:%s sweepToWalletId:%s", wallet.ID, sweepToWalletId)
+		return entity.TrxEntity{}, apperror.ErrInvalidSweepWallet
+	}
+	if sweepWallet.Currency != wallet.Currency || !sweepWallet.Status.AllowsCredit() {
+		w.log.Errorf("Invalid sweep wallet; walletId:%s sweepToWalletId:%s currency:%s status:%s", wallet.ID, sweepToWalletId, sweepWallet.Currency, sweepWallet.Status)
+		return entity.TrxEntity{}, apperror.ErrInvalidSweepWallet
+	}
+	transferReq := request.TransferReq{Amount: wallet.Balance, CounterpartyWalletId: sweepToWalletId}
+	return w.postTransfer(wallet, &sweepWallet, transferReq, nil, "", dbTx)
+}
+
+// checkCanDebit and the checks below must be called on the locked wallet, so that a status change
+// cannot land between the check and the posting.
+func (w *WalletService) checkCanDebit(wallet entity.WalletEntity) apperror.AppError {
+	if wallet.Status.AllowsDebit() {
+		return apperror.AppError{}
+	}
+	w.log.Errorf("Wallet does not allow debits; walletId:%s status:%s", wallet.ID, wallet.Status)
+	if wallet.Status == common.WalletStatusClosed {
+		return apperror.ErrWalletClosed
+	}
+	return apperror.ErrWalletFrozen
+}
+
+func (w *WalletService) checkCanCredit(wallet entity.WalletEntity) apperror.AppError {
+	if wallet.Status.AllowsCredit() {
+		return apperror.AppError{}
+	}
+	w.log.Errorf("Wallet does not allow credits; walletId:%s status:%s", wallet.ID, wallet.Status)
+	if wallet.Status == common.WalletStatusClosed {
+		return apperror.ErrWalletClosed
+	}
+	return apperror.ErrWalletFrozen
+}
+
+func (w *WalletService) checkCounterpartyCanCredit(counterpartyWallet entity.WalletEntity) apperror.AppError {
+	if counterpartyWallet.Status.AllowsCredit() {
+		return apperror.AppError{}
+	}
+	w.log.Errorf("Counterparty wallet does not allow credits; walletId:%s status:%s", counterpartyWallet.ID, counterpartyWallet.Status)
+	if counterpartyWallet.Status == common.WalletStatusClosed {
+		return apperror.ErrCounterpartyWalletClosed
+	}
+	return apperror.ErrCounterpartyWalletFrozen
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/webhook"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const outboxBatchSize = 100
+const dueDeliveryBatchSize = 100
+const webhookDeliveryHistoryLimit = 100
+
+// IWebhookService manages webhook subscriptions, which are admin only, and delivers outbox events to them.
+type IWebhookService interface {
+	CreateWebhookSubscription(principal auth.Principal, req request.CreateWebhookSubscriptionReq) response.ResonseWrapper
+	GetWebhookSubscriptions(principal auth.Principal) response.ResonseWrapper
+	GetWebhookSubscription(principal auth.Principal, subscriptionId string) response.ResonseWrapper
+	UpdateWebhookSubscription(principal auth.Principal, subscriptionId string, req request.UpdateWebhookSubscriptionReq) response.ResonseWrapper
+	DeleteWebhookSubscription(principal auth.Principal, subscriptionId string) response.ResonseWrapper
+	GetWebhookDeliveries(principal auth.Principal, subscriptionId string, status string) response.ResonseWrapper
+	RedeliverWebhook(principal auth.Principal, deliveryId string) response.ResonseWrapper
+
+	DispatchOutboxEvents(now time.Time) response.ResonseWrapper
+	DeliverWebhooks(now time.Time) response.ResonseWrapper
+}
+
+type WebhookService struct {
+	log         *logrus.Logger
+	cfg         *config.AppConfig
+	dbTxManager manager.IDbTxManager
+	outboxRepo  repo.IOutboxRepo
+	webhookRepo repo.IWebhookRepo
+	sender      webhook.IWebhookSender
+	mapper      *mapper.AppMapper
+}
+
+func NewWebhookService(log *logrus.Logger, cfg *config.AppConfig, outboxRepo repo.IOutboxRepo, webhookRepo repo.IWebhookRepo, sender webhook.IWebhookSender, mapper *mapper.AppMapper, dbTxManager manager.IDbTxManager) IWebhookService {
+	return &WebhookService{log: log, cfg: cfg, outboxRepo: outboxRepo, webhookRepo: webhookRepo, sender: sender, mapper: mapper, dbTxManager: dbTxManager}
+}
+
+func (s *WebhookService) CreateWebhookSubscription(principal auth.Principal, req request.CreateWebhookSubscriptionReq) response.ResonseWrapper {
+	s.log.Infof("CreateWebhookSubscription; url:%s", req.Url)
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if appErr := s.validateUrl(req.Url); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	eventTypes, appErr := s.joinEventTypes(req.EventTypes)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		secret = newWebhookSecret()
+	}
+	now := time.Now().UTC()
+	subscription := entity.WebhookSubscriptionEntity{
+		ID:         uuid.New().String(),
+		Url:        req.Url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedBy:  principal.UserId,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.webhookRepo.SaveWebhookSubscription(subscription); err != nil {
+		s.log.Error("Err saving webhook subscription; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	res := s.mapper.ToWebhookSubscriptionResponse(subscription)
+	res.Secret = subscription.Secret
+	return response.ResonseWrapper{Data: res}
+}
+
+func (s *WebhookService) GetWebhookSubscriptions(principal auth.Principal) response.ResonseWrapper {
+	s.log.Info("GetWebhookSubscriptions")
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToWebhookSubscriptionResponses(s.webhookRepo.FindWebhookSubscriptions())}
+}
+
+func (s *WebhookService) GetWebhookSubscription(principal auth.Principal, subscriptionId string) response.ResonseWrapper {
+	s.log.Infof("GetWebhookSubscription; subscriptionId:%s", subscriptionId)
+	subscription, appErr := s.findSubscription(principal, subscriptionId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToWebhookSubscriptionResponse(subscription)}
+}
+
+func (s *WebhookService) UpdateWebhookSubscription(principal auth.Principal, subscriptionId string, req request.UpdateWebhookSubscriptionReq) response.ResonseWrapper {
+	s.log.Infof("UpdateWebhookSubscription; subscriptionId:%s", subscriptionId)
+	subscription, appErr := s.findSubscription(principal, subscriptionId)
+	if appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if req.Url != nil {
+		if appErr := s.validateUrl(*req.Url); appErr.Code != 0 {
+			return response.ResonseWrapper{Err: appErr}
+		}
+		subscription.Url = *req.Url
+	}
+	if req.EventTypes != nil {
+		eventTypes, appErr := s.joinEventTypes(*req.EventTypes)
+		if appErr.Code != 0 {
+			return response.ResonseWrapper{Err: appErr}
+		}
+		subscription.EventTypes = eventTypes
+	}
+	if req.Active != nil {
+		subscription.Active = *req.Active
+	}
+	subscription.UpdatedAt = time.Now().UTC()
+
+	if err := s.webhookRepo.SaveWebhookSubscription(subscription); err != nil {
+		s.log.Errorf("Err saving webhook subscription; subscriptionId:%s %v", subscriptionId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToWebhookSubscriptionResponse(subscription)}
+}
+
+func (s *WebhookService) DeleteWebhookSubscription(principal auth.Principal, subscriptionId string) response.ResonseWrapper {
+	s.log.Infof("DeleteWebhookSubscription; subscriptionId:%s", subscriptionId)
+	if _, appErr := s.findSubscription(principal, subscriptionId); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	if err := s.webhookRepo.DeleteWebhookSubscription(subscriptionId); err != nil {
+		s.log.Errorf("Err deleting webhook subscription; subscriptionId:%s %v", subscriptionId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{}
+}
+
+func (s *WebhookService) GetWebhookDeliveries(principal auth.Principal, subscriptionId string, status string) response.ResonseWrapper {
+	s.log.Infof("GetWebhookDeliveries; subscriptionId:%s status:%s", subscriptionId, status)
+	if _, appErr := s.findSubscription(principal, subscriptionId); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	switch common.WebhookDeliveryStatus(status) {
+	case "", common.WebhookDeliveryPending, common.WebhookDeliveryDelivered, common.WebhookDeliveryDead, common.WebhookDeliveryDropped:
+	default:
+		s.log.Errorf("Invalid webhook delivery status; status:%s", status)
+		return response.ResonseWrapper{Err: apperror.ErrIncompatibleRequest}
+	}
+	deliveries := s.webhookRepo.FindWebhookDeliveries(subscriptionId, common.WebhookDeliveryStatus(status), webhookDeliveryHistoryLimit)
+	return response.ResonseWrapper{Data: s.mapper.ToWebhookDeliveryResponses(deliveries)}
+}
+
+// RedeliverWebhook queues a delivery again with a fresh attempt budget, whatever its status.
+func (s *WebhookService) RedeliverWebhook(principal auth.Principal, deliveryId string) response.ResonseWrapper {
+	s.log.Infof("RedeliverWebhook; deliveryId:%s", deliveryId)
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return response.ResonseWrapper{Err: appErr}
+	}
+	delivery, err := s.webhookRepo.FindWebhookDeliveryById(deliveryId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Errorf("Webhook delivery not found; deliveryId:%s", deliveryId)
+		return response.ResonseWrapper{Err: apperror.ErrWebhookDeliveryNotFound}
+	}
+	if err != nil {
+		s.log.Errorf("Err finding webhook delivery; deliveryId:%s %v", deliveryId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	now := time.Now().UTC()
+	delivery.Status = common.WebhookDeliveryPending
+	delivery.Attempt = 0
+	delivery.NextAttemptAt = now
+	delivery.UpdatedAt = now
+	if err := s.webhookRepo.SaveWebhookDelivery(delivery); err != nil {
+		s.log.Errorf("Err saving webhook delivery; deliveryId:%s %v", deliveryId, err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	return response.ResonseWrapper{Data: s.mapper.ToWebhookDeliveryResponse(delivery)}
+}
+
+// DispatchOutboxEvents creates a pending delivery per matching active subscription for every event not
+// dispatched yet. Data is the number of events dispatched.
+func (s *WebhookService) DispatchOutboxEvents(now time.Time) response.ResonseWrapper {
+	now = now.UTC()
+	events, err := s.outboxRepo.FindUndispatchedOutboxEvents(outboxBatchSize)
+	if err != nil {
+		s.log.Error("Err finding outbox events; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+	if len(events) == 0 {
+		return response.ResonseWrapper{Data: 0}
+	}
+	subscriptions, err := s.webhookRepo.FindActiveWebhookSubscriptions()
+	if err != nil {
+		s.log.Error("Err finding webhook subscriptions; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	dispatched := 0
+	for _, event := range events {
+		if err := s.dispatchEvent(event, subscriptions, now); err != nil {
+			s.log.Errorf("Err dispatching outbox event; eventId:%s %v", event.ID, err)
+			continue
+		}
+		dispatched++
+	}
+	return response.ResonseWrapper{Data: dispatched}
+}
+
+func (s *WebhookService) dispatchEvent(event entity.OutboxEventEntity, subscriptions []entity.WebhookSubscriptionEntity, now time.Time) error {
+	deliveries := []entity.WebhookDeliveryEntity{}
+	for _, subscription := range subscriptions {
+		if !subscription.Accepts(event.EventType) {
+			continue
+		}
+		deliveries = append(deliveries, entity.WebhookDeliveryEntity{
+			ID:             uuid.New().String(),
+			EventId:        event.ID,
+			SubscriptionId: subscription.ID,
+			Status:         common.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	return s.dbTxManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		marked, err := s.outboxRepo.MarkOutboxEventDispatchedWithTx(event.ID, now, uow.Tx())
+		if err != nil || !marked {
+			return err // nil when another instance dispatched it first
+		}
+		return s.webhookRepo.SaveWebhookDeliveriesWithTx(deliveries, uow.Tx())
+	})
+}
+
+// DeliverWebhooks attempts every pending delivery that is due. A 2xx response marks it delivered; anything
+// else is retried with exponential backoff until webhooks.maxAttempts, after which it is dead. Deliveries of
+// an inactive subscription are dropped without being sent. now is when the job took its lease: no attempt
+// starts unless it can time out before webhooks.leaseTtl from then, so another instance taking over the
+// expired lease never sends the same deliveries; the rest wait for the next tick. Delivery is still at
+// least once: receivers should ignore an event id they have already seen. Data is the number of attempts.
+func (s *WebhookService) DeliverWebhooks(now time.Time) response.ResonseWrapper {
+	now = now.UTC()
+	leaseExpiresAt := now.Add(s.cfg.Webhooks.LeaseTtl)
+	deliveries, err := s.webhookRepo.FindDueWebhookDeliveries(now, dueDeliveryBatchSize)
+	if err != nil {
+		s.log.Error("Err finding due webhook deliveries; ", err)
+		return response.ResonseWrapper{Err: apperror.ErrInternalServer}
+	}
+
+	attempts := 0
+	subscriptions := map[string]entity.WebhookSubscriptionEntity{}
+	for _, delivery := range deliveries {
+		if time.Now().Add(s.cfg.Webhooks.Timeout).After(leaseExpiresAt) {
+			s.log.Infof("Webhook lease about to expire, deliveries left for the next run; attempts:%d", attempts)
+			break
+		}
+		subscription, ok := subscriptions[delivery.SubscriptionId]
+		if !ok {
+			if subscription, err = s.webhookRepo.FindWebhookSubscriptionById(delivery.SubscriptionId); err != nil {
+				s.log.Errorf("Err finding webhook subscription; subscriptionId:%s %v", delivery.SubscriptionId, err)
+				continue
+			}
+			subscriptions[delivery.SubscriptionId] = subscription
+		}
+		if !subscription.Active {
+			s.log.Infof("Webhook subscription inactive, delivery dropped; deliveryId:%s subscriptionId:%s", delivery.ID, subscription.ID)
+			delivery.Status = common.WebhookDeliveryDropped
+			delivery.LastError = "subscription inactive"
+			delivery.UpdatedAt = now
+			if err := s.webhookRepo.SaveWebhookDelivery(delivery); err != nil {
+				s.log.Errorf("Err saving webhook delivery; deliveryId:%s %v", delivery.ID, err)
+			}
+			continue
+		}
+		event, err := s.outboxRepo.FindOutboxEventById(delivery.EventId)
+		if err != nil {
+			s.log.Errorf("Err finding outbox event; eventId:%s %v", delivery.EventId, err)
+			continue
+		}
+
+		s.deliver(&delivery, subscription, event, now)
+		if err := s.webhookRepo.SaveWebhookDelivery(delivery); err != nil {
+			s.log.Errorf("Err saving webhook delivery; deliveryId:%s %v", delivery.ID, err)
+			continue
+		}
+		attempts++
+	}
+	return response.ResonseWrapper{Data: attempts}
+}
+
+func (s *WebhookService) deliver(delivery *entity.WebhookDeliveryEntity, subscription entity.WebhookSubscriptionEntity, event entity.OutboxEventEntity, now time.Time) {
+	statusCode, err := s.sender.Send(webhook.WebhookRequest{
+		Url:       subscription.Url,
+		Secret:    subscription.Secret,
+		EventId:   event.ID,
+		EventType: string(event.EventType),
+		Body:      []byte(event.Payload),
+		Timestamp: now,
+	})
+
+	delivery.LastStatusCode = statusCode
+	delivery.UpdatedAt = now
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		s.log.Infof("Webhook delivered; deliveryId:%s eventId:%s", delivery.ID, event.ID)
+		delivery.Status = common.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	if err != nil {
+		delivery.LastError = err.Error()
+	} else {
+		delivery.LastError = fmt.Sprintf("unexpected status code %d", statusCode)
+	}
+	delivery.Attempt++
+	s.log.Errorf("Webhook delivery failed; deliveryId:%s attempt:%d %s", delivery.ID, delivery.Attempt, delivery.LastError)
+	if delivery.Attempt >= s.cfg.Webhooks.MaxAttempts {
+		delivery.Status = common.WebhookDeliveryDead
+		return
+	}
+	delivery.NextAttemptAt = now.Add(common.Backoff(s.cfg.Webhooks.RetryBackoff, s.cfg.Webhooks.MaxRetryBackoff, delivery.Attempt))
+}
+
+func (s *WebhookService) authorize(principal auth.Principal) apperror.AppError {
+	if !principal.IsAdmin() {
+		s.log.Errorf("Forbidden; caller:%s", principal.UserId)
+		return apperror.ErrForbidden
+	}
+	return apperror.AppError{}
+}
+
+func (s *WebhookService) findSubscription(principal auth.Principal, subscriptionId string) (entity.WebhookSubscriptionEntity, apperror.AppError) {
+	if appErr := s.authorize(principal); appErr.Code != 0 {
+		return entity.WebhookSubscriptionEntity{}, appErr
+	}
+	subscription, err := s.webhookRepo.FindWebhookSubscriptionById(subscriptionId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		s.log.Errorf("Webhook subscription not found; subscriptionId:%s", subscriptionId)
+		return subscription, apperror.ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		s.log.Errorf("Err finding webhook subscription; subscriptionId:%s %v", subscriptionId, err)
+		return subscription, apperror.ErrInternalServer
+	}
+	return subscription, apperror.AppError{}
+}
+
+func (s *WebhookService) validateUrl(rawUrl string) apperror.AppError {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		s.log.Errorf("Invalid webhook url; url:%s", rawUrl)
+		return apperror.ErrInvalidWebhookUrl
+	}
+	return apperror.AppError{}
+}
+
+func (s *WebhookService) joinEventTypes(eventTypes []string) (string, apperror.AppError) {
+	for _, eventType := range eventTypes {
+		if !common.EventType(eventType).IsValid() {
+			s.log.Errorf("Invalid webhook event type; eventType:%s", eventType)
+			return "", apperror.ErrInvalidWebhookEventType
+		}
+	}
+	return strings.Join(eventTypes, ","), apperror.AppError{}
+}
+
+func newWebhookSecret() string {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
@@ -0,0 +1,163 @@
+package statement
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+	"wallet-app/common"
+)
+
+const camt053Namespace = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"
+
+// Camt053StatementWriter streams an ISO 20022 BankToCustomerStatement. The document is opened in
+// WriteHeader and closed in WriteFooter, so entries never have to be held in memory.
+type Camt053StatementWriter struct {
+	out      io.Writer
+	enc      *xml.Encoder
+	currency string
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	DtTm string `xml:"DtTm"`
+}
+
+type camtBalance struct {
+	XMLName   xml.Name   `xml:"Bal"`
+	Code      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+type camtEntry struct {
+	XMLName      xml.Name   `xml:"Ntry"`
+	NtryRef      string     `xml:"NtryRef"`
+	Amount       camtAmount `xml:"Amt"`
+	CdtDbtInd    string     `xml:"CdtDbtInd"`
+	Status       string     `xml:"Sts"`
+	BookingDate  camtDate   `xml:"BookgDt"`
+	ValueDate    camtDate   `xml:"ValDt"`
+	BankTxCode   string     `xml:"BkTxCd>Prtry>Cd"`
+	AcctSvcrRef  string     `xml:"AcctSvcrRef,omitempty"`
+	AddtlNtryInf string     `xml:"AddtlNtryInf,omitempty"`
+}
+
+type camtGroupHeader struct {
+	XMLName xml.Name `xml:"GrpHdr"`
+	MsgId   string   `xml:"MsgId"`
+	CreDtTm string   `xml:"CreDtTm"`
+}
+
+type camtPeriod struct {
+	XMLName  xml.Name `xml:"FrToDt"`
+	FromDtTm string   `xml:"FrDtTm"`
+	ToDtTm   string   `xml:"ToDtTm"`
+}
+
+type camtAccount struct {
+	XMLName  xml.Name `xml:"Acct"`
+	Id       string   `xml:"Id>Othr>Id"`
+	Currency string   `xml:"Ccy"`
+}
+
+func newCamt053StatementWriter(out io.Writer) *Camt053StatementWriter {
+	return &Camt053StatementWriter{out: out, enc: xml.NewEncoder(out)}
+}
+
+func (c *Camt053StatementWriter) WriteHeader(header Header) error {
+	c.currency = header.Currency
+	if _, err := io.WriteString(c.out, xml.Header); err != nil {
+		return err
+	}
+	document := xml.StartElement{Name: xml.Name{Local: "Document"}, Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: camt053Namespace}}}
+	if err := c.enc.EncodeToken(document); err != nil {
+		return err
+	}
+	if err := c.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "BkToCstmrStmt"}}); err != nil {
+		return err
+	}
+	createdAt := camtDateTime(header.CreatedAt)
+	if err := c.enc.Encode(camtGroupHeader{MsgId: header.StatementId, CreDtTm: createdAt}); err != nil {
+		return err
+	}
+	if err := c.enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "Stmt"}}); err != nil {
+		return err
+	}
+	// Id, CreDtTm, FrToDt and Acct are direct children of Stmt, so they are encoded one by one.
+	if err := c.enc.EncodeElement(header.StatementId, xml.StartElement{Name: xml.Name{Local: "Id"}}); err != nil {
+		return err
+	}
+	if err := c.enc.EncodeElement(createdAt, xml.StartElement{Name: xml.Name{Local: "CreDtTm"}}); err != nil {
+		return err
+	}
+	if err := c.enc.Encode(camtPeriod{FromDtTm: camtDateTime(header.From), ToDtTm: camtDateTime(header.To)}); err != nil {
+		return err
+	}
+	if err := c.enc.Encode(camtAccount{Id: header.WalletId, Currency: header.Currency}); err != nil {
+		return err
+	}
+	if err := c.enc.Encode(c.balance("OPBD", header.OpeningBalance, header.From)); err != nil {
+		return err
+	}
+	if err := c.enc.Encode(c.balance("CLBD", header.ClosingBalance, header.To)); err != nil {
+		return err
+	}
+	return c.enc.Flush()
+}
+
+func (c *Camt053StatementWriter) WriteEntry(entry Entry) error {
+	bookedAt := camtDate{DtTm: camtDateTime(entry.BookedAt)}
+	err := c.enc.Encode(camtEntry{
+		NtryRef:      entry.TransactionId,
+		Amount:       camtAmount{Currency: c.currency, Value: common.FormatAmount(int64(entry.Amount), c.currency)},
+		CdtDbtInd:    creditDebitIndicator(entry.Direction),
+		Status:       "BOOK",
+		BookingDate:  bookedAt,
+		ValueDate:    bookedAt,
+		BankTxCode:   string(entry.TrxType),
+		AcctSvcrRef:  entry.GroupId,
+		AddtlNtryInf: "running balance " + common.FormatAmount(entry.RunningBalance, c.currency),
+	})
+	if err != nil {
+		return err
+	}
+	return c.enc.Flush()
+}
+
+func (c *Camt053StatementWriter) WriteFooter(footer Footer) error {
+	for _, local := range []string{"Stmt", "BkToCstmrStmt", "Document"} {
+		if err := c.enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: local}}); err != nil {
+			return err
+		}
+	}
+	return c.enc.Flush()
+}
+
+func (c *Camt053StatementWriter) balance(code string, amount int64, at time.Time) camtBalance {
+	indicator := "CRDT"
+	if amount < 0 {
+		indicator, amount = "DBIT", -amount
+	}
+	return camtBalance{
+		Code:      code,
+		Amount:    camtAmount{Currency: c.currency, Value: common.FormatAmount(amount, c.currency)},
+		CdtDbtInd: indicator,
+		Date:      camtDate{DtTm: camtDateTime(at)},
+	}
+}
+
+func creditDebitIndicator(direction common.EntryDirection) string {
+	if direction == common.EntryDirectionDebit {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+func camtDateTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05")
+}
@@ -0,0 +1,53 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+	"wallet-app/common"
+)
+
+// CsvStatementWriter writes one row per entry, framed by opening_balance and closing_balance rows.
+// Amounts are in major units.
+type CsvStatementWriter struct {
+	out      *csv.Writer
+	currency string
+	to       time.Time
+}
+
+func newCsvStatementWriter(out io.Writer) *CsvStatementWriter {
+	return &CsvStatementWriter{out: csv.NewWriter(out)}
+}
+
+func (c *CsvStatementWriter) WriteHeader(header Header) error {
+	c.currency = header.Currency
+	c.to = header.To
+	c.out.Write([]string{"booked_at", "transaction_id", "trx_type", "direction", "amount", "running_balance", "currency", "counterparty_wallet_id", "group_id"})
+	return c.writeBalance(header.From, "opening_balance", header.OpeningBalance)
+}
+
+func (c *CsvStatementWriter) WriteEntry(entry Entry) error {
+	c.out.Write([]string{
+		entry.BookedAt.UTC().Format(time.RFC3339Nano),
+		entry.TransactionId,
+		string(entry.TrxType),
+		string(entry.Direction),
+		common.FormatAmount(int64(entry.Amount), c.currency),
+		common.FormatAmount(entry.RunningBalance, c.currency),
+		c.currency,
+		entry.CounterpartyWalletId,
+		entry.GroupId,
+	})
+	c.out.Flush()
+	return c.out.Error()
+}
+
+func (c *CsvStatementWriter) WriteFooter(footer Footer) error {
+	return c.writeBalance(c.to, "closing_balance", footer.ClosingBalance)
+}
+
+func (c *CsvStatementWriter) writeBalance(at time.Time, kind string, balance int64) error {
+	c.out.Write([]string{at.UTC().Format(time.RFC3339Nano), "", kind, "", "", common.FormatAmount(balance, c.currency), c.currency, "", ""})
+	c.out.Flush()
+	return c.out.Error()
+}
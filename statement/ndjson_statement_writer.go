@@ -0,0 +1,48 @@
+package statement
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const (
+	RecordTypeHeader = "header"
+	RecordTypeEntry  = "entry"
+	RecordTypeFooter = "footer"
+)
+
+// NdjsonStatementWriter writes one JSON object per line, tagged with a RecordType. Amounts are in minor units.
+type NdjsonStatementWriter struct {
+	enc *json.Encoder
+}
+
+type ndjsonHeader struct {
+	RecordType string
+	Header
+}
+
+type ndjsonEntry struct {
+	RecordType string
+	Entry
+}
+
+type ndjsonFooter struct {
+	RecordType string
+	Footer
+}
+
+func newNdjsonStatementWriter(out io.Writer) *NdjsonStatementWriter {
+	return &NdjsonStatementWriter{enc: json.NewEncoder(out)}
+}
+
+func (n *NdjsonStatementWriter) WriteHeader(header Header) error {
+	return n.enc.Encode(ndjsonHeader{RecordType: RecordTypeHeader, Header: header})
+}
+
+func (n *NdjsonStatementWriter) WriteEntry(entry Entry) error {
+	return n.enc.Encode(ndjsonEntry{RecordType: RecordTypeEntry, Entry: entry})
+}
+
+func (n *NdjsonStatementWriter) WriteFooter(footer Footer) error {
+	return n.enc.Encode(ndjsonFooter{RecordType: RecordTypeFooter, Footer: footer})
+}
@@ -0,0 +1,92 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"time"
+	"wallet-app/common"
+)
+
+const (
+	FormatCsv     = "csv"
+	FormatNdjson  = "ndjson"
+	FormatCamt053 = "camt053" // ISO 20022 BankToCustomerStatement
+)
+
+// Header opens a statement. Balances are in minor units; the closing balance is known up front
+// because camt.053 puts it before the entries.
+type Header struct {
+	StatementId    string
+	WalletId       string
+	Currency       string
+	Exponent       int
+	From           time.Time // inclusive
+	To             time.Time // exclusive
+	OpeningBalance int64
+	ClosingBalance int64
+	CreatedAt      time.Time
+}
+
+// Entry is one booked transaction with the balance right after it.
+type Entry struct {
+	TransactionId        string
+	TrxType              common.TrxType
+	Direction            common.EntryDirection // credit raises the balance
+	Amount               uint
+	RunningBalance       int64
+	CounterpartyWalletId string
+	GroupId              string
+	BookedAt             time.Time
+}
+
+type Footer struct {
+	ClosingBalance int64
+	EntryCount     int
+	TotalCredits   uint64
+	TotalDebits    uint64
+}
+
+// IStatementWriter renders a statement as it is streamed: the header once, every entry in order, then the footer.
+type IStatementWriter interface {
+	WriteHeader(header Header) error
+	WriteEntry(entry Entry) error
+	WriteFooter(footer Footer) error
+}
+
+func IsSupportedFormat(format string) bool {
+	return format == FormatCsv || format == FormatNdjson || format == FormatCamt053
+}
+
+func NewStatementWriter(format string, out io.Writer) (IStatementWriter, error) {
+	switch format {
+	case FormatCsv:
+		return newCsvStatementWriter(out), nil
+	case FormatNdjson:
+		return newNdjsonStatementWriter(out), nil
+	case FormatCamt053:
+		return newCamt053StatementWriter(out), nil
+	}
+	return nil, fmt.Errorf("unsupported statement format %q", format)
+}
+
+func ContentType(format string) string {
+	switch format {
+	case FormatCsv:
+		return "text/csv; charset=utf-8"
+	case FormatNdjson:
+		return "application/x-ndjson"
+	case FormatCamt053:
+		return "application/xml; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+func FileExtension(format string) string {
+	switch format {
+	case FormatNdjson:
+		return "ndjson"
+	case FormatCamt053:
+		return "xml"
+	}
+	return "csv"
+}
@@ -0,0 +1,163 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"wallet-app/auth"
+	"wallet-app/cli"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTestService(t *testing.T) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, t.Name())
+
+	walletService := testdb.NewWalletService(db, &config.AppConfig{})
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD", Status: common.WalletStatusActive}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), auth.System, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	return walletService, db
+}
+
+func run(walletService service.IWalletService, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := cli.Run(walletService, args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestWalletctl_inspectJson(t *testing.T) {
+	walletService, _ := newTestService(t)
+
+	code, stdout, _ := run(walletService, "inspect", "wallet_mine", "-o", "json")
+
+	require.Equal(t, 0, code)
+	var inspection struct {
+		Wallet struct {
+			WalletId       string
+			CurrentBalance uint
+			Status         string
+		}
+		Holds []any
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &inspection))
+	assert.Equal(t, "wallet_mine", inspection.Wallet.WalletId)
+	assert.Equal(t, uint(10000), inspection.Wallet.CurrentBalance)
+	assert.Equal(t, "active", inspection.Wallet.Status)
+	assert.Empty(t, inspection.Holds)
+}
+
+func TestWalletctl_trxTable(t *testing.T) {
+	walletService, _ := newTestService(t)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), auth.System, "wallet_mine", request.TrxReq{Amount: 2500}, "").Err.Code)
+
+	code, stdout, _ := run(walletService, "trx", "-type", "withdrawal", "wallet_mine")
+
+	require.Equal(t, 0, code)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "CREATED_AT"))
+	assert.Contains(t, lines[1], "withdrawal")
+	assert.Contains(t, lines[1], "25.00")
+}
+
+func TestWalletctl_adjustIsAuditedAndReconciles(t *testing.T) {
+	walletService, db := newTestService(t)
+
+	code, stdout, stderr := run(walletService, "adjust", "wallet_mine", "-debit", "300", "-reason", "duplicate refund", "-actor", "ops:jana")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "100.00 -> 97.00")
+
+	var adjustment entity.BalanceAdjustmentEntity
+	require.NoError(t, db.First(&adjustment, "wallet_id = ?", "wallet_mine").Error)
+	assert.Equal(t, common.AdjustmentKindManual, adjustment.Kind)
+	assert.Equal(t, "ops:jana", adjustment.Actor)
+	assert.Equal(t, "duplicate refund", adjustment.Reason)
+	var trx entity.TrxEntity
+	require.NoError(t, db.First(&trx, "id = ?", adjustment.TrxId).Error)
+	assert.Equal(t, common.TrxTypeAdjustmentOut, trx.TrxType)
+
+	code, stdout, _ = run(walletService, "reconcile")
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "checked 1 wallets, 0 drifted")
+}
+
+func TestWalletctl_reconcileExitsWhenDriftIsLeft(t *testing.T) {
+	walletService, db := newTestService(t)
+	require.NoError(t, db.Model(&entity.WalletEntity{}).Where("id = ?", "wallet_mine").Update("balance", 9000).Error)
+
+	code, stdout, _ := run(walletService, "reconcile", "-o", "json")
+	assert.Equal(t, 3, code)
+	assert.Contains(t, stdout, `"Delta": -1000`)
+
+	code, _, _ = run(walletService, "reconcile", "-repair", "-reason", "ticket 3")
+	assert.Equal(t, 0, code)
+}
+
+func TestWalletctl_freezeThenUnfreeze(t *testing.T) {
+	walletService, db := newTestService(t)
+
+	code, stdout, stderr := run(walletService, "freeze", "wallet_mine", "-debits-only", "-reason", "compliance_review", "-note", "case 12")
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "active -> frozen_debits")
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_mine").Error)
+	assert.Equal(t, common.WalletStatusFrozenDebits, wallet.Status)
+
+	code, _, stderr = run(walletService, "unfreeze", "wallet_mine")
+	require.Equal(t, 0, code, stderr)
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_mine").Error)
+	assert.Equal(t, common.WalletStatusActive, wallet.Status)
+
+	var changes int64
+	require.NoError(t, db.Model(&entity.WalletStatusChangeEntity{}).Where("wallet_id = ?", "wallet_mine").Count(&changes).Error)
+	assert.Equal(t, int64(2), changes)
+}
+
+func TestWalletctl_exportToFile(t *testing.T) {
+	walletService, _ := newTestService(t)
+	out := filepath.Join(t.TempDir(), "statement.csv")
+
+	code, _, stderr := run(walletService, "export", "wallet_mine", "-from", "2000-01-01T00:00:00Z", "-out", out)
+
+	require.Equal(t, 0, code, stderr)
+	statement, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Contains(t, string(statement), "deposit")
+}
+
+func TestWalletctl_usageAndFailures(t *testing.T) {
+	walletService, _ := newTestService(t)
+
+	for _, args := range [][]string{
+		{},
+		{"explode"},
+		{"inspect"},
+		{"inspect", "wallet_mine", "-o", "yaml"},
+		{"adjust", "wallet_mine", "-reason", "x"},
+		{"adjust", "wallet_mine", "-credit", "1", "-debit", "1", "-reason", "x"},
+		{"freeze", "wallet_mine"},
+		{"trx", "wallet_mine", "-from", "yesterday"},
+		{"export", "wallet_mine"},
+	} {
+		code, _, _ := run(walletService, args...)
+		assert.Equal(t, 2, code, args)
+	}
+
+	code, _, stderr := run(walletService, "inspect", "wallet_missing")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "wallet not found")
+	code, _, _ = run(walletService, "adjust", "wallet_mine", "-debit", "20000", "-reason", "x")
+	assert.Equal(t, 1, code)
+}
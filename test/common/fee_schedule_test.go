@@ -0,0 +1,52 @@
+package common_test
+
+import (
+	"testing"
+	"wallet-app/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeSchedule_flat(t *testing.T) {
+	schedule := common.FeeSchedule{Kind: common.FeeKindFlat, FlatAmount: 50}
+	assert.NoError(t, schedule.Validate())
+	assert.Equal(t, uint(50), schedule.Calculate(1))
+	assert.Equal(t, uint(50), schedule.Calculate(1000000))
+}
+
+func TestFeeSchedule_percentageWithMinAndMax(t *testing.T) {
+	schedule := common.FeeSchedule{Kind: common.FeeKindPercentage, PercentageBps: 150, MinAmount: 100, MaxAmount: 1000}
+	assert.NoError(t, schedule.Validate())
+	assert.Equal(t, uint(100), schedule.Calculate(1000))    // 15 raised to the minimum
+	assert.Equal(t, uint(151), schedule.Calculate(10001))   // 150.015 rounded up
+	assert.Equal(t, uint(1000), schedule.Calculate(100000)) // 1500 capped at the maximum
+}
+
+func TestFeeSchedule_tiered(t *testing.T) {
+	schedule := common.FeeSchedule{Kind: common.FeeKindTiered, Tiers: []common.FeeTier{
+		{UpTo: 10000, FlatAmount: 25},
+		{UpTo: 100000, FlatAmount: 50, PercentageBps: 10},
+		{PercentageBps: 20},
+	}}
+	assert.NoError(t, schedule.Validate())
+	assert.Equal(t, uint(25), schedule.Calculate(10000))
+	assert.Equal(t, uint(61), schedule.Calculate(10001)) // 50 + 10.001 rounded up
+	assert.Equal(t, uint(400), schedule.Calculate(200000))
+}
+
+func TestFeeSchedule_invalid(t *testing.T) {
+	invalid := []common.FeeSchedule{
+		{Kind: "free"},
+		{Kind: common.FeeKindFlat},
+		{Kind: common.FeeKindPercentage},
+		{Kind: common.FeeKindPercentage, PercentageBps: 10001},
+		{Kind: common.FeeKindPercentage, PercentageBps: 100, MinAmount: 10, MaxAmount: 5},
+		{Kind: common.FeeKindTiered},
+		{Kind: common.FeeKindTiered, Tiers: []common.FeeTier{{UpTo: 100, FlatAmount: 1}}},
+		{Kind: common.FeeKindTiered, Tiers: []common.FeeTier{{UpTo: 100}, {UpTo: 50}, {}}},
+		{Kind: common.FeeKindTiered, Tiers: []common.FeeTier{{}, {UpTo: 100}}},
+	}
+	for _, schedule := range invalid {
+		assert.Error(t, schedule.Validate(), "%+v", schedule)
+	}
+}
@@ -0,0 +1,46 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+	"wallet-app/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleRule_invalid(t *testing.T) {
+	_, err := common.ParseScheduleRule("", 0)
+	assert.Error(t, err)
+	_, err = common.ParseScheduleRule("0 0 1 * *", time.Hour)
+	assert.Error(t, err)
+	_, err = common.ParseScheduleRule("", time.Second)
+	assert.Error(t, err)
+	_, err = common.ParseScheduleRule("not a cron", 0)
+	assert.Error(t, err)
+}
+
+func TestScheduleRule_cronMonthly(t *testing.T) {
+	rule, err := common.ParseScheduleRule("0 0 1 * *", 0)
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 17, 9, 30, 0, 0, time.UTC)
+
+	first := rule.First(now, nil)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), first)
+	assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), rule.NextAfter(first, first.Add(time.Minute)))
+	// occurrences missed while down are skipped
+	assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), rule.NextAfter(first, time.Date(2024, 9, 3, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestScheduleRule_interval(t *testing.T) {
+	rule, err := common.ParseScheduleRule("", 24*time.Hour)
+	require.NoError(t, err)
+	now := time.Date(2024, 5, 17, 9, 30, 0, 0, time.UTC)
+	startAt := time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, now.Add(24*time.Hour), rule.First(now, nil))
+	assert.Equal(t, startAt, rule.First(now, &startAt))
+	assert.Equal(t, startAt.Add(24*time.Hour), rule.NextAfter(startAt, startAt.Add(time.Minute)))
+	// stays on the same time of day after a gap
+	assert.Equal(t, startAt.Add(4*24*time.Hour), rule.NextAfter(startAt, startAt.Add(3*24*time.Hour+time.Hour)))
+}
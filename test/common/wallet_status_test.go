@@ -0,0 +1,31 @@
+package common_test
+
+import (
+	"testing"
+	"wallet-app/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletStatus_transitions(t *testing.T) {
+	assert.True(t, common.WalletStatusActive.CanTransitionTo(common.WalletStatusFrozenDebits))
+	assert.True(t, common.WalletStatusFrozenDebits.CanTransitionTo(common.WalletStatusFrozen))
+	assert.True(t, common.WalletStatusFrozen.CanTransitionTo(common.WalletStatusActive))
+	assert.True(t, common.WalletStatusFrozen.CanTransitionTo(common.WalletStatusClosed))
+	assert.False(t, common.WalletStatusActive.CanTransitionTo(common.WalletStatusActive))
+	assert.False(t, common.WalletStatusClosed.CanTransitionTo(common.WalletStatusActive))
+	assert.False(t, common.WalletStatusActive.CanTransitionTo("suspended"))
+}
+
+func TestWalletStatus_allowedMovements(t *testing.T) {
+	assert.True(t, common.WalletStatusActive.AllowsDebit())
+	assert.True(t, common.WalletStatusActive.AllowsCredit())
+	assert.False(t, common.WalletStatusFrozenDebits.AllowsDebit())
+	assert.True(t, common.WalletStatusFrozenDebits.AllowsCredit())
+	assert.False(t, common.WalletStatusFrozen.AllowsDebit())
+	assert.False(t, common.WalletStatusFrozen.AllowsCredit())
+	assert.False(t, common.WalletStatusClosed.AllowsDebit())
+	assert.False(t, common.WalletStatusClosed.AllowsCredit())
+	// a wallet built in memory before it was saved has no status yet
+	assert.True(t, common.WalletStatus("").AllowsDebit())
+}
@@ -0,0 +1,48 @@
+package db_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/test/testdb"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestIsRetryable_classifiesPostgresErrors(t *testing.T) {
+	deadlock := fmt.Errorf("locking wallet: %w", &pgconn.PgError{Code: "40P01"})
+	serialization := &pgconn.PgError{Code: "40001"}
+	lockNotAvailable := &pgconn.PgError{Code: "55P03"}
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+
+	assert.True(t, appdb.IsDeadlock(deadlock))
+	assert.False(t, appdb.IsDeadlock(serialization))
+	assert.True(t, appdb.IsSerializationFailure(serialization))
+	assert.False(t, appdb.IsSerializationFailure(lockNotAvailable))
+	assert.True(t, appdb.IsLockNotAvailable(lockNotAvailable))
+	assert.False(t, appdb.IsLockNotAvailable(deadlock))
+
+	for _, err := range []error{deadlock, serialization, lockNotAvailable} {
+		assert.True(t, appdb.IsRetryable(err), err)
+	}
+	for _, err := range []error{nil, uniqueViolation, gorm.ErrRecordNotFound, errors.New("connection refused")} {
+		assert.False(t, appdb.IsRetryable(err), err)
+	}
+}
+
+func TestIsUniqueViolation_onBothDrivers(t *testing.T) {
+	assert.True(t, appdb.IsUniqueViolation(fmt.Errorf("saving key: %w", &pgconn.PgError{Code: "23505"})))
+	assert.False(t, appdb.IsUniqueViolation(&pgconn.PgError{Code: "40P01"}))
+	assert.False(t, appdb.IsUniqueViolation(nil))
+
+	db := testdb.Open(t, "errors_unique_test")
+	wallet := entity.WalletEntity{ID: "wallet_1", UserId: "jana", Currency: "SGD"}
+	require.NoError(t, db.Create(&wallet).Error)
+	err := db.Create(&wallet).Error
+	assert.True(t, appdb.IsUniqueViolation(err), err)
+}
@@ -0,0 +1,205 @@
+package db_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMigrations_sameVersionsForEveryDriver(t *testing.T) {
+	postgres, err := appdb.Migrations(appdb.DriverPostgres)
+	require.NoError(t, err)
+	sqlite, err := appdb.Migrations(appdb.DriverSqlite)
+	require.NoError(t, err)
+
+	require.Equal(t, len(postgres), len(sqlite))
+	for i := range postgres {
+		assert.Equal(t, i+1, postgres[i].Version, "versions are numbered without gaps")
+		assert.Equal(t, postgres[i].Version, sqlite[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+	}
+}
+
+// The entities are no longer migrated themselves, so every column and index they declare must come
+// from a migration.
+func TestMigrations_matchEntities(t *testing.T) {
+	db := testdb.Open(t, "migrate_entities_test")
+
+	for _, model := range appdb.Entities() {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		require.True(t, db.Migrator().HasTable(model), stmt.Schema.Table)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+		for _, index := range stmt.Schema.ParseIndexes() {
+			assert.True(t, db.Migrator().HasIndex(model, index.Name), "%s %s", stmt.Schema.Table, index.Name)
+		}
+	}
+}
+
+func TestMigrations_downAndUpAgain(t *testing.T) {
+	db := testdb.Open(t, "migrate_down_test")
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana"}).Error)
+	migrations, err := appdb.Migrations(db.Dialector.Name())
+	require.NoError(t, err)
+
+	states, err := appdb.MigrationStatus(db)
+	require.NoError(t, err)
+	require.Len(t, states, len(migrations))
+	for _, state := range states {
+		assert.NotNil(t, state.AppliedAt)
+	}
+
+	reverted, err := appdb.MigrateDown(db, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, migrations[len(migrations)-1].Version, reverted[0].Version)
+	states, err = appdb.MigrationStatus(db)
+	require.NoError(t, err)
+	assert.Nil(t, states[len(states)-1].AppliedAt)
+
+	_, err = appdb.MigrateDown(db, len(migrations))
+	require.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable(&entity.WalletEntity{}))
+
+	applied, err := appdb.MigrateUp(db)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrations))
+	assert.True(t, db.Migrator().HasTable(&entity.WalletEntity{}))
+
+	applied, err = appdb.MigrateUp(db)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrations_refuseNewerSchema(t *testing.T) {
+	db := testdb.Open(t, "migrate_newer_test")
+	migrations, err := appdb.Migrations(db.Dialector.Name())
+	require.NoError(t, err)
+	latest := migrations[len(migrations)-1].Version
+	require.NoError(t, db.Create(&entity.SchemaMigrationEntity{Version: latest + 1, Name: "from_the_future", AppliedAt: time.Now()}).Error)
+
+	assert.ErrorIs(t, appdb.CheckSchemaVersion(db), appdb.ErrSchemaTooNew)
+	_, err = appdb.MigrateUp(db)
+	assert.ErrorIs(t, err, appdb.ErrSchemaTooNew)
+	_, err = appdb.MigrateDown(db, 1)
+	assert.ErrorIs(t, err, appdb.ErrSchemaTooNew)
+
+	states, err := appdb.MigrationStatus(db)
+	require.NoError(t, err)
+	assert.True(t, states[len(states)-1].Unknown)
+}
+
+// Databases created by AutoMigrate before versioned migrations adopt the baseline and keep their rows.
+func TestMigrations_adoptAutoMigratedDatabase(t *testing.T) {
+	db := testdb.Open(t, "migrate_adopt_test")
+	require.NoError(t, db.Migrator().DropTable(appdb.Entities()...))
+	require.NoError(t, db.AutoMigrate(appdb.Entities()...))
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Balance: 100}).Error)
+
+	applied, err := appdb.MigrateUp(db)
+	require.NoError(t, err)
+	assert.NotEmpty(t, applied)
+
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_mine").Error)
+	assert.Equal(t, uint(100), wallet.Balance)
+}
+
+// A CREATE INDEX CONCURRENTLY that failed leaves an INVALID index, which the migration drops and builds
+// again instead of skipping it for IF NOT EXISTS. Postgres only.
+func TestMigrations_noTransactionMigrationRebuildsInvalidIndex(t *testing.T) {
+	if os.Getenv("TEST_DATABASE_DRIVER") != appdb.DriverPostgres {
+		t.Skip("needs TEST_DATABASE_DRIVER=postgres")
+	}
+	db := testdb.Open(t, "migrate_invalid_index_test")
+	migrations, err := appdb.Migrations(db.Dialector.Name())
+	require.NoError(t, err)
+	// revert down to before 0002_wallets_user_id_index
+	_, err = appdb.MigrateDown(db, len(migrations)-1)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_1", UserId: "jana"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_2", UserId: "jana"}).Error)
+	require.Error(t, db.Exec("CREATE UNIQUE INDEX CONCURRENTLY idx_wallets_user_id ON wallets (user_id)").Error)
+
+	_, err = appdb.MigrateUp(db)
+	require.NoError(t, err)
+	var valid []bool
+	require.NoError(t, db.Raw(`SELECT i.indisvalid FROM pg_index i JOIN pg_class c ON c.oid = i.indexrelid WHERE c.relname = 'idx_wallets_user_id'`).Scan(&valid).Error)
+	assert.Equal(t, []bool{true}, valid)
+}
+
+// baselineWallet and baselineTrx are the only tables the app had before currencies, as AutoMigrate
+// created them.
+type baselineWallet struct {
+	ID        string `gorm:"primaryKey;column:id"`
+	UserId    string `gorm:"column:user_id"`
+	Balance   uint   `gorm:"column:balance"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (baselineWallet) TableName() string { return "wallets" }
+
+type baselineTrx struct {
+	ID                   string `gorm:"primaryKey;column:id"`
+	WalletId             string `gorm:"column:wallet_id"`
+	Amount               uint   `gorm:"column:amount"`
+	CounterpartyWalletId string `gorm:"column:counterparty_wallet_id"`
+	TrxType              string `gorm:"column:trx_type"`
+	GroupId              string `gorm:"column:group_id"`
+	CreatedAt            time.Time
+}
+
+func (baselineTrx) TableName() string { return "transactions" }
+
+// A database from before versioned migrations is upgraded in place: its rows become SGD wallets and
+// transactions, and money moves through them as through new ones.
+func TestMigrations_upgradeBaselineDatabase(t *testing.T) {
+	db := testdb.OpenUnmigrated(t, "migrate_baseline_test")
+	require.NoError(t, db.AutoMigrate(&baselineWallet{}, &baselineTrx{}))
+	require.NoError(t, db.Create(&baselineWallet{ID: "wallet_old", UserId: "jana", Balance: 500}).Error)
+	require.NoError(t, db.Create(&baselineTrx{ID: "trx_old", WalletId: "wallet_old", Amount: 500, TrxType: string(common.TrxTypeDeposit), GroupId: "group_old"}).Error)
+
+	require.NoError(t, appdb.Migrate(db))
+
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_old").Error)
+	assert.Equal(t, "SGD", wallet.Currency)
+	assert.Equal(t, common.WalletStatusActive, wallet.Status)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_new", UserId: "jana", Currency: "SGD", Status: common.WalletStatusActive}).Error)
+
+	walletService := testdb.NewWalletService(db, &config.AppConfig{})
+	ctx := context.Background()
+	jana := auth.Principal{UserId: "jana"}
+	res := walletService.DepositMoney(ctx, jana, "wallet_old", request.TrxReq{Amount: 250}, "")
+	require.Zero(t, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(750), res.Data.(response.TrxResponse).CurrentBalance)
+	res = walletService.TransferMoney(ctx, jana, "wallet_old", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_new"}, "")
+	require.Zero(t, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(450), res.Data.(response.TrxResponse).CurrentBalance)
+
+	res = walletService.GetTransactions(ctx, jana, "wallet_old", request.TrxHistoryReq{})
+	require.Zero(t, res.Err.Code, res.Err.Message)
+	history := res.Data.(response.TrxHistoryResponse).Transactions
+	require.Len(t, history, 3)
+	assert.Equal(t, "trx_old", history[2].TransactionId)
+	assert.Equal(t, "SGD", history[2].Currency)
+}
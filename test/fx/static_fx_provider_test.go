@@ -0,0 +1,58 @@
+package fx_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"wallet-app/fx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRatesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "fx_rates.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestStaticFxProvider_directAndInverseRates(t *testing.T) {
+	provider, err := fx.NewStaticFxProvider(writeRatesFile(t, "rates:\n  SGD/USD: \"0.8\"\n"))
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate("SGD", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "0.8", fx.FormatRate(rate))
+
+	rate, err = provider.GetRate("USD", "SGD")
+	require.NoError(t, err)
+	assert.Equal(t, "1.25", fx.FormatRate(rate))
+
+	rate, err = provider.GetRate("SGD", "SGD")
+	require.NoError(t, err)
+	assert.Equal(t, "1", fx.FormatRate(rate))
+}
+
+func TestStaticFxProvider_unknownPair(t *testing.T) {
+	provider, err := fx.NewStaticFxProvider(writeRatesFile(t, "rates:\n  SGD/USD: \"0.8\"\n"))
+	require.NoError(t, err)
+
+	_, err = provider.GetRate("SGD", "JPY")
+	assert.ErrorIs(t, err, fx.ErrRateNotFound)
+}
+
+func TestStaticFxProvider_invalidRate(t *testing.T) {
+	_, err := fx.NewStaticFxProvider(writeRatesFile(t, "rates:\n  SGD/USD: \"-1\"\n"))
+	assert.Error(t, err)
+}
+
+func TestConvert_acrossExponents(t *testing.T) {
+	rate, _ := fx.ParseRate("113.5")
+	assert.Equal(t, uint(11350), fx.Convert(10000, rate, 2, 0)) // 100.00 SGD -> 11350 JPY
+
+	rate, _ = fx.ParseRate("0.0088")
+	assert.Equal(t, uint(8800), fx.Convert(10000, rate, 0, 2)) // 10000 JPY -> 88.00 SGD
+
+	rate, _ = fx.ParseRate("0.74")
+	assert.Equal(t, uint(73), fx.Convert(99, rate, 2, 2)) // rounds down
+}
@@ -0,0 +1,219 @@
+package grpcserver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/grpcserver"
+	"wallet-app/proto/walletpb"
+	"wallet-app/test/testdb"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+const testSecret = "test-secret"
+
+// newTestClient serves the wallet service over an in-memory listener backed by a fresh sqlite db.
+func newTestClient(t *testing.T) (walletpb.WalletServiceClient, *gorm.DB) {
+	db := testdb.Open(t, t.Name())
+
+	log := logrus.New()
+	walletService := testdb.NewWalletService(db, &config.AppConfig{Idempotency: config.IdempotencyConfig{KeyTtl: time.Hour}},
+		func(deps *testdb.WalletServiceDeps) { deps.Log = log })
+	verifier, err := auth.NewJwtVerifier(&config.AuthConfig{HmacSecret: testSecret})
+	require.NoError(t, err)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpcserver.NewServer(log, verifier, walletService)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return walletpb.NewWalletServiceClient(conn), db
+}
+
+func withToken(t *testing.T, userId string, roles ...string) context.Context {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": userId, "roles": roles, "exp": time.Now().Add(time.Hour).Unix()}).
+		SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func seedWallet(t *testing.T, db *gorm.DB, id string, userId string) {
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: id, UserId: userId, Currency: "SGD", Status: "active"}).Error)
+}
+
+func TestGrpc_rejectsMissingToken(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetBalance(context.Background(), &walletpb.WalletIdRequest{WalletId: "wallet_mine"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestGrpc_depositThenGetBalance(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	ctx := withToken(t, "jana")
+
+	trx, err := client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 1500})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), trx.CurrentBalance)
+
+	wallet, err := client.GetBalance(ctx, &walletpb.WalletIdRequest{WalletId: "wallet_mine"})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1500), wallet.CurrentBalance)
+	assert.Equal(t, "SGD", wallet.Currency)
+}
+
+func TestGrpc_idempotencyKeyReplaysDeposit(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	ctx := metadata.AppendToOutgoingContext(withToken(t, "jana"), "idempotency-key", "deposit-1")
+
+	first, err := client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 700})
+	require.NoError(t, err)
+	second, err := client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 700})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.TransactionId, second.TransactionId)
+	assert.Equal(t, uint64(700), second.CurrentBalance)
+}
+
+func TestGrpc_mapsAppErrorToStatus(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	ctx := withToken(t, "jana")
+
+	_, err := client.GetBalance(ctx, &walletpb.WalletIdRequest{WalletId: "wallet_missing"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	require.Len(t, st.Details(), 1)
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.Equal(t, "wallet-app", info.Domain)
+	assert.Equal(t, "400", info.Metadata["httpCode"])
+
+	_, err = client.WithdrawMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 100})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	perTransaction := uint(50)
+	require.NoError(t, db.Create(&entity.SpendingLimitEntity{WalletId: "wallet_mine", PerTransaction: &perTransaction}).Error)
+	_, err = client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 100})
+	require.NoError(t, err)
+	_, err = client.WithdrawMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 100})
+	st = status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Equal(t, "429", st.Details()[0].(*errdetails.ErrorInfo).Metadata["httpCode"])
+}
+
+func TestGrpc_adminOnlyCalls(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetAllTrxs(withToken(t, "jana"), &walletpb.Empty{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.GetAllTrxs(withToken(t, "ops", "admin"), &walletpb.Empty{})
+	assert.NoError(t, err)
+}
+
+func TestGrpc_streamTransactionsFollowsCursor(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	ctx := withToken(t, "jana")
+	for i := 0; i < 5; i++ {
+		_, err := client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 100})
+		require.NoError(t, err)
+	}
+
+	stream, err := client.StreamTransactions(ctx, &walletpb.GetTransactionsRequest{WalletId: "wallet_mine", Limit: 2})
+	require.NoError(t, err)
+	seen := map[string]bool{}
+	for {
+		trx, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		seen[trx.TransactionId] = true
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestGrpc_exportStatementStreamsChunks(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	ctx := withToken(t, "jana")
+	_, err := client.DepositMoney(ctx, &walletpb.TrxRequest{WalletId: "wallet_mine", Amount: 1999})
+	require.NoError(t, err)
+
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	stream, err := client.ExportStatement(ctx, &walletpb.ExportStatementRequest{WalletId: "wallet_mine", From: timestamppb.New(from), To: timestamppb.New(to)})
+	require.NoError(t, err)
+
+	var contentType string
+	out := &bytes.Buffer{}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if contentType == "" {
+			contentType = chunk.ContentType
+		}
+		out.Write(chunk.Data)
+	}
+	assert.Equal(t, "text/csv; charset=utf-8", contentType)
+	assert.Contains(t, out.String(), "19.99")
+}
+
+func TestGrpc_adjustBalanceThenListAdjustments(t *testing.T) {
+	client, db := newTestClient(t)
+	seedWallet(t, db, "wallet_mine", "jana")
+	admin := withToken(t, "ops", "admin")
+
+	_, err := client.AdjustBalance(withToken(t, "jana"), &walletpb.AdjustBalanceRequest{WalletId: "wallet_mine", Direction: "credit", Amount: 250, Reason: "goodwill"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.AdjustBalance(admin, &walletpb.AdjustBalanceRequest{WalletId: "wallet_mine", Direction: "credit", Amount: 250})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	adjustment, err := client.AdjustBalance(admin, &walletpb.AdjustBalanceRequest{WalletId: "wallet_mine", Direction: "credit", Amount: 250, Reason: "goodwill"})
+	require.NoError(t, err)
+	assert.Equal(t, "credit", adjustment.Direction)
+	assert.Equal(t, uint64(0), adjustment.BalanceBefore)
+	assert.Equal(t, uint64(250), adjustment.BalanceAfter)
+	assert.Equal(t, "ops", adjustment.Actor)
+	assert.NotEmpty(t, adjustment.TransactionId)
+
+	adjustments, err := client.GetBalanceAdjustments(admin, &walletpb.WalletIdRequest{WalletId: "wallet_mine"})
+	require.NoError(t, err)
+	require.Len(t, adjustments.Adjustments, 1)
+	assert.Equal(t, adjustment.AdjustmentId, adjustments.Adjustments[0].AdjustmentId)
+	assert.Equal(t, "goodwill", adjustments.Adjustments[0].Reason)
+}
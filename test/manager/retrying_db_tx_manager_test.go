@@ -0,0 +1,92 @@
+package manager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wallet-app/config"
+	"wallet-app/manager"
+	"wallet-app/test/testdb"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errDeadlock  = &pgconn.PgError{Code: "40P01"}
+	retryConfig  = config.DatabaseConfig{MaxAttempts: 3, RetryBackoff: time.Millisecond, MaxRetryBackoff: 5 * time.Millisecond}
+	retryTimeout = 5 * time.Second
+)
+
+func TestRetryingInTx_runsAgainAfterADeadlock(t *testing.T) {
+	db := testdb.Open(t, "uow_retry")
+	txManager := manager.NewRetryingDbTxManager(logrus.New(), retryConfig, manager.NewDbTxManager(db))
+
+	attempts := 0
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		attempts++
+		require.NoError(t, saveWallet(uow, "wallet_1"))
+		if attempts < 3 {
+			return errDeadlock
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	// the failed attempts were rolled back, so the wallet was saved once
+	assert.Equal(t, []string{"wallet_1"}, walletIds(t, db))
+}
+
+func TestRetryingInTx_givesUpAfterMaxAttempts(t *testing.T) {
+	db := testdb.Open(t, "uow_retry_exhausted")
+	txManager := manager.NewRetryingDbTxManager(logrus.New(), retryConfig, manager.NewDbTxManager(db))
+
+	attempts := 0
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		attempts++
+		return errDeadlock
+	})
+	assert.ErrorIs(t, err, errDeadlock)
+	assert.Equal(t, retryConfig.MaxAttempts, attempts)
+}
+
+func TestRetryingInTx_doesNotRetryOtherErrors(t *testing.T) {
+	db := testdb.Open(t, "uow_retry_other")
+	txManager := manager.NewRetryingDbTxManager(logrus.New(), retryConfig, manager.NewDbTxManager(db))
+
+	attempts := 0
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		attempts++
+		return errFn
+	})
+	assert.ErrorIs(t, err, errFn)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryingInTx_stopsWhenTheContextIsDone(t *testing.T) {
+	db := testdb.Open(t, "uow_retry_cancelled")
+	cfg := retryConfig
+	cfg.MaxAttempts, cfg.RetryBackoff, cfg.MaxRetryBackoff = 100, time.Hour, time.Hour
+	txManager := manager.NewRetryingDbTxManager(logrus.New(), cfg, manager.NewDbTxManager(db))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	done := make(chan error)
+	go func() {
+		done <- txManager.InTx(ctx, func(uow *manager.UnitOfWork) error {
+			attempts++
+			cancel() // while the transaction is running, as a deadline would
+			return errDeadlock
+		})
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errDeadlock)
+		assert.Equal(t, 1, attempts)
+	case <-time.After(retryTimeout):
+		t.Fatal("retry kept waiting after the context was cancelled")
+	}
+}
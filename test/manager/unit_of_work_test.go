@@ -0,0 +1,125 @@
+package manager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/repo"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var errFn = errors.New("fn failed")
+
+func saveWallet(uow *manager.UnitOfWork, id string) error {
+	return uow.Tx().Create(&entity.WalletEntity{ID: id, UserId: "jana", Currency: "SGD"}).Error
+}
+
+func walletIds(t *testing.T, db *gorm.DB) []string {
+	var ids []string
+	require.NoError(t, db.Model(&entity.WalletEntity{}).Order("id").Pluck("id", &ids).Error)
+	return ids
+}
+
+func TestInTx_commitsWhenFnSucceeds(t *testing.T) {
+	db := testdb.Open(t, "uow_commit")
+	txManager := manager.NewDbTxManager(db)
+
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		return saveWallet(uow, "wallet_1")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet_1"}, walletIds(t, db))
+}
+
+func TestInTx_rollsBackWhenFnFails(t *testing.T) {
+	db := testdb.Open(t, "uow_error")
+	txManager := manager.NewDbTxManager(db)
+
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		require.NoError(t, saveWallet(uow, "wallet_1"))
+		return errFn
+	})
+	assert.ErrorIs(t, err, errFn)
+	assert.Empty(t, walletIds(t, db))
+}
+
+func TestInTx_rollsBackAndRepanics(t *testing.T) {
+	db := testdb.Open(t, "uow_panic")
+	txManager := manager.NewDbTxManager(db)
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+			require.NoError(t, saveWallet(uow, "wallet_1"))
+			panic("boom")
+		})
+	})
+	assert.Empty(t, walletIds(t, db))
+
+	// the transaction was released, so the next one can write
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		return saveWallet(uow, "wallet_2")
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet_2"}, walletIds(t, db))
+}
+
+func TestInTx_returnsCommitFailure(t *testing.T) {
+	db := testdb.Open(t, "uow_commit_failure")
+	txManager := manager.NewDbTxManager(testdb.FailingCommits(db))
+
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		return saveWallet(uow, "wallet_1")
+	})
+	assert.ErrorIs(t, err, testdb.ErrCommitFailed)
+	assert.Empty(t, walletIds(t, db))
+}
+
+func TestSavepoint_rollsBackOnlyNestedWork(t *testing.T) {
+	db := testdb.Open(t, "uow_savepoint")
+	txManager := manager.NewDbTxManager(db)
+
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		require.NoError(t, saveWallet(uow, "wallet_1"))
+		err := uow.Savepoint(func(uow *manager.UnitOfWork) error {
+			require.NoError(t, saveWallet(uow, "wallet_2"))
+			assert.ErrorIs(t, uow.Savepoint(func(uow *manager.UnitOfWork) error {
+				require.NoError(t, saveWallet(uow, "wallet_3"))
+				return errFn
+			}), errFn)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Panics(t, func() {
+			_ = uow.Savepoint(func(uow *manager.UnitOfWork) error {
+				require.NoError(t, saveWallet(uow, "wallet_4"))
+				panic("boom")
+			})
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet_1", "wallet_2"}, walletIds(t, db))
+}
+
+func TestBind_runsRepoInTheTransaction(t *testing.T) {
+	db := testdb.Open(t, "uow_bind")
+	txManager := manager.NewDbTxManager(db)
+	walletRepo := repo.NewWalletRepo(db)
+
+	err := txManager.InTx(context.Background(), func(uow *manager.UnitOfWork) error {
+		bound := manager.Bind[repo.IWalletRepo](uow, walletRepo)
+		require.NoError(t, bound.SaveWallet(context.Background(), entity.WalletEntity{ID: "wallet_1", UserId: "jana", Currency: "SGD"}))
+		_, err := bound.FindWalletById(context.Background(), "wallet_1")
+		require.NoError(t, err)
+		return errFn
+	})
+	assert.ErrorIs(t, err, errFn)
+	assert.Empty(t, walletIds(t, db))
+}
@@ -0,0 +1,104 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/metrics"
+	"wallet-app/request"
+	mock_test "wallet-app/test/mock"
+	"wallet-app/test/testdb"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var admin = auth.Principal{UserId: "ops", Roles: []string{auth.RoleAdmin}}
+
+func scrape(t *testing.T, appMetrics *metrics.Metrics) string {
+	res := httptest.NewRecorder()
+	appMetrics.Handler().ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_countMovementsAndDbTransactions(t *testing.T) {
+	db := testdb.Open(t, "metrics_test")
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	appMetrics, err := metrics.NewMetrics(db)
+	require.NoError(t, err)
+
+	walletService := metrics.NewWalletService(appMetrics, testdb.NewWalletService(db, &config.AppConfig{}, func(deps *testdb.WalletServiceDeps) {
+		deps.WalletRepo = metrics.NewWalletRepo(appMetrics, deps.WalletRepo)
+		deps.FxQuoteRepo = metrics.NewFxQuoteRepo(appMetrics, deps.FxQuoteRepo)
+		deps.DbTxManager = metrics.NewDbTxManager(appMetrics, deps.DbTxManager)
+	}))
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 300}, "").Err.Code)
+	require.NotEqual(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 5000}, "").Err.Code)
+	require.Equal(t, 0, walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "debit", Amount: 50, Reason: "ticket 1"}).Err.Code)
+
+	body := scrape(t, appMetrics)
+	assert.Contains(t, body, `wallet_transactions_total{outcome="ok",trx_type="deposit"} 1`)
+	assert.Contains(t, body, `wallet_transactions_total{outcome="ok",trx_type="withdrawal"} 1`)
+	assert.Contains(t, body, `wallet_transactions_total{outcome="rejected",trx_type="withdrawal"} 1`)
+	assert.Contains(t, body, `wallet_transactions_total{outcome="ok",trx_type="adjustment_out"} 1`)
+	assert.Contains(t, body, `wallet_transaction_amount_minor_total{currency="SGD",trx_type="deposit"} 1000`)
+	assert.Contains(t, body, `wallet_transaction_amount_minor_total{currency="SGD",trx_type="withdrawal"} 300`)
+	assert.Contains(t, body, `wallet_insufficient_funds_total{trx_type="withdrawal"} 1`)
+	assert.Contains(t, body, `wallet_db_transaction_duration_seconds_count{outcome="commit"} 3`)
+	assert.Contains(t, body, `wallet_db_transaction_duration_seconds_count{outcome="rollback"} 1`)
+	assert.Contains(t, body, `wallet_db_transaction_rollbacks_total 1`)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="wallet"}`)
+}
+
+func TestMetrics_countLockContention(t *testing.T) {
+	appMetrics, err := metrics.NewMetrics(nil)
+	require.NoError(t, err)
+	mockWalletRepo := new(mock_test.MockWalletRepo)
+	mockWalletRepo.On("FindWalletByIdWithTx", "wallet_locked", mock.Anything).Return(entity.WalletEntity{}, &pgconn.PgError{Code: "55P03"})
+	mockWalletRepo.On("FindWalletByIdWithTx", "wallet_deadlocked", mock.Anything).Return(entity.WalletEntity{}, &pgconn.PgError{Code: "40P01"})
+	mockWalletRepo.On("FindWalletByIdWithTx", "wallet_missing", mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
+	walletRepo := metrics.NewWalletRepo(appMetrics, mockWalletRepo)
+
+	_, err = walletRepo.FindWalletByIdWithTx("wallet_locked", nil)
+	assert.Error(t, err)
+	_, err = walletRepo.FindWalletByIdWithTx("wallet_deadlocked", nil)
+	assert.Error(t, err)
+	_, err = walletRepo.FindWalletByIdWithTx("wallet_missing", nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	assert.Contains(t, scrape(t, appMetrics), `wallet_lock_contention_total{table="wallets"} 2`)
+}
+
+func TestMetrics_countLockContentionOnWrites(t *testing.T) {
+	appMetrics, err := metrics.NewMetrics(nil)
+	require.NoError(t, err)
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	mockTrxRepo := new(mock_test.MockTrxRepo)
+	mockTrxRepo.On("SaveTrxsWithDbTx", mock.Anything, mock.Anything).Return(deadlock)
+	mockLedgerRepo := new(mock_test.MockLedgerRepo)
+	mockLedgerRepo.On("SaveLedgerEntriesWithTx", mock.Anything, mock.Anything).Return(deadlock)
+	mockHoldRepo := new(mock_test.MockHoldRepo)
+	mockHoldRepo.On("SaveHoldWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	assert.Error(t, metrics.NewTrxRepo(appMetrics, mockTrxRepo).SaveTrxsWithDbTx(nil, nil))
+	assert.Error(t, metrics.NewLedgerRepo(appMetrics, mockLedgerRepo).SaveLedgerEntriesWithTx(nil, nil))
+	assert.NoError(t, metrics.NewHoldRepo(appMetrics, mockHoldRepo).SaveHoldWithTx(entity.HoldEntity{}, nil))
+
+	body := scrape(t, appMetrics)
+	assert.Contains(t, body, `wallet_lock_contention_total{table="transactions"} 1`)
+	assert.Contains(t, body, `wallet_lock_contention_total{table="ledger_entries"} 1`)
+	assert.NotContains(t, body, `wallet_lock_contention_total{table="holds"}`)
+}
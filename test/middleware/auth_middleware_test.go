@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func newTestRouter(t *testing.T, cfg *config.AuthConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	verifier, err := auth.NewJwtVerifier(cfg)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET("/whoami", middleware.Authenticate(logrus.New(), verifier), func(c *gin.Context) {
+		principal := middleware.GetPrincipal(c)
+		c.JSON(http.StatusOK, gin.H{"userId": principal.UserId, "admin": principal.IsAdmin()})
+	})
+	return r
+}
+
+func call(r *gin.Engine, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func signHmac(t *testing.T, secret string, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticate_validHmacToken(t *testing.T) {
+	r := newTestRouter(t, &config.AuthConfig{HmacSecret: testSecret})
+	token := signHmac(t, testSecret, jwt.MapClaims{"sub": "jana", "roles": []string{"admin"}, "exp": time.Now().Add(time.Hour).Unix()})
+
+	rec := call(r, token)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"userId":"jana","admin":true}`, rec.Body.String())
+}
+
+func TestAuthenticate_rejectsMissingExpiredAndForgedTokens(t *testing.T) {
+	r := newTestRouter(t, &config.AuthConfig{HmacSecret: testSecret})
+
+	expired := signHmac(t, testSecret, jwt.MapClaims{"sub": "jana", "exp": time.Now().Add(-time.Minute).Unix()})
+	forged := signHmac(t, "other-secret", jwt.MapClaims{"sub": "jana", "exp": time.Now().Add(time.Hour).Unix()})
+	noSubject := signHmac(t, testSecret, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})
+	noExpiry := signHmac(t, testSecret, jwt.MapClaims{"sub": "jana"})
+
+	for name, token := range map[string]string{"missing": "", "expired": expired, "forged": forged, "noSubject": noSubject, "noExpiry": noExpiry} {
+		rec := call(r, token)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code, name)
+	}
+}
+
+func TestAuthenticate_validRsaToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwt.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey}), 0o600))
+
+	r := newTestRouter(t, &config.AuthConfig{RsaPublicKeyFile: path, Issuer: "wallet-auth"})
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"sub": "jana", "iss": "wallet-auth", "exp": time.Now().Add(time.Hour).Unix()}).SignedString(key)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, call(r, token).Code)
+
+	// an HS256 token must not be accepted by an RS256 verifier
+	assert.Equal(t, http.StatusUnauthorized, call(r, signHmac(t, testSecret, jwt.MapClaims{"sub": "jana", "iss": "wallet-auth", "exp": time.Now().Add(time.Hour).Unix()})).Code)
+}
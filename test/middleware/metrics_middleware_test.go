@@ -0,0 +1,38 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-app/metrics"
+	"wallet-app/middleware"
+	"wallet-app/route"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_recordsRequestsByRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	appMetrics, err := metrics.NewMetrics(nil)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.Use(middleware.Metrics(appMetrics))
+	r.GET("/wallets/:walletId", func(c *gin.Context) { c.Status(http.StatusOK) })
+	route.InitMetricsRoutes(r, appMetrics.Handler())
+
+	for _, path := range []string{"/wallets/wallet_1", "/wallets/wallet_2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	body, _ := io.ReadAll(res.Body)
+	assert.Contains(t, string(body), `http_request_duration_seconds_count{method="GET",route="/wallets/:walletId",status="200"} 2`)
+	assert.Contains(t, string(body), `http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, string(body), "wallet_1")
+}
@@ -0,0 +1,45 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wallet-app/config"
+	"wallet-app/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeout_setsDeadlineByRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deadlines := map[string]time.Duration{}
+	record := func(c *gin.Context) {
+		if deadline, ok := c.Request.Context().Deadline(); ok {
+			deadlines[c.Request.Method+" "+c.FullPath()] = time.Until(deadline).Round(time.Second)
+		}
+		c.Status(http.StatusOK)
+	}
+
+	r := gin.New()
+	r.Use(middleware.Timeout(config.TimeoutsConfig{
+		Default: 30 * time.Second,
+		Endpoints: []config.EndpointTimeout{
+			{Route: "POST /wallets/:walletId/transfer", Timeout: 5 * time.Second},
+			{Route: "GET /wallets/:walletId/statements", Timeout: 0},
+		},
+	}))
+	r.POST("/wallets/:walletId/transfer", record)
+	r.GET("/wallets/:walletId/balance", record)
+	r.GET("/wallets/:walletId/statements", record)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/wallets/wallet_1/transfer", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wallets/wallet_1/balance", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wallets/wallet_1/statements", nil))
+
+	assert.Equal(t, map[string]time.Duration{
+		"POST /wallets/:walletId/transfer": 5 * time.Second,
+		"GET /wallets/:walletId/balance":   30 * time.Second,
+	}, deadlines)
+}
@@ -0,0 +1,26 @@
+package mock_test
+
+import (
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockAdjustmentRepo struct {
+	mock.Mock
+}
+
+func NewMockAdjustmentRepo() *MockAdjustmentRepo {
+	return &MockAdjustmentRepo{}
+}
+
+func (m *MockAdjustmentRepo) FindBalanceAdjustmentsByWalletId(walletId string) []entity.BalanceAdjustmentEntity {
+	args := m.Called(walletId)
+	return args.Get(0).([]entity.BalanceAdjustmentEntity)
+}
+
+func (m *MockAdjustmentRepo) SaveBalanceAdjustmentWithTx(adjustment entity.BalanceAdjustmentEntity, tx *gorm.DB) error {
+	args := m.Called(adjustment, tx)
+	return args.Error(0)
+}
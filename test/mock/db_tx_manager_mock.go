@@ -1,6 +1,9 @@
 package mock_test
 
 import (
+	"context"
+	"wallet-app/manager"
+
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
@@ -9,10 +12,15 @@ type MockDbTxManager struct {
 	mock.Mock
 }
 
-func (m *MockDbTxManager) GetTx() *gorm.DB {
+func (m *MockDbTxManager) GetTx(ctx context.Context) *gorm.DB {
 	args := m.Called()
 	if tx := args.Get(0); tx != nil {
 		return tx.(*gorm.DB)
 	}
 	return nil
 }
+
+// InTx runs units of work on the db GetTx is set up to return.
+func (m *MockDbTxManager) InTx(ctx context.Context, fn func(uow *manager.UnitOfWork) error) error {
+	return manager.NewDbTxManager(m.GetTx(ctx)).InTx(ctx, fn)
+}
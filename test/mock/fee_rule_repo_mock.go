@@ -0,0 +1,47 @@
+package mock_test
+
+import (
+	"wallet-app/common"
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockFeeRuleRepo struct {
+	mock.Mock
+}
+
+func NewMockFeeRuleRepo() *MockFeeRuleRepo {
+	return &MockFeeRuleRepo{}
+}
+
+func (m *MockFeeRuleRepo) FindFeeRuleById(id string) (entity.FeeRuleEntity, error) {
+	args := m.Called(id)
+	return args.Get(0).(entity.FeeRuleEntity), args.Error(1)
+}
+
+func (m *MockFeeRuleRepo) FindFeeRules() []entity.FeeRuleEntity {
+	args := m.Called()
+	return args.Get(0).([]entity.FeeRuleEntity)
+}
+
+func (m *MockFeeRuleRepo) FindActiveFeeRules(operations []common.FeeOperation, currency string) ([]entity.FeeRuleEntity, error) {
+	args := m.Called(operations, currency)
+	return args.Get(0).([]entity.FeeRuleEntity), args.Error(1)
+}
+
+func (m *MockFeeRuleRepo) FindActiveFeeRulesWithTx(operations []common.FeeOperation, currency string, tx *gorm.DB) ([]entity.FeeRuleEntity, error) {
+	args := m.Called(operations, currency, tx)
+	return args.Get(0).([]entity.FeeRuleEntity), args.Error(1)
+}
+
+func (m *MockFeeRuleRepo) SaveFeeRule(rule entity.FeeRuleEntity) error {
+	args := m.Called(rule)
+	return args.Error(0)
+}
+
+func (m *MockFeeRuleRepo) DeleteFeeRule(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,19 @@
+package mock_test
+
+import (
+	"math/big"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockFxProvider struct {
+	mock.Mock
+}
+
+func (m *MockFxProvider) GetRate(from string, to string) (*big.Rat, error) {
+	args := m.Called(from, to)
+	if rate := args.Get(0); rate != nil {
+		return rate.(*big.Rat), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
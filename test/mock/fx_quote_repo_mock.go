@@ -0,0 +1,31 @@
+package mock_test
+
+import (
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockFxQuoteRepo struct {
+	mock.Mock
+}
+
+func NewMockFxQuoteRepo() *MockFxQuoteRepo {
+	return &MockFxQuoteRepo{}
+}
+
+func (m *MockFxQuoteRepo) FindFxQuoteByIdWithTx(quoteId string, tx *gorm.DB) (entity.FxQuoteEntity, error) {
+	args := m.Called(quoteId, tx)
+	return args.Get(0).(entity.FxQuoteEntity), args.Error(1)
+}
+
+func (m *MockFxQuoteRepo) SaveFxQuote(quote entity.FxQuoteEntity) error {
+	args := m.Called(quote)
+	return args.Error(0)
+}
+
+func (m *MockFxQuoteRepo) SaveFxQuoteWithTx(quote entity.FxQuoteEntity, tx *gorm.DB) error {
+	args := m.Called(quote, tx)
+	return args.Error(0)
+}
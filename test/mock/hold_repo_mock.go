@@ -0,0 +1,52 @@
+package mock_test
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockHoldRepo struct {
+	mock.Mock
+}
+
+func NewMockHoldRepo() *MockHoldRepo {
+	return &MockHoldRepo{}
+}
+
+func (m *MockHoldRepo) FindHoldById(holdId string) (entity.HoldEntity, error) {
+	args := m.Called(holdId)
+	return args.Get(0).(entity.HoldEntity), args.Error(1)
+}
+
+func (m *MockHoldRepo) FindHoldByIdWithTx(holdId string, tx *gorm.DB) (entity.HoldEntity, error) {
+	args := m.Called(holdId, tx)
+	return args.Get(0).(entity.HoldEntity), args.Error(1)
+}
+
+func (m *MockHoldRepo) FindHoldsByWalletId(walletId string) []entity.HoldEntity {
+	args := m.Called(walletId)
+	return args.Get(0).([]entity.HoldEntity)
+}
+
+func (m *MockHoldRepo) FindExpiredHolds(now time.Time, limit int) ([]entity.HoldEntity, error) {
+	args := m.Called(now, limit)
+	return args.Get(0).([]entity.HoldEntity), args.Error(1)
+}
+
+func (m *MockHoldRepo) SumActiveHoldAmount(walletId string, now time.Time) (uint, error) {
+	args := m.Called(walletId, now)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockHoldRepo) SumActiveHoldAmountWithTx(walletId string, now time.Time, tx *gorm.DB) (uint, error) {
+	args := m.Called(walletId, now, tx)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockHoldRepo) SaveHoldWithTx(hold entity.HoldEntity, tx *gorm.DB) error {
+	args := m.Called(hold, tx)
+	return args.Error(0)
+}
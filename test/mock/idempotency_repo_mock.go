@@ -0,0 +1,37 @@
+package mock_test
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockIdempotencyRepo struct {
+	mock.Mock
+}
+
+func NewMockIdempotencyRepo() *MockIdempotencyRepo {
+	return &MockIdempotencyRepo{}
+}
+
+func (m *MockIdempotencyRepo) FindIdempotencyKeyWithTx(userId string, walletId string, key string, tx *gorm.DB) (entity.IdempotencyKeyEntity, error) {
+	args := m.Called(userId, walletId, key, tx)
+	return args.Get(0).(entity.IdempotencyKeyEntity), args.Error(1)
+}
+
+func (m *MockIdempotencyRepo) CreateIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error {
+	args := m.Called(record, tx)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) DeleteIdempotencyKeyWithTx(record entity.IdempotencyKeyEntity, tx *gorm.DB) error {
+	args := m.Called(record, tx)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepo) DeleteExpiredIdempotencyKeys(now time.Time) error {
+	args := m.Called(now)
+	return args.Error(0)
+}
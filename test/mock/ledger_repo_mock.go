@@ -0,0 +1,36 @@
+package mock_test
+
+import (
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockLedgerRepo struct {
+	mock.Mock
+}
+
+func NewMockLedgerRepo() *MockLedgerRepo {
+	return &MockLedgerRepo{}
+}
+
+func (m *MockLedgerRepo) EnsureLedgerAccountWithTx(account entity.LedgerAccountEntity, tx *gorm.DB) (bool, error) {
+	args := m.Called(account, tx)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLedgerRepo) SaveLedgerEntriesWithTx(entries []entity.LedgerEntryEntity, tx *gorm.DB) error {
+	args := m.Called(entries, tx)
+	return args.Error(0)
+}
+
+func (m *MockLedgerRepo) FindLedgerEntriesByJournalId(journalId string) []entity.LedgerEntryEntity {
+	args := m.Called(journalId)
+	return args.Get(0).([]entity.LedgerEntryEntity)
+}
+
+func (m *MockLedgerRepo) FindLedgerAccountBalance(accountId string) (int64, error) {
+	args := m.Called(accountId)
+	return args.Get(0).(int64), args.Error(1)
+}
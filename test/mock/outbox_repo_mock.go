@@ -0,0 +1,37 @@
+package mock_test
+
+import (
+	"time"
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockOutboxRepo struct {
+	mock.Mock
+}
+
+func NewMockOutboxRepo() *MockOutboxRepo {
+	return &MockOutboxRepo{}
+}
+
+func (m *MockOutboxRepo) FindOutboxEventById(eventId string) (entity.OutboxEventEntity, error) {
+	args := m.Called(eventId)
+	return args.Get(0).(entity.OutboxEventEntity), args.Error(1)
+}
+
+func (m *MockOutboxRepo) FindUndispatchedOutboxEvents(limit int) ([]entity.OutboxEventEntity, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]entity.OutboxEventEntity), args.Error(1)
+}
+
+func (m *MockOutboxRepo) SaveOutboxEventWithTx(event entity.OutboxEventEntity, tx *gorm.DB) error {
+	args := m.Called(event, tx)
+	return args.Error(0)
+}
+
+func (m *MockOutboxRepo) MarkOutboxEventDispatchedWithTx(eventId string, now time.Time, tx *gorm.DB) (bool, error) {
+	args := m.Called(eventId, now, tx)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package mock_test
+
+import (
+	"wallet-app/entity"
+
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+type MockSpendingLimitRepo struct {
+	mock.Mock
+}
+
+func NewMockSpendingLimitRepo() *MockSpendingLimitRepo {
+	return &MockSpendingLimitRepo{}
+}
+
+func (m *MockSpendingLimitRepo) FindSpendingLimitByWalletId(walletId string) (entity.SpendingLimitEntity, error) {
+	args := m.Called(walletId)
+	return args.Get(0).(entity.SpendingLimitEntity), args.Error(1)
+}
+
+func (m *MockSpendingLimitRepo) FindSpendingLimitByWalletIdWithTx(walletId string, tx *gorm.DB) (entity.SpendingLimitEntity, error) {
+	args := m.Called(walletId, tx)
+	return args.Get(0).(entity.SpendingLimitEntity), args.Error(1)
+}
+
+func (m *MockSpendingLimitRepo) SaveSpendingLimit(limit entity.SpendingLimitEntity) error {
+	args := m.Called(limit)
+	return args.Error(0)
+}
+
+func (m *MockSpendingLimitRepo) DeleteSpendingLimit(walletId string) error {
+	args := m.Called(walletId)
+	return args.Error(0)
+}
@@ -1,7 +1,11 @@
 package mock_test
 
 import (
+	"context"
+	"time"
+	"wallet-app/common"
 	"wallet-app/entity"
+	"wallet-app/repo"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -15,17 +19,47 @@ func NewMockTrxRepo() *MockTrxRepo {
 	return &MockTrxRepo{}
 }
 
-func (m *MockTrxRepo) FindAllTrxs() []entity.TrxEntity {
+func (m *MockTrxRepo) FindAllTrxs(ctx context.Context) []entity.TrxEntity {
 	args := m.Called()
 	return args.Get(0).([]entity.TrxEntity)
 }
 
-func (m *MockTrxRepo) FindTransactionsByWalletId(walletId string) []entity.TrxEntity {
+func (m *MockTrxRepo) FindTransactionsByWalletId(ctx context.Context, walletId string) []entity.TrxEntity {
 	args := m.Called(walletId)
 	return args.Get(0).([]entity.TrxEntity)
 }
 
-func (m *MockTrxRepo) SaveTrx(trx entity.TrxEntity) error {
+func (m *MockTrxRepo) FindTransactions(ctx context.Context, query repo.TrxQuery) ([]entity.TrxEntity, error) {
+	args := m.Called(query)
+	return args.Get(0).([]entity.TrxEntity), args.Error(1)
+}
+
+func (m *MockTrxRepo) FindTrxsByGroupId(ctx context.Context, groupId string) []entity.TrxEntity {
+	args := m.Called(groupId)
+	return args.Get(0).([]entity.TrxEntity)
+}
+
+func (m *MockTrxRepo) SumAmountsByTrxTypeWithTx(walletId string, tx *gorm.DB) ([]repo.TrxTypeTotal, error) {
+	args := m.Called(walletId, tx)
+	return args.Get(0).([]repo.TrxTypeTotal), args.Error(1)
+}
+
+func (m *MockTrxRepo) SumAmountsByTrxTypeBetween(ctx context.Context, walletId string, from time.Time, to time.Time) ([]repo.TrxTypeTotal, error) {
+	args := m.Called(walletId, from, to)
+	return args.Get(0).([]repo.TrxTypeTotal), args.Error(1)
+}
+
+func (m *MockTrxRepo) FindTrxsByTypesSinceWithTx(walletId string, trxTypes []common.TrxType, since time.Time, tx *gorm.DB) ([]entity.TrxEntity, error) {
+	args := m.Called(walletId, trxTypes, since, tx)
+	return args.Get(0).([]entity.TrxEntity), args.Error(1)
+}
+
+func (m *MockTrxRepo) StreamTrxs(ctx context.Context, walletId string, from time.Time, to time.Time, fn func(trx entity.TrxEntity) error) error {
+	args := m.Called(walletId, from, to, fn)
+	return args.Error(0)
+}
+
+func (m *MockTrxRepo) SaveTrx(ctx context.Context, trx entity.TrxEntity) error {
 	args := m.Called(trx)
 	return args.Error(0)
 }
@@ -35,7 +69,7 @@ func (m *MockTrxRepo) SaveTrxWithDbTx(trx entity.TrxEntity, tx *gorm.DB) error {
 	return args.Error(0)
 }
 
-func (m *MockTrxRepo) SaveTrxs(trxs []entity.TrxEntity) error {
+func (m *MockTrxRepo) SaveTrxs(ctx context.Context, trxs []entity.TrxEntity) error {
 	args := m.Called(trxs)
 	return args.Error(0)
 }
@@ -45,7 +79,7 @@ func (m *MockTrxRepo) SaveTrxsWithDbTx(trxs []entity.TrxEntity, tx *gorm.DB) err
 	return args.Error(0)
 }
 
-func (m *MockTrxRepo) DeleteAllTrxs() error {
-	args := m.Called()
-	return args.Error(0)
+// BindTx returns the mock itself; it ignores the transaction of every call.
+func (m *MockTrxRepo) BindTx(tx *gorm.DB) repo.ITrxRepo {
+	return m
 }
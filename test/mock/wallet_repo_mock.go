@@ -1,7 +1,9 @@
 package mock_test
 
 import (
+	"context"
 	"wallet-app/entity"
+	"wallet-app/repo"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -15,12 +17,12 @@ func NewMockWalletRepo() *MockWalletRepo {
 	return &MockWalletRepo{}
 }
 
-func (w *MockWalletRepo) FindWalletById(id string) (entity.WalletEntity, error) {
+func (w *MockWalletRepo) FindWalletById(ctx context.Context, id string) (entity.WalletEntity, error) {
 	args := w.Called(id)
 	return args.Get(0).(entity.WalletEntity), args.Error(1)
 }
 
-func (w *MockWalletRepo) FindWalletsByUserId(userId string) []entity.WalletEntity {
+func (w *MockWalletRepo) FindWalletsByUserId(ctx context.Context, userId string) []entity.WalletEntity {
 	args := w.Called(userId)
 	return args.Get(0).([]entity.WalletEntity)
 }
@@ -30,12 +32,17 @@ func (w *MockWalletRepo) FindWalletByIdWithTx(id string, tx *gorm.DB) (entity.Wa
 	return args.Get(0).(entity.WalletEntity), args.Error(1)
 }
 
-func (w *MockWalletRepo) FindAllWallets() []entity.WalletEntity {
+func (w *MockWalletRepo) FindAllWallets(ctx context.Context) []entity.WalletEntity {
 	args := w.Called()
 	return args.Get(0).([]entity.WalletEntity)
 }
 
-func (w *MockWalletRepo) SaveWallet(wallet entity.WalletEntity) error {
+func (w *MockWalletRepo) FindWalletIds(ctx context.Context, afterId string, limit int) ([]string, error) {
+	args := w.Called(afterId, limit)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (w *MockWalletRepo) SaveWallet(ctx context.Context, wallet entity.WalletEntity) error {
 	args := w.Called()
 	return args.Error(0)
 }
@@ -45,7 +52,7 @@ func (w *MockWalletRepo) SaveWalletWithTx(wallet entity.WalletEntity, tx *gorm.D
 	return args.Error(0)
 }
 
-func (w *MockWalletRepo) SaveWallets(wallets []entity.WalletEntity) error {
+func (w *MockWalletRepo) SaveWallets(ctx context.Context, wallets []entity.WalletEntity) error {
 	args := w.Called(wallets)
 	return args.Error(0)
 }
@@ -55,7 +62,22 @@ func (w *MockWalletRepo) SaveWalletsWithTx(wallets []entity.WalletEntity, tx *go
 	return args.Error(0)
 }
 
-func (w *MockWalletRepo) DeleteAllWallets() error {
+func (w *MockWalletRepo) DeleteAll(ctx context.Context) error {
 	args := w.Called()
 	return args.Error(0)
 }
+
+func (w *MockWalletRepo) FindWalletStatusChanges(ctx context.Context, walletId string) []entity.WalletStatusChangeEntity {
+	args := w.Called(walletId)
+	return args.Get(0).([]entity.WalletStatusChangeEntity)
+}
+
+func (w *MockWalletRepo) SaveWalletStatusChangeWithTx(change entity.WalletStatusChangeEntity, tx *gorm.DB) error {
+	args := w.Called(change, tx)
+	return args.Error(0)
+}
+
+// BindTx returns the mock itself; it ignores the transaction of every call.
+func (w *MockWalletRepo) BindTx(tx *gorm.DB) repo.IWalletRepo {
+	return w
+}
@@ -0,0 +1,122 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"wallet-app/openapi"
+	"wallet-app/route"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRouter registers the routes the way main does; the handlers are never called.
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	route.InitRoutes(r, func(c *gin.Context) {}, nil, nil, nil, nil)
+	route.InitDocsRoutes(r)
+	return r
+}
+
+func TestSpec_coversEveryRoute(t *testing.T) {
+	spec := openapi.Build()
+
+	for _, registered := range newRouter().Routes() {
+		operation := spec.Paths[openapi.Path(registered.Path)][strings.ToLower(registered.Method)]
+		assert.NotNil(t, operation, "%s %s is missing from the OpenAPI spec", registered.Method, registered.Path)
+	}
+}
+
+func TestSpec_hasNoUnregisteredOperations(t *testing.T) {
+	registered := map[string]bool{}
+	for _, r := range newRouter().Routes() {
+		registered[strings.ToLower(r.Method)+" "+openapi.Path(r.Path)] = true
+	}
+
+	for path, item := range openapi.Build().Paths {
+		for method := range item {
+			assert.True(t, registered[method+" "+path], "%s %s is in the OpenAPI spec but not registered", method, path)
+		}
+	}
+}
+
+func TestSpec_listsEveryAppError(t *testing.T) {
+	packages, err := parser.ParseDir(token.NewFileSet(), "../../apperror", nil, 0)
+	require.NoError(t, err)
+	examples := openapi.Build().Components.Examples
+
+	found := 0
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					for _, name := range spec.(*ast.ValueSpec).Names {
+						if strings.HasPrefix(name.Name, "Err") {
+							found++
+							assert.Contains(t, examples, name.Name, "apperror.%s is missing from the OpenAPI spec", name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	assert.Positive(t, found)
+}
+
+func TestSpec_refsResolve(t *testing.T) {
+	spec := openapi.Build()
+	body, err := json.Marshal(spec)
+	require.NoError(t, err)
+
+	for _, match := range regexp.MustCompile(`"\$ref":"#/components/(schemas|examples)/(\w+)"`).FindAllStringSubmatch(string(body), -1) {
+		if match[1] == "schemas" {
+			assert.Contains(t, spec.Components.Schemas, match[2])
+		} else {
+			assert.Contains(t, spec.Components.Examples, match[2])
+		}
+	}
+}
+
+func TestSpec_describesRequestsAndResponses(t *testing.T) {
+	spec := openapi.Build()
+
+	transfer := spec.Paths["/wallets/{walletId}/transfer"]["post"]
+	require.NotNil(t, transfer)
+	assert.Equal(t, "#/components/schemas/TransferReq", transfer.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, []string{"amount", "counterpartyWalletId"}, spec.Components.Schemas["TransferReq"].Required)
+	assert.Equal(t, "#/components/schemas/TrxResponse", transfer.Responses["200"].Content["application/json"].Schema.Properties["Data"].Ref)
+	assert.Contains(t, transfer.Responses["429"].Content["application/json"].Examples, "ErrSpendingLimitExceeded")
+
+	var params []string
+	for _, param := range spec.Paths["/wallets/{walletId}/transactions"]["get"].Parameters {
+		params = append(params, param.In+":"+param.Name)
+	}
+	assert.Equal(t, []string{"path:walletId", "query:cursor", "query:limit", "query:trxType", "query:minAmount", "query:maxAmount", "query:from", "query:to", "query:counterpartyWalletId"}, params)
+
+	history := spec.Components.Schemas["TrxHistoryResponse"]
+	assert.Equal(t, "array", history.Properties["Transactions"].Type)
+	assert.Equal(t, "#/components/schemas/TransactionResponse", history.Properties["Transactions"].Items.Ref)
+}
+
+func TestGetOpenApiSpec_isPublic(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newRouter().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+}
@@ -0,0 +1,97 @@
+package repo_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newTrxRepoTestDB(t *testing.T, name string) *gorm.DB {
+	db := testdb.Open(t, name)
+	return db
+}
+
+func TestFindTransactions_PagingIsStableWithEqualCreatedAt(t *testing.T) {
+	db := newTrxRepoTestDB(t, "trx_repo_paging")
+	trxRepo := repo.NewTransactionRepo(db)
+
+	// several rows share a timestamp so the id tiebreaker decides the order
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	var trxs []entity.TrxEntity
+	for i := 0; i < 12; i++ {
+		trxs = append(trxs, entity.TrxEntity{
+			ID:        fmt.Sprintf("trx-%02d", i),
+			WalletId:  "w1",
+			Amount:    uint(100 + i),
+			Currency:  "SGD",
+			TrxType:   common.TrxTypeDeposit,
+			CreatedAt: base.Add(time.Duration(i/4) * time.Second),
+		})
+	}
+	trxs = append(trxs, entity.TrxEntity{ID: "other", WalletId: "w2", Amount: 1, Currency: "SGD", TrxType: common.TrxTypeDeposit, CreatedAt: base})
+	require.NoError(t, trxRepo.SaveTrxs(context.Background(), trxs))
+
+	var seen []string
+	query := repo.TrxQuery{WalletId: "w1", Limit: 5}
+	for {
+		page, err := trxRepo.FindTransactions(context.Background(), query)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		for _, trx := range page {
+			seen = append(seen, trx.ID)
+		}
+		last := page[len(page)-1]
+		query.After = &repo.TrxCursor{CreatedAt: last.CreatedAt, Id: last.ID}
+	}
+
+	expected := []string{
+		"trx-11", "trx-10", "trx-09", "trx-08",
+		"trx-07", "trx-06", "trx-05", "trx-04",
+		"trx-03", "trx-02", "trx-01", "trx-00",
+	}
+	assert.Equal(t, expected, seen)
+}
+
+func TestFindTransactions_Filters(t *testing.T) {
+	db := newTrxRepoTestDB(t, "trx_repo_filters")
+	trxRepo := repo.NewTransactionRepo(db)
+
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, trxRepo.SaveTrxs(context.Background(), []entity.TrxEntity{
+		{ID: "d1", WalletId: "w1", Amount: 1000, Currency: "SGD", TrxType: common.TrxTypeDeposit, CreatedAt: base},
+		{ID: "wd1", WalletId: "w1", Amount: 300, Currency: "SGD", TrxType: common.TrxTypeWithdrawal, CreatedAt: base.Add(24 * time.Hour)},
+		{ID: "to1", WalletId: "w1", Amount: 200, Currency: "SGD", CounterpartyWalletId: "w2", TrxType: common.TrxTypeTransferOut, CreatedAt: base.Add(48 * time.Hour)},
+		{ID: "to2", WalletId: "w1", Amount: 50, Currency: "SGD", CounterpartyWalletId: "w3", TrxType: common.TrxTypeTransferOut, CreatedAt: base.Add(72 * time.Hour)},
+		{ID: "ti1", WalletId: "w2", Amount: 200, Currency: "SGD", CounterpartyWalletId: "w1", TrxType: common.TrxTypeTransferIn, CreatedAt: base.Add(48 * time.Hour)},
+	}))
+
+	ids := func(query repo.TrxQuery) []string {
+		trxs, err := trxRepo.FindTransactions(context.Background(), query)
+		require.NoError(t, err)
+		var result []string
+		for _, trx := range trxs {
+			result = append(result, trx.ID)
+		}
+		return result
+	}
+	uintPtr := func(v uint) *uint { return &v }
+	timePtr := func(v time.Time) *time.Time { return &v }
+
+	assert.Equal(t, []string{"to2", "to1", "wd1", "d1"}, ids(repo.TrxQuery{WalletId: "w1"}))
+	assert.Equal(t, []string{"to2", "to1", "d1"}, ids(repo.TrxQuery{WalletId: "w1", TrxTypes: []common.TrxType{common.TrxTypeDeposit, common.TrxTypeTransferOut}}))
+	assert.Equal(t, []string{"to1", "wd1"}, ids(repo.TrxQuery{WalletId: "w1", MinAmount: uintPtr(200), MaxAmount: uintPtr(300)}))
+	assert.Equal(t, []string{"to1", "wd1"}, ids(repo.TrxQuery{WalletId: "w1", From: timePtr(base.Add(24 * time.Hour)), To: timePtr(base.Add(72 * time.Hour))}))
+	assert.Equal(t, []string{"to1"}, ids(repo.TrxQuery{WalletId: "w1", CounterpartyWalletId: "w2"}))
+	assert.Equal(t, []string{"ti1"}, ids(repo.TrxQuery{WalletId: "w2"}))
+}
@@ -0,0 +1,44 @@
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDeleteAll_emptiesEveryTableButSchemaMigrations(t *testing.T) {
+	db := testdb.Open(t, "wallet_repo_delete_all")
+	rows := []any{
+		&entity.WalletEntity{ID: "w1"}, &entity.WalletStatusChangeEntity{ID: "c1"}, &entity.TrxEntity{ID: "t1"},
+		&entity.LedgerAccountEntity{ID: "a1"}, &entity.LedgerEntryEntity{ID: "e1"}, &entity.HoldEntity{ID: "h1"},
+		&entity.IdempotencyKeyEntity{UserId: "u1", WalletId: "w1", Key: "k1"}, &entity.FxQuoteEntity{ID: "q1"},
+		&entity.BalanceAdjustmentEntity{ID: "b1"}, &entity.SpendingLimitEntity{WalletId: "w1"}, &entity.FeeRuleEntity{ID: "f1"},
+		&entity.OutboxEventEntity{ID: "o1"}, &entity.ScheduleEntity{ID: "s1"}, &entity.ScheduleRunEntity{ID: "r1"},
+		&entity.LeaseEntity{Name: "l1"}, &entity.WebhookSubscriptionEntity{ID: "ws1"}, &entity.WebhookDeliveryEntity{ID: "wd1"},
+	}
+	for _, row := range rows {
+		require.NoError(t, db.Create(row).Error)
+	}
+	tables, err := db.Migrator().GetTables()
+	require.NoError(t, err)
+
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		return repo.NewWalletRepo(db).BindTx(tx).DeleteAll(context.Background())
+	}))
+
+	for _, table := range tables {
+		var count int64
+		require.NoError(t, db.Table(table).Count(&count).Error)
+		if table == (entity.SchemaMigrationEntity{}).TableName() {
+			assert.NotZero(t, count)
+			continue
+		}
+		assert.Zero(t, count, table)
+	}
+}
@@ -0,0 +1,35 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+	"wallet-app/repo"
+	"wallet-app/scheduler"
+	"wallet-app/test/testdb"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeasedJob_onlyOneInstanceRuns(t *testing.T) {
+	db := testdb.Open(t, "leased_job_test")
+	leaseRepo := repo.NewLeaseRepo(db)
+
+	runs := map[string]int{}
+	newJob := func(holder string) *scheduler.LeasedJob {
+		return scheduler.NewLeasedJob(logrus.New(), leaseRepo, "schedules", holder, time.Second, time.Minute, func(now time.Time) { runs[holder]++ })
+	}
+	first, second := newJob("instance-1"), newJob("instance-2")
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, first.Tick(now))
+	assert.False(t, second.Tick(now.Add(time.Second)))
+	// the holder renews its own lease
+	assert.True(t, first.Tick(now.Add(30*time.Second)))
+	assert.False(t, second.Tick(now.Add(80*time.Second)))
+	// once the holder stops renewing, the lease expires and another instance takes over
+	assert.True(t, second.Tick(now.Add(91*time.Second)))
+	assert.False(t, first.Tick(now.Add(92*time.Second)))
+
+	assert.Equal(t, map[string]int{"instance-1": 2, "instance-2": 1}, runs)
+}
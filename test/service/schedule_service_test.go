@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newScheduleTestService(t *testing.T) (service.IScheduleService, *gorm.DB) {
+	return newScheduleTestServiceWrapping(t, func(walletService service.IWalletService) service.IWalletService { return walletService })
+}
+
+// newScheduleTestServiceWrapping runs the schedules through wrap of the wallet service.
+func newScheduleTestServiceWrapping(t *testing.T, wrap func(service.IWalletService) service.IWalletService) (service.IScheduleService, *gorm.DB) {
+	db := testdb.Open(t, "schedule_test")
+
+	cfg := &config.AppConfig{
+		Idempotency: config.IdempotencyConfig{KeyTtl: 24 * time.Hour},
+		Schedules:   config.SchedulesConfig{MaxAttempts: 3, RetryBackoff: time.Minute, MaxRetryBackoff: 10 * time.Minute},
+	}
+	deps := testdb.Deps(db, cfg)
+	scheduleService := service.NewScheduleService(logrus.New(), cfg, wrap(deps.NewWalletService()), deps.WalletRepo, repo.NewScheduleRepo(db), &mapper.AppMapper{}, deps.DbTxManager)
+
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Balance: 10000, Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_savings", UserId: "jana", Currency: "SGD"}).Error)
+	return scheduleService, db
+}
+
+// makeDue moves the schedule's current occurrence to at, as if it had been created earlier.
+func makeDue(t *testing.T, db *gorm.DB, scheduleId string, at time.Time) {
+	require.NoError(t, db.Model(&entity.ScheduleEntity{}).Where("id = ?", scheduleId).
+		Updates(map[string]interface{}{"next_run_at": at, "attempt_at": at}).Error)
+}
+
+func findSchedule(t *testing.T, db *gorm.DB, scheduleId string) entity.ScheduleEntity {
+	var schedule entity.ScheduleEntity
+	require.NoError(t, db.First(&schedule, "id = ?", scheduleId).Error)
+	return schedule
+}
+
+func TestCreateSchedule_validation(t *testing.T) {
+	scheduleService, db := newScheduleTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_usd", UserId: "jana", Currency: "USD"}).Error)
+	jana := auth.Principal{UserId: "jana"}
+
+	assert.Equal(t, apperror.ErrInvalidScheduleRule, scheduleService.CreateSchedule(jana, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_savings", Cron: "every day"}).Err)
+	assert.Equal(t, apperror.ErrInvalidScheduleRule, scheduleService.CreateSchedule(jana, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_savings", Cron: "0 0 1 * *", Interval: "24h"}).Err)
+	assert.Equal(t, apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet, scheduleService.CreateSchedule(jana, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_mine", Interval: "24h"}).Err)
+	assert.Equal(t, apperror.ErrScheduleCrossCurrency, scheduleService.CreateSchedule(jana, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_usd", Interval: "24h"}).Err)
+	assert.Equal(t, apperror.ErrForbidden, scheduleService.CreateSchedule(auth.Principal{UserId: "rathan"}, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_savings", Interval: "24h"}).Err)
+
+	res := scheduleService.CreateSchedule(jana, "wallet_mine", request.CreateScheduleReq{Amount: 5000, CounterpartyWalletId: "wallet_savings", Cron: "0 0 1 * *"})
+	require.Equal(t, 0, res.Err.Code)
+	created := res.Data.(response.ScheduleResponse)
+	assert.Equal(t, common.ScheduleStatusActive, created.Status)
+	assert.Equal(t, 1, created.NextRunAt.Day())
+}
+
+func TestRunDueSchedules_transfersAndAdvances(t *testing.T) {
+	scheduleService, db := newScheduleTestService(t)
+	created := scheduleService.CreateSchedule(admin, "wallet_mine", request.CreateScheduleReq{Amount: 2500, CounterpartyWalletId: "wallet_savings", Interval: "24h"}).Data.(response.ScheduleResponse)
+	now := time.Now().UTC()
+	occurrence := now.Add(-time.Minute)
+	makeDue(t, db, created.ScheduleId, occurrence)
+
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	assert.Equal(t, 0, scheduleService.RunDueSchedules(now).Data)
+
+	assert.Equal(t, uint(7500), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(2500), walletBalance(t, db, "wallet_savings"))
+	schedule := findSchedule(t, db, created.ScheduleId)
+	assert.Equal(t, common.ScheduleRunSucceeded, schedule.LastOutcome)
+	assert.True(t, schedule.NextRunAt.After(now))
+
+	runs := scheduleService.GetScheduleRuns(admin, "wallet_mine", created.ScheduleId).Data.([]response.ScheduleRunResponse)
+	require.Len(t, runs, 1)
+	assert.Equal(t, common.ScheduleRunSucceeded, runs[0].Outcome)
+	assert.NotEmpty(t, runs[0].TransactionId)
+
+	// the same occurrence run again, e.g. by an instance that lost its lease, does not move money twice
+	makeDue(t, db, created.ScheduleId, occurrence)
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	assert.Equal(t, uint(7500), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestRunDueSchedules_retriesInsufficientFundsWithBackoff(t *testing.T) {
+	scheduleService, db := newScheduleTestService(t)
+	created := scheduleService.CreateSchedule(admin, "wallet_mine", request.CreateScheduleReq{Amount: 20000, CounterpartyWalletId: "wallet_savings", Interval: "24h"}).Data.(response.ScheduleResponse)
+	now := time.Now().UTC()
+	occurrence := now.Add(-time.Minute)
+	makeDue(t, db, created.ScheduleId, occurrence)
+
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	schedule := findSchedule(t, db, created.ScheduleId)
+	assert.Equal(t, common.ScheduleRunInsufficientFunds, schedule.LastOutcome)
+	assert.Equal(t, 1, schedule.Attempt)
+	assert.WithinDuration(t, now.Add(time.Minute), schedule.AttemptAt, time.Second)
+	assert.WithinDuration(t, occurrence, schedule.NextRunAt, time.Second)
+
+	assert.Equal(t, 0, scheduleService.RunDueSchedules(now.Add(30*time.Second)).Data)
+	now = now.Add(61 * time.Second)
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	schedule = findSchedule(t, db, created.ScheduleId)
+	assert.Equal(t, 2, schedule.Attempt)
+	assert.WithinDuration(t, now.Add(2*time.Minute), schedule.AttemptAt, time.Second)
+
+	// the last attempt gives up on this occurrence and moves to the next one
+	now = now.Add(3 * time.Minute)
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	schedule = findSchedule(t, db, created.ScheduleId)
+	assert.Equal(t, common.ScheduleRunFailed, schedule.LastOutcome)
+	assert.Equal(t, 0, schedule.Attempt)
+	assert.WithinDuration(t, occurrence.Add(24*time.Hour), schedule.NextRunAt, time.Second)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_mine"))
+	assert.Len(t, scheduleService.GetScheduleRuns(admin, "wallet_mine", created.ScheduleId).Data, 3)
+}
+
+// busyWalletService fails every transfer as a wallet the db kept busy past its retries would.
+type busyWalletService struct {
+	service.IWalletService
+}
+
+func (s busyWalletService) TransferMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TransferReq, idempotencyKey string) response.ResonseWrapper {
+	return response.ResonseWrapper{Err: apperror.ErrWalletBusy}
+}
+
+func TestRunDueSchedules_retriesServerErrorsAsFailedRetrying(t *testing.T) {
+	scheduleService, db := newScheduleTestServiceWrapping(t, func(walletService service.IWalletService) service.IWalletService {
+		return busyWalletService{IWalletService: walletService}
+	})
+	created := scheduleService.CreateSchedule(admin, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_savings", Interval: "24h"}).Data.(response.ScheduleResponse)
+	now := time.Now().UTC()
+	makeDue(t, db, created.ScheduleId, now.Add(-time.Minute))
+
+	assert.Equal(t, 1, scheduleService.RunDueSchedules(now).Data)
+	schedule := findSchedule(t, db, created.ScheduleId)
+	assert.Equal(t, common.ScheduleRunFailedRetrying, schedule.LastOutcome)
+	assert.Equal(t, 1, schedule.Attempt)
+	assert.WithinDuration(t, now.Add(time.Minute), schedule.AttemptAt, time.Second)
+	runs := scheduleService.GetScheduleRuns(admin, "wallet_mine", created.ScheduleId).Data.([]response.ScheduleRunResponse)
+	require.Len(t, runs, 1)
+	assert.Equal(t, common.ScheduleRunFailedRetrying, runs[0].Outcome)
+	assert.Equal(t, apperror.ErrWalletBusy.Message, runs[0].Error)
+}
+
+func TestUpdateSchedule_pauseAndCancel(t *testing.T) {
+	scheduleService, db := newScheduleTestService(t)
+	created := scheduleService.CreateSchedule(admin, "wallet_mine", request.CreateScheduleReq{Amount: 100, CounterpartyWalletId: "wallet_savings", Interval: "1h"}).Data.(response.ScheduleResponse)
+	now := time.Now().UTC()
+	makeDue(t, db, created.ScheduleId, now.Add(-time.Minute))
+
+	paused := scheduleService.UpdateSchedule(admin, "wallet_mine", created.ScheduleId, request.UpdateScheduleReq{Status: string(common.ScheduleStatusPaused)})
+	require.Equal(t, 0, paused.Err.Code)
+	assert.Equal(t, 0, scheduleService.RunDueSchedules(now).Data)
+
+	amount := uint(300)
+	resumed := scheduleService.UpdateSchedule(admin, "wallet_mine", created.ScheduleId, request.UpdateScheduleReq{Amount: &amount, Status: string(common.ScheduleStatusActive)})
+	require.Equal(t, 0, resumed.Err.Code)
+	assert.Equal(t, uint(300), resumed.Data.(response.ScheduleResponse).Amount)
+	assert.True(t, resumed.Data.(response.ScheduleResponse).NextRunAt.After(now))
+
+	require.Equal(t, 0, scheduleService.CancelSchedule(admin, "wallet_mine", created.ScheduleId).Err.Code)
+	assert.Equal(t, apperror.ErrScheduleCancelled, scheduleService.UpdateSchedule(admin, "wallet_mine", created.ScheduleId, request.UpdateScheduleReq{Amount: &amount}).Err)
+	assert.Equal(t, apperror.ErrScheduleNotFound, scheduleService.GetSchedule(admin, "wallet_savings", created.ScheduleId).Err)
+}
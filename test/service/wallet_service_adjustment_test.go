@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustBalance_creditAndDebit(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+
+	credit := walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "credit", Amount: 500, Reason: "goodwill, ticket 7"})
+	require.Equal(t, 0, credit.Err.Code)
+	credited := credit.Data.(response.BalanceAdjustmentResponse)
+	assert.Equal(t, common.AdjustmentKindManual, credited.Kind)
+	assert.Equal(t, uint(10000), credited.BalanceBefore)
+	assert.Equal(t, uint(10500), credited.BalanceAfter)
+	assert.Equal(t, admin.UserId, credited.Actor)
+
+	debit := walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "debit", Amount: 200, Reason: "duplicate refund"})
+	require.Equal(t, 0, debit.Err.Code)
+	assert.Equal(t, uint(10300), walletBalance(t, db, "wallet_mine"))
+
+	var trx entity.TrxEntity
+	require.NoError(t, db.First(&trx, "id = ?", credited.TransactionId).Error)
+	assert.Equal(t, common.TrxTypeAdjustmentIn, trx.TrxType)
+	assert.Equal(t, credited.AdjustmentId, trx.GroupId)
+	var debitTrx entity.TrxEntity
+	require.NoError(t, db.First(&debitTrx, "id = ?", debit.Data.(response.BalanceAdjustmentResponse).TransactionId).Error)
+	assert.Equal(t, common.TrxTypeAdjustmentOut, debitTrx.TrxType)
+
+	var events int64
+	require.NoError(t, db.Model(&entity.OutboxEventEntity{}).Where("event_type = ?", common.EventTypeAdjustment).Count(&events).Error)
+	assert.Equal(t, int64(2), events)
+
+	adjustments := walletService.GetBalanceAdjustments(context.Background(), admin, "wallet_mine").Data.([]response.BalanceAdjustmentResponse)
+	assert.Len(t, adjustments, 2)
+
+	// the transaction rows keep reconciliation from undoing the adjustments
+	assert.Empty(t, walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{}).Data.(response.ReconciliationReport).Drifts)
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertLedgerBalances(t, db)
+}
+
+func TestAdjustBalance_rejected(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_closed", Currency: "SGD", Status: common.WalletStatusClosed}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+	valid := request.BalanceAdjustmentReq{Direction: "credit", Amount: 100, Reason: "ticket 9"}
+
+	assert.Equal(t, apperror.ErrForbidden, walletService.AdjustBalance(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", valid).Err)
+	assert.Equal(t, apperror.ErrForbidden, walletService.GetBalanceAdjustments(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine").Err)
+	assert.Equal(t, apperror.ErrInvalidBalanceAdjustment, walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "sideways", Amount: 100, Reason: "x"}).Err)
+	assert.Equal(t, apperror.ErrInvalidBalanceAdjustment, walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "credit", Amount: 100}).Err)
+	assert.Equal(t, apperror.ErrInsufficientAmount, walletService.AdjustBalance(context.Background(), admin, "wallet_mine", request.BalanceAdjustmentReq{Direction: "debit", Amount: 1001, Reason: "x"}).Err)
+	assert.Equal(t, apperror.ErrWalletClosed, walletService.AdjustBalance(context.Background(), admin, "wallet_closed", valid).Err)
+	assert.Equal(t, apperror.ErrWalletNotFound, walletService.AdjustBalance(context.Background(), admin, "wallet_missing", valid).Err)
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_mine"))
+}
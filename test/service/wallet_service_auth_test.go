@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	mock_test "wallet-app/test/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newAuthTestService(mockWalletRepo *mock_test.MockWalletRepo, mockTrxRepo *mock_test.MockTrxRepo, mockLedgerRepo *mock_test.MockLedgerRepo, mockTxManager *mock_test.MockDbTxManager) service.IWalletService {
+	mocks := newWalletServiceMocks()
+	mocks.walletRepo, mocks.trxRepo, mocks.ledgerRepo, mocks.txManager = mockWalletRepo, mockTrxRepo, mockLedgerRepo, mockTxManager
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", mock.Anything, mock.Anything, mock.Anything).Return(uint(0), nil).Maybe()
+	mocks.outboxRepo.On("SaveOutboxEventWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return mocks.walletService()
+}
+
+func TestWithdrawMoney_forbiddenForOtherUser(t *testing.T) {
+	mockWalletRepo := new(mock_test.MockWalletRepo)
+	mockTrxRepo := new(mock_test.MockTrxRepo)
+	mockLedgerRepo := new(mock_test.MockLedgerRepo)
+	mockTxManager := new(mock_test.MockDbTxManager)
+
+	walletId := "wallet_jana"
+	wallet := entity.WalletEntity{ID: walletId, UserId: "jana", Balance: 20000, Currency: "SGD"}
+
+	mockTxManager.On("GetTx").Return(getTestDB(t))
+	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+
+	service := newAuthTestService(mockWalletRepo, mockTrxRepo, mockLedgerRepo, mockTxManager)
+
+	result := service.WithdrawMoney(context.Background(), auth.Principal{UserId: "rathan"}, walletId, request.TrxReq{Amount: 1000}, "")
+
+	assert.Equal(t, 403, result.Err.Code)
+	assert.Equal(t, apperror.ErrForbidden.Message, result.Err.Message)
+	mockWalletRepo.AssertExpectations(t)
+	mockTrxRepo.AssertExpectations(t)
+	mockLedgerRepo.AssertExpectations(t)
+}
+
+func TestWithdrawMoney_allowedForOwnerAndAdmin(t *testing.T) {
+	for _, principal := range []auth.Principal{{UserId: "jana"}, {UserId: "ops", Roles: []string{auth.RoleAdmin}}} {
+		mockWalletRepo := new(mock_test.MockWalletRepo)
+		mockTrxRepo := new(mock_test.MockTrxRepo)
+		mockLedgerRepo := new(mock_test.MockLedgerRepo)
+		mockTxManager := new(mock_test.MockDbTxManager)
+
+		walletId := "wallet_jana"
+		wallet := entity.WalletEntity{ID: walletId, UserId: "jana", Balance: 20000, Currency: "SGD"}
+
+		mockTxManager.On("GetTx").Return(getTestDB(t))
+		mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+		mockWalletRepo.On("SaveWalletWithTx", mock.Anything, mock.Anything).Return(nil)
+		mockLedgerRepo.On("EnsureLedgerAccountWithTx", mock.Anything, mock.Anything).Return(false, nil)
+		mockLedgerRepo.On("SaveLedgerEntriesWithTx", mock.Anything, mock.Anything).Return(nil)
+		mockTrxRepo.On("SaveTrxWithDbTx", mock.Anything, mock.Anything).Return(nil)
+
+		service := newAuthTestService(mockWalletRepo, mockTrxRepo, mockLedgerRepo, mockTxManager)
+
+		result := service.WithdrawMoney(context.Background(), principal, walletId, request.TrxReq{Amount: 1000}, "")
+
+		assert.Equal(t, 0, result.Err.Code, principal.UserId)
+		assert.Equal(t, uint(19000), result.Data.(response.TrxResponse).CurrentBalance)
+	}
+}
+
+func TestCreateWallet_forAnotherUserRequiresAdmin(t *testing.T) {
+	mockWalletRepo := new(mock_test.MockWalletRepo)
+	mockWalletRepo.On("SaveWallet", mock.Anything).Return(nil)
+
+	service := newAuthTestService(mockWalletRepo, new(mock_test.MockTrxRepo), new(mock_test.MockLedgerRepo), new(mock_test.MockDbTxManager))
+	req := request.CreateWalletReq{UserId: "jana", Currency: "SGD"}
+
+	forbidden := service.CreateWallet(context.Background(), auth.Principal{UserId: "rathan"}, req)
+	assert.Equal(t, 403, forbidden.Err.Code)
+
+	created := service.CreateWallet(context.Background(), admin, req)
+	assert.Equal(t, 0, created.Err.Code)
+	assert.Equal(t, "jana", created.Data.(response.WalletResponse).UserId)
+}
+
+func TestGetWalletsByUserId_forbiddenForOtherUser(t *testing.T) {
+	mockWalletRepo := new(mock_test.MockWalletRepo)
+
+	service := newAuthTestService(mockWalletRepo, new(mock_test.MockTrxRepo), new(mock_test.MockLedgerRepo), new(mock_test.MockDbTxManager))
+
+	result := service.GetWalletsByUserId(context.Background(), auth.Principal{UserId: "rathan"}, "jana")
+
+	assert.Equal(t, 403, result.Err.Code)
+	mockWalletRepo.AssertExpectations(t)
+}
+
+func TestDeleteAll_requiresAdmin(t *testing.T) {
+	service := newAuthTestService(new(mock_test.MockWalletRepo), new(mock_test.MockTrxRepo), new(mock_test.MockLedgerRepo), new(mock_test.MockDbTxManager))
+
+	result := service.DeleteAll(context.Background(), auth.Principal{UserId: "jana"})
+
+	assert.Equal(t, 403, result.Err.Code)
+}
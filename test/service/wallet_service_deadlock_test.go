@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/common"
+	"wallet-app/config"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var deadlockRetries = config.DatabaseConfig{MaxAttempts: 10, RetryBackoff: 5 * time.Millisecond, MaxRetryBackoff: 100 * time.Millisecond}
+
+// deadlockingWalletRepo fails every wallet lock as the victim of a deadlock.
+type deadlockingWalletRepo struct {
+	repo.IWalletRepo
+	locks int
+}
+
+func (r *deadlockingWalletRepo) FindWalletByIdWithTx(walletId string, tx *gorm.DB) (entity.WalletEntity, error) {
+	r.locks++
+	return entity.WalletEntity{}, &pgconn.PgError{Code: "40P01"}
+}
+
+func newDeadlockTestService(db *gorm.DB, walletRepo repo.IWalletRepo) service.IWalletService {
+	cfg := &config.AppConfig{Fees: config.FeesConfig{RevenueWallets: map[string]string{"sgd": "wallet_house"}}}
+	return testdb.NewWalletService(db, cfg, func(deps *testdb.WalletServiceDeps) {
+		deps.WalletRepo = walletRepo
+		deps.DbTxManager = manager.NewRetryingDbTxManager(logrus.New(), deadlockRetries, deps.DbTxManager)
+	})
+}
+
+// Opposing transfers lock the same two wallets from either end, and the house revenue wallet their
+// fees go to. They must neither deadlock into a 5xx nor lose or create money.
+func TestTransferMoney_concurrentOpposingTransfers(t *testing.T) {
+	db, err := appdb.InitDb(&config.DatabaseConfig{Driver: appdb.DriverSqlite, Path: filepath.Join(t.TempDir(), "wallet.db")})
+	require.NoError(t, err)
+	runOpposingTransfers(t, db)
+}
+
+// On Postgres the transfers take row locks (FOR UPDATE), so this exercises the lock order. It runs
+// with TEST_DATABASE_DRIVER=postgres and is skipped otherwise.
+func TestTransferMoney_concurrentOpposingTransfersOnPostgres(t *testing.T) {
+	if os.Getenv("TEST_DATABASE_DRIVER") != appdb.DriverPostgres {
+		t.Skip("needs TEST_DATABASE_DRIVER=postgres")
+	}
+	runOpposingTransfers(t, testdb.Open(t, "opposing_transfers"))
+}
+
+func runOpposingTransfers(t *testing.T, db *gorm.DB) {
+	const opening, fee = 100000, 7
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_a", UserId: "jana", Currency: "SGD", Balance: opening}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_b", UserId: "omar", Currency: "SGD", Balance: opening}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_house", UserId: "house", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.FeeRuleEntity{ID: "fee_rule_transfer", Operation: common.FeeOperationTransferOut, Currency: "SGD", Kind: common.FeeKindFlat, FlatAmount: fee, Active: true}).Error)
+	walletService := newDeadlockTestService(db, repo.NewWalletRepo(db))
+
+	const workers, transfersEach = 8, 10
+	type result struct {
+		from string
+		res  response.ResonseWrapper
+	}
+	results := make(chan result, workers*transfersEach)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		from, to := "wallet_a", "wallet_b"
+		if i%2 == 1 {
+			from, to = to, from
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < transfersEach; j++ {
+				res := walletService.TransferMoney(context.Background(), admin, from, request.TransferReq{Amount: 100 + uint(j), CounterpartyWalletId: to}, "")
+				results <- result{from: from, res: res}
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	sent := map[string]int64{}
+	for r := range results {
+		assert.Less(t, r.res.Err.Code, 500, "transfer from %s failed: %s", r.from, r.res.Err.Message)
+		require.Zero(t, r.res.Err.Code, "transfer from %s failed: %s", r.from, r.res.Err.Message)
+		trx := r.res.Data.(response.TrxResponse)
+		assert.Equal(t, uint(fee), trx.Fee)
+		sent[r.from] += int64(trx.Amount)
+	}
+	const transfers = workers * transfersEach
+	balanceA, balanceB, balanceHouse := walletBalance(t, db, "wallet_a"), walletBalance(t, db, "wallet_b"), walletBalance(t, db, "wallet_house")
+	assert.Equal(t, uint(2*opening), balanceA+balanceB+balanceHouse, "money is conserved")
+	assert.Equal(t, uint(transfers*fee), balanceHouse)
+	assert.Equal(t, int64(opening)-sent["wallet_a"]-transfers/2*fee+sent["wallet_b"], int64(balanceA))
+	var trxs int64
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Count(&trxs).Error)
+	assert.Equal(t, int64(4*transfers), trxs, "transfer_out, transfer_in, fee and fee_in rows per transfer")
+}
+
+func TestTransferMoney_busyWhenRetriesAreExhausted(t *testing.T) {
+	db := testdb.Open(t, "retries_exhausted")
+	walletRepo := &deadlockingWalletRepo{IWalletRepo: repo.NewWalletRepo(db)}
+	walletService := newDeadlockTestService(db, walletRepo)
+
+	res := walletService.TransferMoney(context.Background(), admin, "wallet_a", request.TransferReq{Amount: 100, CounterpartyWalletId: "wallet_b"}, "")
+	assert.Equal(t, apperror.ErrWalletBusy, res.Err)
+	assert.Equal(t, deadlockRetries.MaxAttempts, walletRepo.locks)
+}
@@ -0,0 +1,189 @@
+package service_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	mock_test "wallet-app/test/mock"
+	"wallet-app/test/testdb"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newFeeTestService(t *testing.T) (service.IWalletService, service.IFeeService, repo.ILedgerRepo, *gorm.DB) {
+	db := testdb.Open(t, "fee_test")
+
+	fxProvider := new(mock_test.MockFxProvider)
+	fxProvider.On("GetRate", "SGD", "JPY").Return(big.NewRat(1135, 10), nil)
+
+	cfg := &config.AppConfig{
+		Fx:    config.FxConfig{QuoteTtl: time.Minute},
+		Fees:  config.FeesConfig{RevenueWallets: map[string]string{"sgd": "wallet_house"}},
+		Holds: config.HoldsConfig{DefaultTtl: time.Hour, MaxTtl: 24 * time.Hour},
+	}
+	deps := testdb.Deps(db, cfg)
+	deps.FxProvider = fxProvider
+	walletService := deps.NewWalletService()
+	feeService := service.NewFeeService(logrus.New(), cfg, repo.NewFeeRuleRepo(db), &mapper.AppMapper{})
+
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", UserId: "rathan", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_jpy", UserId: "rathan", Currency: "JPY"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_house", UserId: "house", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	return walletService, feeService, deps.LedgerRepo, db
+}
+
+func createFeeRule(t *testing.T, feeService service.IFeeService, req request.FeeRuleReq) string {
+	result := feeService.CreateFeeRule(admin, req)
+	require.Equal(t, 0, result.Err.Code)
+	return result.Data.(response.FeeRuleResponse).FeeRuleId
+}
+
+func TestFees_withdrawalPostsFeeRows(t *testing.T) {
+	walletService, feeService, ledgerRepo, db := newFeeTestService(t)
+	feeRuleId := createFeeRule(t, feeService, request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "percentage", PercentageBps: 150, MinAmount: 50})
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "")
+	require.Equal(t, 0, result.Err.Code)
+	trx := result.Data.(response.TrxResponse)
+	// 1.5% of 1000 is 15, raised to the minimum
+	assert.Equal(t, uint(50), trx.Fee)
+	assert.Equal(t, uint(8950), trx.CurrentBalance)
+	assert.Equal(t, uint(8950), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(50), walletBalance(t, db, "wallet_house"))
+
+	var feeRows []entity.TrxEntity
+	require.NoError(t, db.Where("fee_of = ?", trx.TransactionId).Order("trx_type").Find(&feeRows).Error)
+	require.Equal(t, 2, len(feeRows))
+	assert.Equal(t, common.TrxTypeFee, feeRows[0].TrxType)
+	assert.Equal(t, "wallet_mine", feeRows[0].WalletId)
+	assert.Equal(t, common.TrxTypeFeeIn, feeRows[1].TrxType)
+	assert.Equal(t, "wallet_house", feeRows[1].WalletId)
+	assert.Equal(t, feeRuleId, feeRows[0].FeeRuleId)
+	assert.Equal(t, uint(50), feeRows[1].Amount)
+
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_house")
+	assertLedgerBalances(t, db)
+}
+
+func TestFees_insufficientFundsIncludesFee(t *testing.T) {
+	walletService, feeService, _, db := newFeeTestService(t)
+	createFeeRule(t, feeService, request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "flat", FlatAmount: 100})
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 9950}, "")
+	assert.Equal(t, apperror.ErrInsufficientAmount, result.Err)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_house"))
+
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 9900}, "").Err.Code)
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestFees_holdCapturePaysFeesOnTop(t *testing.T) {
+	walletService, feeService, ledgerRepo, db := newFeeTestService(t)
+	createFeeRule(t, feeService, request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "flat", FlatAmount: 100})
+
+	// the fee does not fit next to a hold of all but 50
+	hold := createTestHold(t, walletService, "wallet_mine", 9950)
+	result := walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{})
+	assert.Equal(t, apperror.ErrInsufficientAmount, result.Err)
+	require.Equal(t, 0, walletService.ReleaseHold(context.Background(), admin, hold.HoldId).Err.Code)
+
+	hold = createTestHold(t, walletService, "wallet_mine", 9000)
+	result = walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{})
+	require.Equal(t, 0, result.Err.Code, result.Err.Message)
+	assert.Equal(t, uint(100), result.Data.(response.TrxResponse).Fee)
+	assert.Equal(t, uint(900), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(100), walletBalance(t, db, "wallet_house"))
+
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_house")
+	assertLedgerBalances(t, db)
+}
+
+func TestFees_transferAndFx(t *testing.T) {
+	walletService, feeService, ledgerRepo, db := newFeeTestService(t)
+	createFeeRule(t, feeService, request.FeeRuleReq{Operation: "transfer_out", Currency: "SGD", Kind: "flat", FlatAmount: 25})
+	createFeeRule(t, feeService, request.FeeRuleReq{Operation: "fx", Currency: "SGD", Kind: "tiered", Tiers: []request.FeeTierReq{
+		{UpTo: 1000, FlatAmount: 10},
+		{PercentageBps: 100},
+	}})
+
+	result := walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 1000, CounterpartyWalletId: "wallet_counterparty"}, "")
+	require.Equal(t, 0, result.Err.Code)
+	assert.Equal(t, uint(25), result.Data.(response.TrxResponse).Fee)
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_counterparty"))
+
+	preview := walletService.PreviewFees(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", request.FeePreviewReq{TrxType: "transfer_out", Amount: 2000, CounterpartyWalletId: "wallet_jpy"})
+	require.Equal(t, 0, preview.Err.Code)
+	fees := preview.Data.(response.FeePreviewResponse)
+	require.Equal(t, 2, len(fees.Fees))
+	assert.Equal(t, common.FeeOperationTransferOut, fees.Fees[0].Operation)
+	assert.Equal(t, common.FeeOperationFx, fees.Fees[1].Operation)
+	assert.Equal(t, uint(45), fees.TotalFee)
+	assert.Equal(t, uint(2045), fees.TotalDebit)
+
+	quote := walletService.QuoteTransfer(context.Background(), admin, "wallet_mine", request.TransferQuoteReq{Amount: 2000, CounterpartyWalletId: "wallet_jpy"})
+	require.Equal(t, 0, quote.Err.Code)
+	result = walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 2000, CounterpartyWalletId: "wallet_jpy", QuoteId: quote.Data.(response.TransferQuoteResponse).QuoteId}, "")
+	require.Equal(t, 0, result.Err.Code)
+	assert.Equal(t, uint(45), result.Data.(response.TrxResponse).Fee)
+	assert.Equal(t, uint(10000-1025-2045), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(70), walletBalance(t, db, "wallet_house"))
+
+	for _, walletId := range []string{"wallet_mine", "wallet_counterparty", "wallet_jpy", "wallet_house"} {
+		assertWalletMatchesLedger(t, db, ledgerRepo, walletId)
+	}
+	assertLedgerBalances(t, db)
+}
+
+func TestFees_revenueWalletIsExempt(t *testing.T) {
+	walletService, feeService, _, db := newFeeTestService(t)
+	createFeeRule(t, feeService, request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "flat", FlatAmount: 100})
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_house", request.TrxReq{Amount: 500}, "").Err.Code)
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_house", request.TrxReq{Amount: 500}, "")
+	require.Equal(t, 0, result.Err.Code)
+	assert.Equal(t, uint(0), result.Data.(response.TrxResponse).Fee)
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_house"))
+}
+
+func TestFeeRules_adminOnlyAndValidated(t *testing.T) {
+	_, feeService, _, _ := newFeeTestService(t)
+
+	req := request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "flat", FlatAmount: 100}
+	assert.Equal(t, apperror.ErrForbidden, feeService.CreateFeeRule(auth.Principal{UserId: "jana"}, req).Err)
+
+	feeRuleId := createFeeRule(t, feeService, req)
+	assert.Equal(t, apperror.ErrFeeRuleConflict, feeService.CreateFeeRule(admin, req).Err)
+
+	inactive := false
+	req.Active = &inactive
+	assert.Equal(t, 0, feeService.CreateFeeRule(admin, req).Err.Code)
+
+	result := feeService.CreateFeeRule(admin, request.FeeRuleReq{Operation: "withdrawal", Currency: "SGD", Kind: "percentage", MinAmount: 10, MaxAmount: 5})
+	assert.Equal(t, apperror.ErrInvalidFeeRule.Code, result.Err.Code)
+	assert.NotNil(t, result.Err.Details)
+	assert.Equal(t, apperror.ErrInvalidFeeRule, feeService.CreateFeeRule(admin, request.FeeRuleReq{Operation: "deposit", Currency: "SGD", Kind: "flat"}).Err)
+	assert.Equal(t, apperror.ErrFeeRevenueWalletNotConfigured, feeService.CreateFeeRule(admin, request.FeeRuleReq{Operation: "withdrawal", Currency: "JPY", Kind: "flat", FlatAmount: 1}).Err)
+
+	assert.Equal(t, 2, len(feeService.GetFeeRules(admin).Data.([]response.FeeRuleResponse)))
+	require.Equal(t, 0, feeService.DeleteFeeRule(admin, feeRuleId).Err.Code)
+	assert.Equal(t, apperror.ErrFeeRuleNotFound, feeService.GetFeeRule(admin, feeRuleId).Err)
+}
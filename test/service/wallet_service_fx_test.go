@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	mock_test "wallet-app/test/mock"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newFxTestService(t *testing.T, quoteTtl time.Duration) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, "fx_test")
+
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_sgd", Balance: 20000, Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_jpy", Balance: 0, Currency: "JPY"}).Error)
+
+	fxProvider := new(mock_test.MockFxProvider)
+	fxProvider.On("GetRate", "SGD", "JPY").Return(big.NewRat(1135, 10), nil)
+
+	walletService := testdb.NewWalletService(db, &config.AppConfig{Fx: config.FxConfig{QuoteTtl: quoteTtl}},
+		func(deps *testdb.WalletServiceDeps) { deps.FxProvider = fxProvider })
+	return walletService, db
+}
+
+func TestQuoteTransfer_thenTransferCrossCurrency(t *testing.T) {
+	service, db := newFxTestService(t, time.Minute)
+
+	quoteRes := service.QuoteTransfer(context.Background(), admin, "wallet_sgd", request.TransferQuoteReq{Amount: 10000, CounterpartyWalletId: "wallet_jpy"})
+	require.Equal(t, 0, quoteRes.Err.Code)
+	quote := quoteRes.Data.(response.TransferQuoteResponse)
+	assert.Equal(t, uint(11350), quote.DestinationAmount)
+	assert.Equal(t, "113.5", quote.Rate)
+	assert.Equal(t, 0, quote.DestinationExponent)
+
+	result := service.TransferMoney(context.Background(), admin, "wallet_sgd", request.TransferReq{Amount: 10000, CounterpartyWalletId: "wallet_jpy", QuoteId: quote.QuoteId}, "")
+	require.Equal(t, 0, result.Err.Code)
+	assert.Equal(t, uint(10000), result.Data.(response.TrxResponse).CurrentBalance)
+	assert.Equal(t, uint(11350), result.Data.(response.TrxResponse).CounterpartyAmount)
+
+	var counterpartyWallet entity.WalletEntity
+	require.NoError(t, db.First(&counterpartyWallet, "id = ?", "wallet_jpy").Error)
+	assert.Equal(t, uint(11350), counterpartyWallet.Balance)
+
+	var trxs []entity.TrxEntity
+	require.NoError(t, db.Order("trx_type").Find(&trxs).Error)
+	require.Equal(t, 2, len(trxs))
+	for _, trx := range trxs {
+		assert.Equal(t, "113.5", trx.FxRate)
+		assert.Equal(t, quote.QuoteId, trx.FxQuoteId)
+	}
+	assert.Equal(t, uint(11350), trxs[0].Amount) // transfer_in
+	assert.Equal(t, uint(10000), trxs[0].CounterpartyAmount)
+	assert.Equal(t, uint(10000), trxs[1].Amount) // transfer_out
+	assert.Equal(t, uint(11350), trxs[1].CounterpartyAmount)
+
+	ledgerRepo := repo.NewLedgerRepo(db)
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_sgd")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_jpy")
+	assertLedgerBalances(t, db)
+}
+
+func TestTransferMoney_quoteCannotBeReused(t *testing.T) {
+	service, _ := newFxTestService(t, time.Minute)
+
+	quote := service.QuoteTransfer(context.Background(), admin, "wallet_sgd", request.TransferQuoteReq{Amount: 5000, CounterpartyWalletId: "wallet_jpy"}).Data.(response.TransferQuoteResponse)
+	req := request.TransferReq{Amount: 5000, CounterpartyWalletId: "wallet_jpy", QuoteId: quote.QuoteId}
+
+	first := service.TransferMoney(context.Background(), admin, "wallet_sgd", req, "")
+	second := service.TransferMoney(context.Background(), admin, "wallet_sgd", req, "")
+
+	assert.Equal(t, 0, first.Err.Code)
+	assert.Equal(t, apperror.ErrFxQuoteAlreadyUsed.Message, second.Err.Message)
+}
+
+func TestTransferMoney_expiredQuote(t *testing.T) {
+	service, _ := newFxTestService(t, -time.Second)
+
+	quote := service.QuoteTransfer(context.Background(), admin, "wallet_sgd", request.TransferQuoteReq{Amount: 5000, CounterpartyWalletId: "wallet_jpy"}).Data.(response.TransferQuoteResponse)
+	result := service.TransferMoney(context.Background(), admin, "wallet_sgd", request.TransferReq{Amount: 5000, CounterpartyWalletId: "wallet_jpy", QuoteId: quote.QuoteId}, "")
+
+	assert.Equal(t, apperror.ErrFxQuoteExpired.Message, result.Err.Message)
+}
+
+func TestTransferMoney_quoteAmountMismatch(t *testing.T) {
+	service, _ := newFxTestService(t, time.Minute)
+
+	quote := service.QuoteTransfer(context.Background(), admin, "wallet_sgd", request.TransferQuoteReq{Amount: 5000, CounterpartyWalletId: "wallet_jpy"}).Data.(response.TransferQuoteResponse)
+	result := service.TransferMoney(context.Background(), admin, "wallet_sgd", request.TransferReq{Amount: 6000, CounterpartyWalletId: "wallet_jpy", QuoteId: quote.QuoteId}, "")
+
+	assert.Equal(t, apperror.ErrFxQuoteMismatch.Message, result.Err.Message)
+}
@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactions_cursorWalksAllPages(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	for i := 1; i <= 5; i++ {
+		require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: uint(i * 100)}, "").Err.Code)
+	}
+
+	var amounts []uint
+	req := request.TrxHistoryReq{Limit: 2}
+	pages := 0
+	for {
+		res := walletService.GetTransactions(context.Background(), admin, "wallet_mine", req)
+		require.Equal(t, 0, res.Err.Code)
+		history := res.Data.(response.TrxHistoryResponse)
+		for _, trx := range history.Transactions {
+			amounts = append(amounts, trx.Amount)
+		}
+		pages++
+		if history.NextCursor == "" {
+			break
+		}
+		req.Cursor = history.NextCursor
+	}
+
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, []uint{500, 400, 300, 200, 100}, amounts)
+}
+
+func TestGetTransactions_filtersByTrxType(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 300}, "").Err.Code)
+
+	res := walletService.GetTransactions(context.Background(), admin, "wallet_mine", request.TrxHistoryReq{TrxTypes: []string{string(common.TrxTypeWithdrawal)}})
+
+	require.Equal(t, 0, res.Err.Code)
+	history := res.Data.(response.TrxHistoryResponse)
+	require.Len(t, history.Transactions, 1)
+	assert.Equal(t, uint(300), history.Transactions[0].Amount)
+	assert.Empty(t, history.NextCursor)
+}
+
+func TestGetTransactions_invalidRequest(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	minAmount, maxAmount := uint(500), uint(100)
+
+	assert.Equal(t, apperror.ErrInvalidCursor, walletService.GetTransactions(context.Background(), admin, "wallet_mine", request.TrxHistoryReq{Cursor: "not-a-cursor"}).Err)
+	assert.Equal(t, apperror.ErrInvalidTrxFilter, walletService.GetTransactions(context.Background(), admin, "wallet_mine", request.TrxHistoryReq{TrxTypes: []string{"refund"}}).Err)
+	assert.Equal(t, apperror.ErrInvalidTrxFilter, walletService.GetTransactions(context.Background(), admin, "wallet_mine", request.TrxHistoryReq{MinAmount: &minAmount, MaxAmount: &maxAmount}).Err)
+}
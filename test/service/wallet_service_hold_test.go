@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newHoldTestService(t *testing.T) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, "hold_test")
+
+	walletService := testdb.NewWalletService(db, &config.AppConfig{Holds: config.HoldsConfig{DefaultTtl: time.Hour, MaxTtl: 24 * time.Hour}})
+	return walletService, db
+}
+
+func createTestHold(t *testing.T, walletService service.IWalletService, walletId string, amount uint) response.HoldResponse {
+	res := walletService.CreateHold(context.Background(), admin, walletId, request.CreateHoldReq{Amount: amount})
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+	return res.Data.(response.HoldResponse)
+}
+
+func availableBalanceOf(t *testing.T, walletService service.IWalletService, walletId string) uint {
+	res := walletService.GetBalance(context.Background(), admin, walletId)
+	require.Equal(t, 0, res.Err.Code)
+	return res.Data.(response.WalletResponse).AvailableBalance
+}
+
+func TestHold_reservesAvailableBalance(t *testing.T) {
+	walletService, db := newHoldTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Balance: 10000, Currency: "SGD"}).Error)
+
+	createTestHold(t, walletService, "wallet_mine", 7000)
+
+	assert.Equal(t, uint(3000), availableBalanceOf(t, walletService, "wallet_mine"))
+	assert.Equal(t, apperror.ErrInsufficientAmount, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 3001}, "").Err)
+	assert.Equal(t, apperror.ErrInsufficientAmount, walletService.CreateHold(context.Background(), admin, "wallet_mine", request.CreateHoldReq{Amount: 3001}).Err)
+	assert.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 3000}, "").Err.Code)
+}
+
+func TestHold_partialCaptureIntoWithdrawalReleasesTheRest(t *testing.T) {
+	walletService, db := newHoldTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Balance: 10000, Currency: "SGD"}).Error)
+	hold := createTestHold(t, walletService, "wallet_mine", 6000)
+
+	res := walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{Amount: 4000})
+
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(6000), res.Data.(response.TrxResponse).CurrentBalance)
+	assert.Equal(t, uint(6000), availableBalanceOf(t, walletService, "wallet_mine"))
+
+	var saved entity.HoldEntity
+	require.NoError(t, db.First(&saved, "id = ?", hold.HoldId).Error)
+	assert.Equal(t, common.HoldStatusCaptured, saved.Status)
+	assert.Equal(t, uint(4000), saved.CapturedAmount)
+	assert.Equal(t, res.Data.(response.TrxResponse).TransactionId, saved.TrxId)
+
+	var releases []entity.TrxEntity
+	require.NoError(t, db.Where("hold_id = ? AND trx_type = ?", hold.HoldId, common.TrxTypeHoldRelease).Find(&releases).Error)
+	require.Len(t, releases, 1)
+	assert.Equal(t, uint(2000), releases[0].Amount)
+
+	assert.Equal(t, apperror.ErrHoldNotActive, walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{}).Err)
+	assert.Equal(t, apperror.ErrHoldNotActive, walletService.ReleaseHold(context.Background(), admin, hold.HoldId).Err)
+}
+
+func TestHold_captureIntoTransfer(t *testing.T) {
+	walletService, db := newHoldTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Balance: 10000, Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", Currency: "SGD"}).Error)
+	hold := createTestHold(t, walletService, "wallet_mine", 2500)
+
+	assert.Equal(t, apperror.ErrHoldCaptureExceedsAmount, walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{Amount: 2501, CounterpartyWalletId: "wallet_counterparty"}).Err)
+	res := walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{CounterpartyWalletId: "wallet_counterparty"})
+
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(7500), availableBalanceOf(t, walletService, "wallet_mine"))
+	assert.Equal(t, uint(2500), availableBalanceOf(t, walletService, "wallet_counterparty"))
+}
+
+func TestHold_releaseAndExpiry(t *testing.T) {
+	walletService, db := newHoldTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Balance: 10000, Currency: "SGD"}).Error)
+	released := createTestHold(t, walletService, "wallet_mine", 1000)
+	expiring := createTestHold(t, walletService, "wallet_mine", 2000)
+	assert.Equal(t, uint(7000), availableBalanceOf(t, walletService, "wallet_mine"))
+
+	res := walletService.ReleaseHold(context.Background(), admin, released.HoldId)
+	require.Equal(t, 0, res.Err.Code)
+	assert.Equal(t, common.HoldStatusReleased, res.Data.(response.HoldResponse).Status)
+	assert.Equal(t, uint(8000), availableBalanceOf(t, walletService, "wallet_mine"))
+
+	require.NoError(t, db.Model(&entity.HoldEntity{}).Where("id = ?", expiring.HoldId).Update("expires_at", time.Now().UTC().Add(-time.Minute)).Error)
+	// stops counting as soon as it expires, before the sweeper runs
+	assert.Equal(t, uint(10000), availableBalanceOf(t, walletService, "wallet_mine"))
+	assert.Equal(t, apperror.ErrHoldExpired, walletService.CaptureHold(context.Background(), admin, expiring.HoldId, request.CaptureHoldReq{}).Err)
+
+	assert.Equal(t, 1, walletService.ExpireHolds(context.Background()).Data)
+	assert.Equal(t, 0, walletService.ExpireHolds(context.Background()).Data)
+	var saved entity.HoldEntity
+	require.NoError(t, db.First(&saved, "id = ?", expiring.HoldId).Error)
+	assert.Equal(t, common.HoldStatusExpired, saved.Status)
+	assert.Equal(t, uint(10000), availableBalanceOf(t, walletService, "wallet_mine"))
+}
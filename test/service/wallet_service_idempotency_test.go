@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newIdempotencyTestService(t *testing.T, keyTtl time.Duration) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, "idempotency_test")
+
+	return newIdempotencyTestServiceWithRepo(t, keyTtl, db, repo.NewIdempotencyRepo(db)), db
+}
+
+func newIdempotencyTestServiceWithRepo(t *testing.T, keyTtl time.Duration, db *gorm.DB, idempotencyRepo repo.IIdempotencyRepo) service.IWalletService {
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Balance: 20000}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", UserId: "rathan", Balance: 0}).Error)
+
+	return testdb.NewWalletService(db, &config.AppConfig{Idempotency: config.IdempotencyConfig{KeyTtl: keyTtl}},
+		func(deps *testdb.WalletServiceDeps) { deps.IdempotencyRepo = idempotencyRepo })
+}
+
+func TestDepositMoney_sameIdempotencyKeyReplaysResponse(t *testing.T) {
+	service, db := newIdempotencyTestService(t, time.Hour)
+	req := request.TrxReq{Amount: 1000}
+
+	first := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+	second := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+
+	assert.Equal(t, 0, first.Err.Code)
+	assert.Equal(t, 0, second.Err.Code)
+	assert.Equal(t, first.Data.(response.TrxResponse), second.Data.(response.TrxResponse))
+
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_mine").Error)
+	assert.Equal(t, uint(21000), wallet.Balance)
+
+	var trxCount int64
+	db.Model(&entity.TrxEntity{}).Count(&trxCount)
+	assert.Equal(t, int64(1), trxCount)
+}
+
+func TestWithdrawMoney_idempotencyKeyReusedWithDifferentBody(t *testing.T) {
+	service, _ := newIdempotencyTestService(t, time.Hour)
+
+	first := service.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "key-1")
+	second := service.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 2000}, "key-1")
+
+	assert.Equal(t, 0, first.Err.Code)
+	assert.Equal(t, 409, second.Err.Code)
+	assert.Equal(t, apperror.ErrIdempotencyKeyConflict.Message, second.Err.Message)
+}
+
+func TestTransferMoney_sameIdempotencyKeyMovesMoneyOnce(t *testing.T) {
+	service, db := newIdempotencyTestService(t, time.Hour)
+	req := request.TransferReq{Amount: 5000, CounterpartyWalletId: "wallet_counterparty"}
+
+	first := service.TransferMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+	second := service.TransferMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+
+	assert.Equal(t, 0, first.Err.Code)
+	assert.Equal(t, first.Data.(response.TrxResponse), second.Data.(response.TrxResponse))
+
+	var counterpartyWallet entity.WalletEntity
+	require.NoError(t, db.First(&counterpartyWallet, "id = ?", "wallet_counterparty").Error)
+	assert.Equal(t, uint(5000), counterpartyWallet.Balance)
+}
+
+func TestDepositMoney_expiredIdempotencyKeyIsProcessedAgain(t *testing.T) {
+	service, db := newIdempotencyTestService(t, -time.Second)
+	req := request.TrxReq{Amount: 1000}
+
+	first := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+	second := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+
+	assert.Equal(t, 0, first.Err.Code)
+	assert.Equal(t, 0, second.Err.Code)
+	assert.NotEqual(t, first.Data.(response.TrxResponse).TransactionId, second.Data.(response.TrxResponse).TransactionId)
+
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", "wallet_mine").Error)
+	assert.Equal(t, uint(22000), wallet.Balance)
+}
+
+func TestDepositMoney_idempotencyKeysAreScopedToCallerAndWallet(t *testing.T) {
+	service, db := newIdempotencyTestService(t, time.Hour)
+	jana := auth.Principal{UserId: "jana"}
+	rathan := auth.Principal{UserId: "rathan"}
+
+	mine := service.DepositMoney(context.Background(), jana, "wallet_mine", request.TrxReq{Amount: 1000}, "key-1")
+	theirs := service.DepositMoney(context.Background(), rathan, "wallet_counterparty", request.TrxReq{Amount: 2000}, "key-1")
+	byAdmin := service.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 3000}, "key-1")
+
+	require.Equal(t, 0, mine.Err.Code)
+	require.Equal(t, 0, theirs.Err.Code, theirs.Err.Message)
+	require.Equal(t, 0, byAdmin.Err.Code, byAdmin.Err.Message)
+	assert.Equal(t, uint(2000), theirs.Data.(response.TrxResponse).Amount)
+	assert.Equal(t, uint(24000), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(2000), walletBalance(t, db, "wallet_counterparty"))
+}
+
+// racingIdempotencyRepo misses keys on its first lookups, as a request would that looked its key up
+// before a concurrent request with the same key committed.
+type racingIdempotencyRepo struct {
+	repo.IIdempotencyRepo
+	misses int
+}
+
+func (r *racingIdempotencyRepo) FindIdempotencyKeyWithTx(userId string, walletId string, key string, tx *gorm.DB) (entity.IdempotencyKeyEntity, error) {
+	if r.misses > 0 {
+		r.misses--
+		return entity.IdempotencyKeyEntity{}, gorm.ErrRecordNotFound
+	}
+	return r.IIdempotencyRepo.FindIdempotencyKeyWithTx(userId, walletId, key, tx)
+}
+
+func TestDepositMoney_idempotencyKeyLostToConcurrentRequestReplaysIt(t *testing.T) {
+	db := testdb.Open(t, "idempotency_race_test")
+	idempotencyRepo := &racingIdempotencyRepo{IIdempotencyRepo: repo.NewIdempotencyRepo(db)}
+	service := newIdempotencyTestServiceWithRepo(t, time.Hour, db, idempotencyRepo)
+	req := request.TrxReq{Amount: 1000}
+
+	first := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+	require.Equal(t, 0, first.Err.Code)
+	idempotencyRepo.misses = 1
+	second := service.DepositMoney(context.Background(), admin, "wallet_mine", req, "key-1")
+
+	require.Equal(t, 0, second.Err.Code, second.Err.Message)
+	assert.Equal(t, first.Data.(response.TrxResponse), second.Data.(response.TrxResponse))
+	assert.Equal(t, uint(21000), walletBalance(t, db, "wallet_mine"))
+	var trxCount int64
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Count(&trxCount).Error)
+	assert.Equal(t, int64(1), trxCount)
+}
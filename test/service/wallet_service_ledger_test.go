@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newLedgerTestService(t *testing.T) (service.IWalletService, repo.ILedgerRepo, *gorm.DB) {
+	db := testdb.Open(t, "ledger_test")
+
+	deps := testdb.Deps(db, &config.AppConfig{})
+	return deps.NewWalletService(), deps.LedgerRepo, db
+}
+
+func assertWalletMatchesLedger(t *testing.T, db *gorm.DB, ledgerRepo repo.ILedgerRepo, walletId string) {
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", walletId).Error)
+	ledgerBalance, err := ledgerRepo.FindLedgerAccountBalance(service.WalletAccount(wallet).ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(wallet.Balance), ledgerBalance)
+}
+
+func assertLedgerBalances(t *testing.T, db *gorm.DB) {
+	var entries []entity.LedgerEntryEntity
+	require.NoError(t, db.Find(&entries).Error)
+	sums := map[string]int64{}
+	for _, entry := range entries {
+		if entry.Direction == common.EntryDirectionDebit {
+			sums[entry.Currency] += int64(entry.Amount)
+		} else {
+			sums[entry.Currency] -= int64(entry.Amount)
+		}
+	}
+	for currency, sum := range sums {
+		assert.Equal(t, int64(0), sum, "ledger does not balance for %s", currency)
+	}
+}
+
+func TestLedger_moneyOperationsPostBalancedJournals(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", Currency: "SGD"}).Error)
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 2500}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 4000, CounterpartyWalletId: "wallet_counterparty"}, "").Err.Code)
+
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_counterparty")
+	assertLedgerBalances(t, db)
+
+	cashIn, err := ledgerRepo.FindLedgerAccountBalance(service.SystemAccount(common.SystemAccountCashIn, "SGD").ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-10000), cashIn)
+	cashOut, err := ledgerRepo.FindLedgerAccountBalance(service.SystemAccount(common.SystemAccountCashOut, "SGD").ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), cashOut)
+}
+
+func TestLedger_existingBalanceGetsOpeningJournal(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Balance: 5000, Currency: "SGD"}).Error)
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertLedgerBalances(t, db)
+}
+
+func TestValidateLedgerLegs(t *testing.T) {
+	wallet := service.WalletAccount(entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"})
+	cashIn := service.SystemAccount(common.SystemAccountCashIn, "SGD")
+	fxSgd := service.SystemAccount(common.SystemAccountFx, "SGD")
+	fxUsd := service.SystemAccount(common.SystemAccountFx, "USD")
+
+	assert.NoError(t, service.ValidateLedgerLegs([]service.LedgerLeg{service.Debit(cashIn, 100), service.Credit(wallet, 100)}))
+	assert.Error(t, service.ValidateLedgerLegs([]service.LedgerLeg{service.Debit(cashIn, 100), service.Credit(wallet, 99)}))
+	assert.Error(t, service.ValidateLedgerLegs([]service.LedgerLeg{service.Credit(wallet, 100)}))
+	// balanced in total but not per currency
+	assert.Error(t, service.ValidateLedgerLegs([]service.LedgerLeg{service.Debit(fxUsd, 100), service.Credit(fxSgd, 100)}))
+}
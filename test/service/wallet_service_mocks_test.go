@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"wallet-app/config"
+	"wallet-app/mapper"
+	"wallet-app/service"
+	mock_test "wallet-app/test/mock"
+	"wallet-app/test/testdb"
+
+	"github.com/sirupsen/logrus"
+)
+
+// walletServiceMocks are the mocks a wallet service of the unit tests runs on. Spending limits and
+// fee rules are none, and the adjustment repo expects no calls.
+type walletServiceMocks struct {
+	walletRepo      *mock_test.MockWalletRepo
+	trxRepo         *mock_test.MockTrxRepo
+	ledgerRepo      *mock_test.MockLedgerRepo
+	idempotencyRepo *mock_test.MockIdempotencyRepo
+	fxQuoteRepo     *mock_test.MockFxQuoteRepo
+	holdRepo        *mock_test.MockHoldRepo
+	outboxRepo      *mock_test.MockOutboxRepo
+	fxProvider      *mock_test.MockFxProvider
+	txManager       *mock_test.MockDbTxManager
+}
+
+func newWalletServiceMocks() *walletServiceMocks {
+	return &walletServiceMocks{
+		walletRepo:      new(mock_test.MockWalletRepo),
+		trxRepo:         new(mock_test.MockTrxRepo),
+		ledgerRepo:      new(mock_test.MockLedgerRepo),
+		idempotencyRepo: new(mock_test.MockIdempotencyRepo),
+		fxQuoteRepo:     new(mock_test.MockFxQuoteRepo),
+		holdRepo:        new(mock_test.MockHoldRepo),
+		outboxRepo:      new(mock_test.MockOutboxRepo),
+		fxProvider:      new(mock_test.MockFxProvider),
+		txManager:       new(mock_test.MockDbTxManager),
+	}
+}
+
+// walletService returns a wallet service with an empty config over the mocks.
+func (m *walletServiceMocks) walletService() service.IWalletService {
+	return testdb.WalletServiceDeps{
+		Log:               logrus.New(),
+		Cfg:               &config.AppConfig{},
+		WalletRepo:        m.walletRepo,
+		TrxRepo:           m.trxRepo,
+		LedgerRepo:        m.ledgerRepo,
+		IdempotencyRepo:   m.idempotencyRepo,
+		FxQuoteRepo:       m.fxQuoteRepo,
+		HoldRepo:          m.holdRepo,
+		OutboxRepo:        m.outboxRepo,
+		AdjustmentRepo:    new(mock_test.MockAdjustmentRepo),
+		SpendingLimitRepo: noSpendingLimits(),
+		FeeRuleRepo:       noFeeRules(),
+		FxProvider:        m.fxProvider,
+		Mapper:            &mapper.AppMapper{},
+		DbTxManager:       m.txManager,
+	}.NewWalletService()
+}
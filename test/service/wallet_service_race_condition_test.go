@@ -1,16 +1,16 @@
 package service_test
 
 import (
+	"context"
 	"testing"
 	"time"
 	"wallet-app/apperror"
+	"wallet-app/config"
+	appdb "wallet-app/db"
 	"wallet-app/entity"
-	"wallet-app/manager"
-	"wallet-app/mapper"
-	"wallet-app/repo"
 	"wallet-app/request"
 	"wallet-app/response"
-	"wallet-app/service"
+	"wallet-app/test/testdb"
 
 	"github.com/glebarez/sqlite"
 	"github.com/sirupsen/logrus"
@@ -29,8 +29,8 @@ func TestWithdrawMoney_RaceCondition(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	db.Migrator().DropTable(&entity.WalletEntity{}, &entity.TrxEntity{})
-	require.NoError(t, db.AutoMigrate(&entity.WalletEntity{}, &entity.TrxEntity{}))
+	db.Migrator().DropTable(appdb.Entities()...)
+	require.NoError(t, appdb.Migrate(db))
 
 	walletId := "wallet123"
 	initialBalance := uint(20000)
@@ -43,26 +43,20 @@ func TestWithdrawMoney_RaceCondition(t *testing.T) {
 	}
 	require.NoError(t, db.Create(&wallet).Error)
 
-	service := service.NewWalletService(
-		log,
-		repo.NewWalletRepo(db),
-		repo.NewTransactionRepo(db),
-		&mapper.AppMapper{},
-		manager.NewDbTxManager(db),
-	)
+	service := testdb.NewWalletService(db, &config.AppConfig{}, func(deps *testdb.WalletServiceDeps) { deps.Log = log })
 
 	const count = 10
 	results := make(chan response.ResonseWrapper, count)
 
 	for i := 0; i < count; i++ {
-		result := service.WithdrawMoney(walletId, request.TrxReq{Amount: withdrawalAmount})
+		result := service.WithdrawMoney(context.Background(), admin, walletId, request.TrxReq{Amount: withdrawalAmount}, "")
 		results <- result
 	}
 	close(results)
 
 	time.Sleep(time.Second * 2)
-	walletBalance := service.GetBalance(walletId)
-	assert.Equal(t, expectedAmountAfterWithdrawals, walletBalance.Data.(entity.WalletEntity).Balance)
+	walletBalance := service.GetBalance(context.Background(), admin, walletId)
+	assert.Equal(t, expectedAmountAfterWithdrawals, walletBalance.Data.(response.WalletResponse).CurrentBalance)
 
 	successCount := 0
 	insufficientAmountErrs := 0
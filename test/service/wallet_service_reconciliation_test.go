@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileWallets_reportsAndRepairsDrift(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", Currency: "SGD"}).Error)
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 2500}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 4000, CounterpartyWalletId: "wallet_counterparty"}, "").Err.Code)
+
+	clean := walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{}).Data.(response.ReconciliationReport)
+	assert.Equal(t, 2, clean.WalletsChecked)
+	assert.Empty(t, clean.Drifts)
+
+	// the cached balance drifts away from the transactions, e.g. after a manual sql fix
+	require.NoError(t, db.Model(&entity.WalletEntity{}).Where("id = ?", "wallet_mine").Update("balance", 3700).Error)
+
+	reported := walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{}).Data.(response.ReconciliationReport)
+	require.Len(t, reported.Drifts, 1)
+	drift := reported.Drifts[0]
+	assert.Equal(t, "wallet_mine", drift.WalletId)
+	assert.Equal(t, uint(3700), drift.Balance)
+	assert.Equal(t, int64(3500), drift.ReplayedBalance)
+	assert.Equal(t, int64(200), drift.Delta)
+	assert.False(t, drift.Repaired)
+	assert.Equal(t, uint(3700), walletBalance(t, db, "wallet_mine"))
+
+	repaired := walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{WalletIds: []string{"wallet_mine"}, Repair: true, Reason: "ticket 42"}).Data.(response.ReconciliationReport)
+	require.Len(t, repaired.Drifts, 1)
+	assert.True(t, repaired.Drifts[0].Repaired)
+	assert.Equal(t, 1, repaired.Repaired)
+	assert.Equal(t, uint(3500), walletBalance(t, db, "wallet_mine"))
+
+	var adjustment entity.BalanceAdjustmentEntity
+	require.NoError(t, db.First(&adjustment, "id = ?", repaired.Drifts[0].AdjustmentId).Error)
+	assert.Equal(t, common.AdjustmentKindReconciliation, adjustment.Kind)
+	assert.Equal(t, common.EntryDirectionDebit, adjustment.Direction)
+	assert.Equal(t, uint(200), adjustment.Amount)
+	assert.Equal(t, uint(3700), adjustment.BalanceBefore)
+	assert.Equal(t, uint(3500), adjustment.BalanceAfter)
+	assert.Equal(t, "ticket 42", adjustment.Reason)
+	assert.Equal(t, admin.UserId, adjustment.Actor)
+	assert.Len(t, ledgerRepo.FindLedgerEntriesByJournalId(adjustment.JournalId), 2)
+
+	assertLedgerBalances(t, db)
+	assert.Empty(t, walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{}).Data.(response.ReconciliationReport).Drifts)
+}
+
+func TestReconcileWallets_repairsMissingBalance(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	require.NoError(t, db.Model(&entity.WalletEntity{}).Where("id = ?", "wallet_mine").Update("balance", 9000).Error)
+	require.NoError(t, db.Model(&entity.LedgerEntryEntity{}).Where("account_id = ? AND direction = ?", "wallet:wallet_mine", common.EntryDirectionCredit).Update("amount", 9000).Error)
+	require.NoError(t, db.Model(&entity.LedgerEntryEntity{}).Where("account_id = ?", "system:cash_in:SGD").Update("amount", 9000).Error)
+
+	report := walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{Repair: true}).Data.(response.ReconciliationReport)
+	require.Len(t, report.Drifts, 1)
+	assert.Equal(t, int64(-1000), report.Drifts[0].Delta)
+	assert.True(t, report.Drifts[0].Repaired)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_mine"))
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertLedgerBalances(t, db)
+}
+
+func TestReconcileWallets_adminOnly(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+
+	assert.Equal(t, apperror.ErrForbidden, walletService.ReconcileWallets(context.Background(), auth.Principal{UserId: "jana"}, request.ReconcileReq{}).Err)
+	assert.Equal(t, apperror.ErrWalletNotFound, walletService.ReconcileWallets(context.Background(), admin, request.ReconcileReq{WalletIds: []string{"missing"}}).Err)
+}
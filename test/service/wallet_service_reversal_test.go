@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func transferForReversal(t *testing.T, walletService service.IWalletService, db *gorm.DB, amount uint) string {
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_sender", UserId: "jana", Balance: 10000, Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_receiver", UserId: "rathan", Currency: "SGD"}).Error)
+	res := walletService.TransferMoney(context.Background(), admin, "wallet_sender", request.TransferReq{Amount: amount, CounterpartyWalletId: "wallet_receiver"}, "")
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+
+	var transferOut entity.TrxEntity
+	require.NoError(t, db.First(&transferOut, "id = ?", res.Data.(response.TrxResponse).TransactionId).Error)
+	return transferOut.GroupId
+}
+
+func walletBalance(t *testing.T, db *gorm.DB, walletId string) uint {
+	var wallet entity.WalletEntity
+	require.NoError(t, db.First(&wallet, "id = ?", walletId).Error)
+	return wallet.Balance
+}
+
+func TestReverseTransfer_fullThenAgain(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	groupId := transferForReversal(t, walletService, db, 4000)
+
+	res := walletService.ReverseTransfer(context.Background(), auth.Principal{UserId: "rathan"}, groupId, request.ReverseTransferReq{}, "")
+
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_sender"))
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_receiver"))
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_sender")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_receiver")
+	assertLedgerBalances(t, db)
+
+	var originals []entity.TrxEntity
+	require.NoError(t, db.Where("group_id = ?", groupId).Find(&originals).Error)
+	for _, trx := range originals {
+		assert.Equal(t, uint(4000), trx.ReversedAmount)
+	}
+	var reversals []entity.TrxEntity
+	require.NoError(t, db.Where("reversal_of = ?", groupId).Find(&reversals).Error)
+	assert.Len(t, reversals, 2)
+
+	assert.Equal(t, apperror.ErrTransferAlreadyReversed, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{}, "").Err)
+}
+
+func TestReverseTransfer_partial(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	groupId := transferForReversal(t, walletService, db, 4000)
+
+	require.Equal(t, 0, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{Amount: 1000}, "").Err.Code)
+	assert.Equal(t, uint(7000), walletBalance(t, db, "wallet_sender"))
+	assert.Equal(t, apperror.ErrReversalExceedsTransfer, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{Amount: 3001}, "").Err)
+
+	require.Equal(t, 0, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{Amount: 3000}, "").Err.Code)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_sender"))
+	assert.Equal(t, apperror.ErrTransferAlreadyReversed, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{Amount: 1}, "").Err)
+}
+
+func TestReverseTransfer_onlyReceiverOrAdmin(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	groupId := transferForReversal(t, walletService, db, 4000)
+
+	assert.Equal(t, apperror.ErrForbidden, walletService.ReverseTransfer(context.Background(), auth.Principal{UserId: "jana"}, groupId, request.ReverseTransferReq{}, "").Err)
+	assert.Equal(t, apperror.ErrTransferNotFound, walletService.ReverseTransfer(context.Background(), admin, "no-such-group", request.ReverseTransferReq{}, "").Err)
+}
+
+func TestReverseTransfer_insufficientFundsNeedsForce(t *testing.T) {
+	walletService, ledgerRepo, db := newLedgerTestService(t)
+	groupId := transferForReversal(t, walletService, db, 4000)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_receiver", request.TrxReq{Amount: 3000}, "").Err.Code)
+
+	assert.Equal(t, apperror.ErrReversalInsufficientFunds, walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{}, "").Err)
+	assert.Equal(t, apperror.ErrForbidden, walletService.ReverseTransfer(context.Background(), auth.Principal{UserId: "rathan"}, groupId, request.ReverseTransferReq{Force: true}, "").Err)
+
+	res := walletService.ReverseTransfer(context.Background(), admin, groupId, request.ReverseTransferReq{Force: true}, "")
+
+	require.Equal(t, 0, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(1000), res.Data.(response.TrxResponse).Amount)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_sender"))
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_receiver"))
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_sender")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_receiver")
+	assertLedgerBalances(t, db)
+
+	loss, err := ledgerRepo.FindLedgerAccountBalance(service.SystemAccount(common.SystemAccountReversalLoss, "SGD").ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-3000), loss)
+}
@@ -0,0 +1,164 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newSpendingLimitTestService(t *testing.T) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, "spending_limit_test")
+
+	cfg := &config.AppConfig{Limits: config.LimitsConfig{
+		SpendingLimits: config.SpendingLimits{PerTransaction: 800, Daily: 1000, Weekly: 2500},
+		Currencies:     map[string]config.SpendingLimits{"jpy": {PerTransaction: 80000}},
+	}, Holds: config.HoldsConfig{DefaultTtl: time.Hour, MaxTtl: 24 * time.Hour}}
+	walletService := testdb.NewWalletService(db, cfg)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", UserId: "rathan", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	return walletService, db
+}
+
+func spendingLimitDetails(t *testing.T, appErr apperror.AppError) apperror.SpendingLimitDetails {
+	require.Equal(t, apperror.ErrSpendingLimitExceeded.Code, appErr.Code)
+	details, ok := appErr.Details.(apperror.SpendingLimitDetails)
+	require.True(t, ok)
+	return details
+}
+
+func TestSpendingLimits_perTransaction(t *testing.T) {
+	walletService, db := newSpendingLimitTestService(t)
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 801}, "")
+	details := spendingLimitDetails(t, result.Err)
+	assert.Equal(t, service.SpendingLimitPerTransaction, details.Window)
+	assert.Equal(t, uint(800), details.Limit)
+	assert.Equal(t, uint(800), details.Remaining)
+	assert.Equal(t, "SGD", details.Currency)
+	assert.Nil(t, details.ResetsAt)
+
+	result = walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 801, CounterpartyWalletId: "wallet_counterparty"}, "")
+	assert.Equal(t, service.SpendingLimitPerTransaction, spendingLimitDetails(t, result.Err).Window)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestSpendingLimits_holdCapture(t *testing.T) {
+	walletService, db := newSpendingLimitTestService(t)
+
+	hold := createTestHold(t, walletService, "wallet_mine", 900)
+	result := walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{})
+	assert.Equal(t, service.SpendingLimitPerTransaction, spendingLimitDetails(t, result.Err).Window)
+
+	require.Equal(t, 0, walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{Amount: 700}).Err.Code)
+	hold = createTestHold(t, walletService, "wallet_mine", 500)
+	result = walletService.CaptureHold(context.Background(), admin, hold.HoldId, request.CaptureHoldReq{CounterpartyWalletId: "wallet_counterparty"})
+	assert.Equal(t, service.SpendingLimitDaily, spendingLimitDetails(t, result.Err).Window)
+	assert.Equal(t, uint(9300), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestSpendingLimits_dailySlidingWindow(t *testing.T) {
+	walletService, db := newSpendingLimitTestService(t)
+
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 600}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_counterparty"}, "").Err.Code)
+
+	var lastDebit entity.TrxEntity
+	require.NoError(t, db.Where("wallet_id = ? AND trx_type = ?", "wallet_mine", "transfer_out").First(&lastDebit).Error)
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 200}, "")
+	details := spendingLimitDetails(t, result.Err)
+	assert.Equal(t, service.SpendingLimitDaily, details.Window)
+	assert.Equal(t, uint(1000), details.Limit)
+	assert.Equal(t, uint(100), details.Remaining)
+	require.NotNil(t, details.ResetsAt)
+	assert.WithinDuration(t, lastDebit.CreatedAt.Add(24*time.Hour), *details.ResetsAt, time.Second)
+
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err.Code)
+
+	// the debits leave the daily window but still count for the week
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Where("wallet_id = ?", "wallet_mine").Update("created_at", time.Now().UTC().Add(-25*time.Hour)).Error)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 800}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 200}, "").Err.Code)
+
+	limits := walletService.GetSpendingLimits(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine").Data.(response.SpendingLimitsResponse)
+	assert.Equal(t, uint(1000), limits.DailySpent)
+	assert.Equal(t, uint(2000), limits.WeeklySpent)
+	assert.Nil(t, limits.Override)
+}
+
+func TestSpendingLimits_weeklyWindow(t *testing.T) {
+	walletService, db := newSpendingLimitTestService(t)
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 800}, "").Err.Code)
+		require.NoError(t, db.Model(&entity.TrxEntity{}).Where("wallet_id = ? AND created_at > ?", "wallet_mine", time.Now().UTC().Add(-time.Hour)).Update("created_at", time.Now().UTC().Add(-time.Duration(3-i)*24*time.Hour)).Error)
+	}
+	// the deposit was moved out of the window too; only debits count
+
+	result := walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 200}, "")
+	details := spendingLimitDetails(t, result.Err)
+	assert.Equal(t, service.SpendingLimitWeekly, details.Window)
+	assert.Equal(t, uint(100), details.Remaining)
+	require.NotNil(t, details.ResetsAt)
+	assert.WithinDuration(t, time.Now().UTC().Add(6*24*time.Hour), *details.ResetsAt, time.Minute)
+
+	// rows older than a week no longer count
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Where("wallet_id = ?", "wallet_mine").Update("created_at", time.Now().UTC().Add(-8*24*time.Hour)).Error)
+	assert.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 200}, "").Err.Code)
+}
+
+func TestSpendingLimits_currencyDefaultAndOverride(t *testing.T) {
+	walletService, db := newSpendingLimitTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_yen", UserId: "jana", Currency: "JPY", Balance: 0}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_yen", request.TrxReq{Amount: 200000}, "").Err.Code)
+
+	// JPY has its own defaults, without daily and weekly limits
+	assert.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_yen", request.TrxReq{Amount: 50000}, "").Err.Code)
+	assert.Equal(t, service.SpendingLimitPerTransaction, spendingLimitDetails(t, walletService.WithdrawMoney(context.Background(), admin, "wallet_yen", request.TrxReq{Amount: 80001}, "").Err).Window)
+
+	unlimited, perTransaction := uint(0), uint(5000)
+	forbidden := walletService.SetSpendingLimits(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", request.SpendingLimitReq{Daily: &unlimited, Reason: "vip"})
+	assert.Equal(t, apperror.ErrForbidden, forbidden.Err)
+
+	set := walletService.SetSpendingLimits(context.Background(), admin, "wallet_mine", request.SpendingLimitReq{PerTransaction: &perTransaction, Daily: &unlimited, Reason: "vip"})
+	require.Equal(t, 0, set.Err.Code)
+	limits := set.Data.(response.SpendingLimitsResponse)
+	assert.Equal(t, uint(5000), limits.PerTransaction)
+	assert.Equal(t, uint(0), limits.Daily)
+	assert.Equal(t, uint(2500), limits.Weekly)
+	require.NotNil(t, limits.Override)
+	assert.Equal(t, "vip", limits.Override.Reason)
+	assert.Equal(t, admin.UserId, limits.Override.UpdatedBy)
+
+	assert.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 2500}, "").Err.Code)
+	assert.Equal(t, service.SpendingLimitWeekly, spendingLimitDetails(t, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1}, "").Err).Window)
+
+	deleted := walletService.DeleteSpendingLimits(context.Background(), admin, "wallet_mine")
+	require.Equal(t, 0, deleted.Err.Code)
+	assert.Nil(t, deleted.Data.(response.SpendingLimitsResponse).Override)
+	assert.Equal(t, uint(1000), deleted.Data.(response.SpendingLimitsResponse).Daily)
+}
+
+func TestSpendingLimits_invalidOverride(t *testing.T) {
+	walletService, _ := newSpendingLimitTestService(t)
+
+	daily := uint(3000)
+	result := walletService.SetSpendingLimits(context.Background(), admin, "wallet_mine", request.SpendingLimitReq{Daily: &daily, Reason: "typo"})
+	assert.Equal(t, apperror.ErrInvalidSpendingLimit, result.Err)
+
+	result = walletService.SetSpendingLimits(context.Background(), admin, "wallet_missing", request.SpendingLimitReq{Daily: &daily, Reason: "typo"})
+	assert.Equal(t, apperror.ErrWalletNotFound, result.Err)
+}
@@ -0,0 +1,64 @@
+package service_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/config"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// On a sqlite file there are no row locks; concurrent withdrawals must still be serialized by the
+// database write lock taken when each transaction begins.
+func TestWithdrawMoney_concurrentOnSqliteFile(t *testing.T) {
+	db, err := appdb.InitDb(&config.DatabaseConfig{Driver: appdb.DriverSqlite, Path: filepath.Join(t.TempDir(), "wallet.db")})
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", Currency: "SGD", Balance: 20000}).Error)
+
+	walletService := testdb.NewWalletService(db, &config.AppConfig{})
+
+	const count = 10
+	results := make(chan response.ResonseWrapper, count)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "")
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	successes, insufficient := 0, 0
+	for res := range results {
+		switch res.Err {
+		case apperror.AppError{}:
+			successes++
+		case apperror.ErrInsufficientAmount:
+			insufficient++
+		default:
+			t.Errorf("unexpected error: %v", res.Err)
+		}
+	}
+	assert.Equal(t, 2, successes)
+	assert.Equal(t, count-2, insufficient)
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestInitDb_rejectsUnknownDriver(t *testing.T) {
+	_, err := appdb.InitDb(&config.DatabaseConfig{Driver: "mysql"})
+	assert.Error(t, err)
+
+	_, err = appdb.InitDb(&config.DatabaseConfig{Driver: appdb.DriverSqlite})
+	assert.Error(t, err)
+}
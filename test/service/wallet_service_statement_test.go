@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/statement"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedStatementWallet books a deposit and a withdrawal before the statement period and a deposit,
+// a hold, a transfer out and a transfer in inside it.
+func seedStatementWallet(t *testing.T) (from time.Time, to time.Time, exportStatement func(req request.StatementReq) (*bytes.Buffer, apperror.AppError)) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", UserId: "rathan", Currency: "SGD"}).Error)
+
+	from = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to = time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 2500}, "").Err.Code)
+	backdateTrxs(t, db, from.Add(-48*time.Hour))
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1999}, "").Err.Code)
+	require.Equal(t, 0, walletService.CreateHold(context.Background(), admin, "wallet_mine", request.CreateHoldReq{Amount: 100, ExpiresInSeconds: 600}).Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 4000, CounterpartyWalletId: "wallet_counterparty"}, "").Err.Code)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_counterparty", request.TrxReq{Amount: 500}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_counterparty", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_mine"}, "").Err.Code)
+	backdateTrxs(t, db, from.Add(time.Hour))
+
+	// outside the period on the other side
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 7}, "").Err.Code)
+
+	exportStatement = func(req request.StatementReq) (*bytes.Buffer, apperror.AppError) {
+		out := &bytes.Buffer{}
+		res := walletService.ExportStatement(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", req, out)
+		return out, res.Err
+	}
+	return from, to, exportStatement
+}
+
+// backdateTrxs moves every transaction not yet backdated to base, one second apart in insertion order.
+func backdateTrxs(t *testing.T, db *gorm.DB, base time.Time) {
+	var trxs []entity.TrxEntity
+	require.NoError(t, db.Where("created_at > ?", base).Order("created_at, id").Find(&trxs).Error)
+	for i, trx := range trxs {
+		require.NoError(t, db.Model(&entity.TrxEntity{}).Where("id = ?", trx.ID).Update("created_at", base.Add(time.Duration(i)*time.Second)).Error)
+	}
+}
+
+func TestExportStatement_csv(t *testing.T) {
+	from, to, exportStatement := seedStatementWallet(t)
+
+	out, appErr := exportStatement(request.StatementReq{From: &from, To: &to})
+	require.Equal(t, 0, appErr.Code)
+
+	rows, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+	assert.Equal(t, []string{"booked_at", "transaction_id", "trx_type", "direction", "amount", "running_balance", "currency", "counterparty_wallet_id", "group_id"}, rows[0])
+	assert.Equal(t, "opening_balance", rows[1][2])
+	assert.Equal(t, "75.00", rows[1][5])
+
+	assert.Equal(t, []string{"deposit", "credit", "19.99", "94.99"}, rows[2][2:6])
+	assert.Equal(t, []string{"transfer_out", "debit", "40.00", "54.99"}, rows[3][2:6])
+	assert.Equal(t, "wallet_counterparty", rows[3][7])
+	assert.NotEmpty(t, rows[3][8])
+	assert.Equal(t, []string{"transfer_in", "credit", "3.00", "57.99"}, rows[4][2:6])
+
+	assert.Equal(t, "closing_balance", rows[5][2])
+	assert.Equal(t, "57.99", rows[5][5])
+}
+
+func TestExportStatement_ndjson(t *testing.T) {
+	from, to, exportStatement := seedStatementWallet(t)
+
+	out, appErr := exportStatement(request.StatementReq{From: &from, To: &to, Format: statement.FormatNdjson})
+	require.Equal(t, 0, appErr.Code)
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 5)
+
+	assert.Equal(t, statement.RecordTypeHeader, records[0]["RecordType"])
+	assert.Equal(t, "SGD", records[0]["Currency"])
+	assert.EqualValues(t, 2, records[0]["Exponent"])
+	assert.EqualValues(t, 7500, records[0]["OpeningBalance"])
+	assert.EqualValues(t, 5799, records[0]["ClosingBalance"])
+
+	for i, running := range []float64{9499, 5499, 5799} {
+		assert.Equal(t, statement.RecordTypeEntry, records[i+1]["RecordType"])
+		assert.EqualValues(t, running, records[i+1]["RunningBalance"])
+	}
+
+	footer := records[4]
+	assert.Equal(t, statement.RecordTypeFooter, footer["RecordType"])
+	assert.EqualValues(t, 5799, footer["ClosingBalance"])
+	assert.EqualValues(t, 3, footer["EntryCount"])
+	assert.EqualValues(t, 2299, footer["TotalCredits"])
+	assert.EqualValues(t, 4000, footer["TotalDebits"])
+}
+
+func TestExportStatement_camt053(t *testing.T) {
+	from, to, exportStatement := seedStatementWallet(t)
+
+	out, appErr := exportStatement(request.StatementReq{From: &from, To: &to, Format: statement.FormatCamt053})
+	require.Equal(t, 0, appErr.Code)
+
+	var document struct {
+		XMLName xml.Name
+		Stmt    struct {
+			AcctId   string `xml:"Acct>Id>Othr>Id"`
+			Currency string `xml:"Acct>Ccy"`
+			From     string `xml:"FrToDt>FrDtTm"`
+			Bal      []struct {
+				Code      string `xml:"Tp>CdOrPrtry>Cd"`
+				Amount    string `xml:"Amt"`
+				CdtDbtInd string `xml:"CdtDbtInd"`
+			} `xml:"Bal"`
+			Ntry []struct {
+				Amount struct {
+					Value    string `xml:",chardata"`
+					Currency string `xml:"Ccy,attr"`
+				} `xml:"Amt"`
+				CdtDbtInd string `xml:"CdtDbtInd"`
+				Code      string `xml:"BkTxCd>Prtry>Cd"`
+			} `xml:"Ntry"`
+		} `xml:"BkToCstmrStmt>Stmt"`
+	}
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &document))
+	assert.Equal(t, "urn:iso:std:iso:20022:tech:xsd:camt.053.001.02", document.XMLName.Space)
+	assert.Equal(t, "wallet_mine", document.Stmt.AcctId)
+	assert.Equal(t, "SGD", document.Stmt.Currency)
+	assert.Equal(t, "2024-03-01T00:00:00", document.Stmt.From)
+
+	require.Len(t, document.Stmt.Bal, 2)
+	assert.Equal(t, "OPBD", document.Stmt.Bal[0].Code)
+	assert.Equal(t, "75.00", document.Stmt.Bal[0].Amount)
+	assert.Equal(t, "CLBD", document.Stmt.Bal[1].Code)
+	assert.Equal(t, "57.99", document.Stmt.Bal[1].Amount)
+	assert.Equal(t, "CRDT", document.Stmt.Bal[1].CdtDbtInd)
+
+	require.Len(t, document.Stmt.Ntry, 3)
+	assert.Equal(t, "DBIT", document.Stmt.Ntry[1].CdtDbtInd)
+	assert.Equal(t, "40.00", document.Stmt.Ntry[1].Amount.Value)
+	assert.Equal(t, "SGD", document.Stmt.Ntry[1].Amount.Currency)
+	assert.Equal(t, "transfer_out", document.Stmt.Ntry[1].Code)
+}
+
+func TestExportStatement_defaultsToNow(t *testing.T) {
+	from, _, exportStatement := seedStatementWallet(t)
+
+	out, appErr := exportStatement(request.StatementReq{From: &from})
+	require.Equal(t, 0, appErr.Code)
+
+	rows, err := csv.NewReader(out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 7)
+	assert.Equal(t, "58.06", rows[6][5])
+}
+
+func TestExportStatement_rejectedBeforeWriting(t *testing.T) {
+	from, to, exportStatement := seedStatementWallet(t)
+
+	out, appErr := exportStatement(request.StatementReq{From: &to, To: &from})
+	assert.Equal(t, apperror.ErrInvalidStatementRange, appErr)
+	assert.Zero(t, out.Len())
+
+	out, appErr = exportStatement(request.StatementReq{From: &from, To: &to, Format: "pdf"})
+	assert.Equal(t, apperror.ErrInvalidStatementFormat, appErr)
+	assert.Zero(t, out.Len())
+}
+
+func TestExportStatement_forbidden(t *testing.T) {
+	walletService, _, db := newLedgerTestService(t)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	from := time.Now().Add(-time.Hour)
+
+	out := &bytes.Buffer{}
+	res := walletService.ExportStatement(context.Background(), auth.Principal{UserId: "rathan"}, "wallet_mine", request.StatementReq{From: &from}, out)
+	assert.Equal(t, apperror.ErrForbidden, res.Err)
+	assert.Zero(t, out.Len())
+}
+
+// phantomTrxRepo streams a row the balances did not count, as a row committed while a statement was
+// streamed would be without one snapshot for both.
+type phantomTrxRepo struct {
+	repo.ITrxRepo
+}
+
+func (r phantomTrxRepo) BindTx(tx *gorm.DB) repo.ITrxRepo {
+	return phantomTrxRepo{ITrxRepo: r.ITrxRepo.BindTx(tx)}
+}
+
+func (r phantomTrxRepo) StreamTrxs(ctx context.Context, walletId string, from time.Time, to time.Time, fn func(trx entity.TrxEntity) error) error {
+	if err := r.ITrxRepo.StreamTrxs(ctx, walletId, from, to, fn); err != nil {
+		return err
+	}
+	return fn(entity.TrxEntity{ID: "trx_phantom", WalletId: walletId, Amount: 1, Currency: "SGD", TrxType: common.TrxTypeDeposit, CreatedAt: to.Add(-time.Second)})
+}
+
+func TestExportStatement_failsWhenRowsDoNotAddUpToClosing(t *testing.T) {
+	db := testdb.Open(t, "statement_phantom_test")
+	walletService := testdb.NewWalletService(db, &config.AppConfig{},
+		func(deps *testdb.WalletServiceDeps) { deps.TrxRepo = phantomTrxRepo{ITrxRepo: deps.TrxRepo} })
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+	from := time.Now().Add(-time.Hour)
+
+	out := &bytes.Buffer{}
+	res := walletService.ExportStatement(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", request.StatementReq{From: &from}, out)
+	assert.Equal(t, apperror.ErrInternalServer, res.Err)
+}
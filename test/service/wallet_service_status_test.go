@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newStatusTestService(t *testing.T) (service.IWalletService, repo.ILedgerRepo, *gorm.DB) {
+	db := testdb.Open(t, "status_test")
+
+	deps := testdb.Deps(db, &config.AppConfig{})
+	walletService := deps.NewWalletService()
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_counterparty", UserId: "rathan", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_jpy", UserId: "jana", Currency: "JPY"}).Error)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 10000}, "").Err.Code)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_counterparty", request.TrxReq{Amount: 10000}, "").Err.Code)
+	return walletService, deps.LedgerRepo, db
+}
+
+func changeStatus(t *testing.T, walletService service.IWalletService, walletId string, req request.WalletStatusReq) response.WalletStatusChangeResponse {
+	result := walletService.ChangeWalletStatus(context.Background(), admin, walletId, req)
+	require.Equal(t, 0, result.Err.Code)
+	return result.Data.(response.WalletStatusChangeResponse)
+}
+
+func TestWalletStatus_frozenDebits(t *testing.T) {
+	walletService, _, db := newStatusTestService(t)
+	changeStatus(t, walletService, "wallet_mine", request.WalletStatusReq{Status: "frozen_debits", ReasonCode: "compliance_review"})
+
+	assert.Equal(t, apperror.ErrWalletFrozen, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err)
+	assert.Equal(t, apperror.ErrWalletFrozen, walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 100, CounterpartyWalletId: "wallet_counterparty"}, "").Err)
+	assert.Equal(t, apperror.ErrWalletFrozen, walletService.CreateHold(context.Background(), admin, "wallet_mine", request.CreateHoldReq{Amount: 100, ExpiresInSeconds: 600}).Err)
+
+	// money still comes in
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_counterparty", request.TransferReq{Amount: 100, CounterpartyWalletId: "wallet_mine"}, "").Err.Code)
+	assert.Equal(t, uint(10200), walletBalance(t, db, "wallet_mine"))
+
+	balance := walletService.GetBalance(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine").Data.(response.WalletResponse)
+	assert.Equal(t, common.WalletStatusFrozenDebits, balance.Status)
+}
+
+func TestWalletStatus_frozenThenUnfrozen(t *testing.T) {
+	walletService, _, db := newStatusTestService(t)
+	changeStatus(t, walletService, "wallet_mine", request.WalletStatusReq{Status: "frozen", ReasonCode: "suspected_fraud"})
+
+	assert.Equal(t, apperror.ErrWalletFrozen, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err)
+	assert.Equal(t, apperror.ErrCounterpartyWalletFrozen, walletService.TransferMoney(context.Background(), admin, "wallet_counterparty", request.TransferReq{Amount: 100, CounterpartyWalletId: "wallet_mine"}, "").Err)
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(10000), walletBalance(t, db, "wallet_counterparty"))
+
+	changeStatus(t, walletService, "wallet_mine", request.WalletStatusReq{Status: "active", ReasonCode: "review_cleared", Note: "case 42"})
+	require.Equal(t, 0, walletService.WithdrawMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err.Code)
+
+	changes := walletService.GetWalletStatusChanges(context.Background(), admin, "wallet_mine").Data.([]response.WalletStatusChangeResponse)
+	require.Equal(t, 2, len(changes))
+	statuses := map[common.WalletStatus]response.WalletStatusChangeResponse{}
+	for _, change := range changes {
+		statuses[change.ToStatus] = change
+	}
+	assert.Equal(t, common.WalletStatusActive, statuses[common.WalletStatusFrozen].FromStatus)
+	assert.Equal(t, common.WalletStatusReasonSuspectedFraud, statuses[common.WalletStatusFrozen].ReasonCode)
+	assert.Equal(t, "case 42", statuses[common.WalletStatusActive].Note)
+	assert.Equal(t, admin.UserId, statuses[common.WalletStatusActive].Actor)
+}
+
+func TestWalletStatus_closeNeedsZeroBalanceOrSweep(t *testing.T) {
+	walletService, ledgerRepo, db := newStatusTestService(t)
+	closeReq := request.WalletStatusReq{Status: "closed", ReasonCode: "customer_request"}
+
+	assert.Equal(t, apperror.ErrWalletBalanceNotZero, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", closeReq).Err)
+
+	hold := walletService.CreateHold(context.Background(), admin, "wallet_mine", request.CreateHoldReq{Amount: 100, ExpiresInSeconds: 600})
+	require.Equal(t, 0, hold.Err.Code)
+	closeReq.SweepToWalletId = "wallet_counterparty"
+	assert.Equal(t, apperror.ErrWalletHasActiveHolds, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", closeReq).Err)
+	require.Equal(t, 0, walletService.ReleaseHold(context.Background(), admin, hold.Data.(response.HoldResponse).HoldId).Err.Code)
+
+	closeReq.SweepToWalletId = "wallet_jpy"
+	assert.Equal(t, apperror.ErrInvalidSweepWallet, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", closeReq).Err)
+
+	closeReq.SweepToWalletId = "wallet_counterparty"
+	change := changeStatus(t, walletService, "wallet_mine", closeReq)
+	assert.Equal(t, common.WalletStatusClosed, change.ToStatus)
+	require.NotEmpty(t, change.SweepTrxId)
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(20000), walletBalance(t, db, "wallet_counterparty"))
+
+	var sweepTrx entity.TrxEntity
+	require.NoError(t, db.First(&sweepTrx, "id = ?", change.SweepTrxId).Error)
+	assert.Equal(t, common.TrxTypeTransferOut, sweepTrx.TrxType)
+	assert.Equal(t, uint(10000), sweepTrx.Amount)
+
+	assert.Equal(t, apperror.ErrWalletClosed, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 100}, "").Err)
+	assert.Equal(t, apperror.ErrCounterpartyWalletClosed, walletService.TransferMoney(context.Background(), admin, "wallet_counterparty", request.TransferReq{Amount: 100, CounterpartyWalletId: "wallet_mine"}, "").Err)
+	assert.Equal(t, apperror.ErrWalletStatusTransitionNotAllowed, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "active", ReasonCode: "customer_request"}).Err)
+
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_mine")
+	assertWalletMatchesLedger(t, db, ledgerRepo, "wallet_counterparty")
+	assertLedgerBalances(t, db)
+}
+
+func TestWalletStatus_closeEmptyWallet(t *testing.T) {
+	walletService, _, _ := newStatusTestService(t)
+
+	change := changeStatus(t, walletService, "wallet_jpy", request.WalletStatusReq{Status: "closed", ReasonCode: "dormant"})
+	assert.Empty(t, change.SweepTrxId)
+}
+
+func TestWalletStatus_invalidRequests(t *testing.T) {
+	walletService, _, _ := newStatusTestService(t)
+
+	req := request.WalletStatusReq{Status: "frozen", ReasonCode: "legal_order"}
+	assert.Equal(t, apperror.ErrForbidden, walletService.ChangeWalletStatus(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine", req).Err)
+	assert.Equal(t, apperror.ErrForbidden, walletService.GetWalletStatusChanges(context.Background(), auth.Principal{UserId: "jana"}, "wallet_mine").Err)
+	assert.Equal(t, apperror.ErrWalletNotFound, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_missing", req).Err)
+
+	assert.Equal(t, apperror.ErrInvalidWalletStatus, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "suspended", ReasonCode: "legal_order"}).Err)
+	assert.Equal(t, apperror.ErrInvalidWalletStatusReason, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "frozen", ReasonCode: "because"}).Err)
+	assert.Equal(t, apperror.ErrInvalidWalletStatusReason, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "frozen", ReasonCode: "other"}).Err)
+	assert.Equal(t, apperror.ErrInvalidSweepWallet, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "frozen", ReasonCode: "legal_order", SweepToWalletId: "wallet_counterparty"}).Err)
+	assert.Equal(t, apperror.ErrWalletStatusTransitionNotAllowed, walletService.ChangeWalletStatus(context.Background(), admin, "wallet_mine", request.WalletStatusReq{Status: "active", ReasonCode: "review_cleared"}).Err)
+}
@@ -1,52 +1,62 @@
 package service_test
 
 import (
+	"context"
 	"testing"
 	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	appdb "wallet-app/db"
 	"wallet-app/entity"
-	"wallet-app/mapper"
 	"wallet-app/request"
 	"wallet-app/response"
-	"wallet-app/service"
 	mock_test "wallet-app/test/mock"
 
 	"github.com/glebarez/sqlite"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 )
 
 func TestCreateWallet(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	const userId = "jana"
-	req := request.CreateWalletReq{UserId: userId}
+	req := request.CreateWalletReq{Currency: "sgd"}
 
-	mockWalletRepo.On("SaveWallet", mock.Anything, mock.Anything).Return(nil)
+	mocks.walletRepo.On("SaveWallet", mock.Anything, mock.Anything).Return(nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.CreateWallet(req)
+	result := service.CreateWallet(context.Background(), auth.Principal{UserId: userId}, req)
 	assert.Equal(t, 0, result.Err.Code)
-	assert.Equal(t, userId, result.Data.(entity.WalletEntity).UserId)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	assert.Equal(t, userId, result.Data.(response.WalletResponse).UserId)
+	assert.Equal(t, "SGD", result.Data.(response.WalletResponse).Currency)
+	assert.Equal(t, 2, result.Data.(response.WalletResponse).Exponent)
+	assert.Equal(t, common.WalletStatusActive, result.Data.(response.WalletResponse).Status)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+}
+
+func TestCreateWallet_unsupportedCurrency(t *testing.T) {
+	mocks := newWalletServiceMocks()
+
+	req := request.CreateWalletReq{UserId: "jana", Currency: "XYZ"}
+
+	service := mocks.walletService()
+
+	result := service.CreateWallet(context.Background(), admin, req)
+	assert.Equal(t, 400, result.Err.Code)
+	assert.Equal(t, apperror.ErrUnsupportedCurrency.Message, result.Err.Message)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
 }
 
 func TestGetWalletsByUserId_found(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	const userIdJana = "jana"
 
@@ -54,172 +64,143 @@ func TestGetWalletsByUserId_found(t *testing.T) {
 	wallet2 := entity.WalletEntity{ID: "walletId2", UserId: userIdJana}
 	wallets := []entity.WalletEntity{wallet1, wallet2}
 
-	mockWalletRepo.On("FindWalletsByUserId", userIdJana).Return(wallets)
+	mocks.walletRepo.On("FindWalletsByUserId", userIdJana).Return(wallets)
+	mocks.holdRepo.On("SumActiveHoldAmount", mock.Anything, mock.Anything).Return(uint(0), nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.GetWalletsByUserId(userIdJana)
+	result := service.GetWalletsByUserId(context.Background(), admin, userIdJana)
 	assert.Equal(t, 0, result.Err.Code)
-	assert.Equal(t, 2, len(result.Data.([]entity.WalletEntity)))
-	assert.Equal(t, userIdJana, result.Data.([]entity.WalletEntity)[0].UserId)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	assert.Equal(t, 2, len(result.Data.([]response.WalletResponse)))
+	assert.Equal(t, userIdJana, result.Data.([]response.WalletResponse)[0].UserId)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
 }
 
 func TestGetWalletsByUserId_notFound(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	const userIdNone = "none"
 
 	wallets := []entity.WalletEntity{}
 
-	mockWalletRepo.On("FindWalletsByUserId", userIdNone).Return(wallets)
+	mocks.walletRepo.On("FindWalletsByUserId", userIdNone).Return(wallets)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.GetWalletsByUserId(userIdNone)
+	result := service.GetWalletsByUserId(context.Background(), admin, userIdNone)
 	assert.Equal(t, 0, result.Err.Code)
-	assert.Equal(t, 0, len(result.Data.([]entity.WalletEntity)))
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	assert.Equal(t, 0, len(result.Data.([]response.WalletResponse)))
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
 }
 
 func TestDepositMoney_walletNotFound(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "walletIdNot"
 	amount := uint(1000)
 	req := request.TrxReq{Amount: amount}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.DepositMoney(walletId, req)
+	result := service.DepositMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 400, result.Err.Code)
 	assert.Equal(t, apperror.ErrWalletNotFound.Message, result.Err.Message)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
 }
 
 func TestDepositMoney_Success(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet123"
 	amount := uint(1000)
 	req := request.TrxReq{Amount: amount}
 	wallet := entity.WalletEntity{ID: walletId, Balance: 5000}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
-	mockWalletRepo.On("SaveWalletWithTx", mock.Anything, mock.Anything).Return(nil)
-	mockTrxRepo.On("SaveTrxWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.walletRepo.On("SaveWalletWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.ledgerRepo.On("EnsureLedgerAccountWithTx", mock.Anything, mock.Anything).Return(false, nil)
+	mocks.ledgerRepo.On("SaveLedgerEntriesWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.trxRepo.On("SaveTrxWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.outboxRepo.On("SaveOutboxEventWithTx", mock.Anything, mock.Anything).Return(nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.DepositMoney(walletId, req)
+	result := service.DepositMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 0, result.Err.Code)
 	assert.Equal(t, walletId, result.Data.(response.TrxResponse).WalletId)
 	assert.Equal(t, uint(6000), result.Data.(response.TrxResponse).CurrentBalance)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.outboxRepo.AssertExpectations(t)
 }
 
 func TestWithdrawMoney_InsufficientAmount(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet123"
 	req := request.TrxReq{Amount: uint(10000)}
 	wallet := entity.WalletEntity{ID: walletId, Balance: 5000}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.WithdrawMoney(walletId, req)
+	result := service.WithdrawMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 400, result.Err.Code)
 	assert.Equal(t, apperror.ErrInsufficientAmount.Message, result.Err.Message)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
 }
 
 func TestWithdrawMoney_success(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet123"
 	amount := uint(10000)
 	req := request.TrxReq{Amount: amount}
 	wallet := entity.WalletEntity{ID: walletId, Balance: 20000}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
-	mockWalletRepo.On("SaveWalletWithTx", mock.Anything, mock.Anything).Return(nil)
-	mockTrxRepo.On("SaveTrxWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
+	mocks.walletRepo.On("SaveWalletWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.ledgerRepo.On("EnsureLedgerAccountWithTx", mock.Anything, mock.Anything).Return(false, nil)
+	mocks.ledgerRepo.On("SaveLedgerEntriesWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.trxRepo.On("SaveTrxWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.outboxRepo.On("SaveOutboxEventWithTx", mock.Anything, mock.Anything).Return(nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.WithdrawMoney(walletId, req)
+	result := service.WithdrawMoney(context.Background(), admin, walletId, req, "")
 	assert.Equal(t, 0, result.Err.Code)
 	assert.Equal(t, uint(10000), result.Data.(response.TrxResponse).CurrentBalance)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.outboxRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
 }
 
 func TestTransferMoney_CounterpartyWalletSameAsUserWallet(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet_mine"
 	counterpartyWalletId := "wallet_mine"
@@ -227,29 +208,24 @@ func TestTransferMoney_CounterpartyWalletSameAsUserWallet(t *testing.T) {
 	req := request.TransferReq{Amount: amount, CounterpartyWalletId: counterpartyWalletId}
 	wallet := entity.WalletEntity{ID: walletId, Balance: 20000}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.TransferMoney(walletId, req)
+	result := service.TransferMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 400, result.Err.Code)
 	assert.Equal(t, apperror.ErrCounterpartyWalletCannotBeSameAsUserWallet.Message, result.Err.Message)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
 }
 
 func TestTransferMoney_CounterpartyWalletNotFound(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet_mine"
 	counterpartyWalletId := "wallet_counterparty"
@@ -257,68 +233,107 @@ func TestTransferMoney_CounterpartyWalletNotFound(t *testing.T) {
 	req := request.TransferReq{Amount: amount, CounterpartyWalletId: counterpartyWalletId}
 	wallet := entity.WalletEntity{ID: walletId, Balance: 20000}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
-	mockWalletRepo.On("FindWalletByIdWithTx", counterpartyWalletId, mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
+	mocks.walletRepo.On("FindWalletByIdWithTx", counterpartyWalletId, mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.TransferMoney(walletId, req)
+	result := service.TransferMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 400, result.Err.Code)
 	assert.Equal(t, apperror.ErrCounterpartyWalletNotFound.Message, result.Err.Message)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
 }
 
 func TestTransferMoney_success(t *testing.T) {
-	mockWalletRepo := new(mock_test.MockWalletRepo)
-	mockTrxRepo := new(mock_test.MockTrxRepo)
-	mockTxManager := new(mock_test.MockDbTxManager)
+	mocks := newWalletServiceMocks()
 
 	walletId := "wallet_mine"
 	counterpartyWalletId := "wallet_counterparty"
 	amount := uint(5000)
 	req := request.TransferReq{Amount: amount, CounterpartyWalletId: counterpartyWalletId}
-	wallet := entity.WalletEntity{ID: walletId, Balance: 20000}
-	counterpartyWallet := entity.WalletEntity{ID: counterpartyWalletId, Balance: 1000}
+	wallet := entity.WalletEntity{ID: walletId, Balance: 20000, Currency: "SGD"}
+	counterpartyWallet := entity.WalletEntity{ID: counterpartyWalletId, Balance: 1000, Currency: "SGD"}
 
-	mockTxManager.On("GetTx").Return(getTestDB(t))
-	mockWalletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
-	mockWalletRepo.On("FindWalletByIdWithTx", counterpartyWalletId, mock.Anything).Return(counterpartyWallet, nil)
-	mockWalletRepo.On("SaveWalletsWithTx", mock.Anything, mock.Anything).Return(nil)
-	mockTrxRepo.On("SaveTrxsWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
+	mocks.walletRepo.On("FindWalletByIdWithTx", counterpartyWalletId, mock.Anything).Return(counterpartyWallet, nil)
+	mocks.walletRepo.On("SaveWalletsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.ledgerRepo.On("EnsureLedgerAccountWithTx", mock.Anything, mock.Anything).Return(false, nil)
+	mocks.ledgerRepo.On("SaveLedgerEntriesWithTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.trxRepo.On("SaveTrxsWithDbTx", mock.Anything, mock.Anything).Return(nil)
+	mocks.outboxRepo.On("SaveOutboxEventWithTx", mock.Anything, mock.Anything).Return(nil)
 
-	service := service.NewWalletService(
-		logrus.New(),
-		mockWalletRepo,
-		mockTrxRepo,
-		&mapper.AppMapper{},
-		mockTxManager,
-	)
+	service := mocks.walletService()
 
-	result := service.TransferMoney(walletId, req)
+	result := service.TransferMoney(context.Background(), admin, walletId, req, "")
 
 	assert.Equal(t, 0, result.Err.Code)
 	assert.Equal(t, uint(15000), result.Data.(response.TrxResponse).CurrentBalance)
-	mockWalletRepo.AssertExpectations(t)
-	mockTrxRepo.AssertExpectations(t)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.outboxRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
+}
+
+func TestTransferMoney_CrossCurrencyWithoutQuote(t *testing.T) {
+	mocks := newWalletServiceMocks()
+
+	walletId := "wallet_mine"
+	counterpartyWalletId := "wallet_counterparty"
+	amount := uint(5000)
+	req := request.TransferReq{Amount: amount, CounterpartyWalletId: counterpartyWalletId}
+	wallet := entity.WalletEntity{ID: walletId, Balance: 20000, Currency: "SGD"}
+	counterpartyWallet := entity.WalletEntity{ID: counterpartyWalletId, Balance: 1000, Currency: "USD"}
+
+	mocks.txManager.On("GetTx").Return(getTestDB(t))
+	mocks.walletRepo.On("FindWalletByIdWithTx", walletId, mock.Anything).Return(wallet, nil)
+	mocks.holdRepo.On("SumActiveHoldAmountWithTx", walletId, mock.Anything, mock.Anything).Return(uint(0), nil)
+	mocks.walletRepo.On("FindWalletByIdWithTx", counterpartyWalletId, mock.Anything).Return(counterpartyWallet, nil)
+
+	service := mocks.walletService()
+
+	result := service.TransferMoney(context.Background(), admin, walletId, req, "")
+
+	assert.Equal(t, 400, result.Err.Code)
+	assert.Equal(t, apperror.ErrFxQuoteRequired.Message, result.Err.Message)
+	mocks.walletRepo.AssertExpectations(t)
+	mocks.trxRepo.AssertExpectations(t)
+	mocks.ledgerRepo.AssertExpectations(t)
+	mocks.holdRepo.AssertExpectations(t)
 }
 
+var admin = auth.Principal{UserId: "ops", Roles: []string{auth.RoleAdmin}}
+
 func getTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("failed to connect to in-memory DB: %v", err)
 	}
-	err = db.AutoMigrate(&entity.WalletEntity{}, &entity.TrxEntity{})
+	err = appdb.Migrate(db)
 	if err != nil {
 		t.Fatalf("failed to auto-migrate: %v", err)
 	}
 	return db
 }
+
+// noSpendingLimits is a spending limit repo for wallets without an override.
+func noSpendingLimits() *mock_test.MockSpendingLimitRepo {
+	mockSpendingLimitRepo := new(mock_test.MockSpendingLimitRepo)
+	mockSpendingLimitRepo.On("FindSpendingLimitByWalletIdWithTx", mock.Anything, mock.Anything).Return(entity.SpendingLimitEntity{}, gorm.ErrRecordNotFound).Maybe()
+	return mockSpendingLimitRepo
+}
+
+// noFeeRules is a fee rule repo without any active rule.
+func noFeeRules() *mock_test.MockFeeRuleRepo {
+	mockFeeRuleRepo := new(mock_test.MockFeeRuleRepo)
+	mockFeeRuleRepo.On("FindActiveFeeRulesWithTx", mock.Anything, mock.Anything, mock.Anything).Return([]entity.FeeRuleEntity{}, nil).Maybe()
+	return mockFeeRuleRepo
+}
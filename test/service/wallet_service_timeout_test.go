@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// cancellingWalletRepo cancels the request while the wallet lock is being taken, as a client
+// disconnect or a deadline would during a long lock wait.
+type cancellingWalletRepo struct {
+	repo.IWalletRepo
+	cancel context.CancelFunc
+}
+
+func (r *cancellingWalletRepo) FindWalletByIdWithTx(walletId string, tx *gorm.DB) (entity.WalletEntity, error) {
+	r.cancel()
+	return r.IWalletRepo.FindWalletByIdWithTx(walletId, tx)
+}
+
+func newTimeoutTestService(t *testing.T, walletRepo repo.IWalletRepo) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, "timeout_test")
+	walletService := testdb.NewWalletService(db, &config.AppConfig{}, func(deps *testdb.WalletServiceDeps) {
+		if walletRepo != nil {
+			deps.WalletRepo = walletRepo
+		}
+	})
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD", Balance: 1000}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_other", UserId: "omar", Currency: "SGD"}).Error)
+	return walletService, db
+}
+
+func TestWithdrawMoney_cancelledContextIsATimeout(t *testing.T) {
+	walletService, db := newTimeoutTestService(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res := walletService.WithdrawMoney(ctx, admin, "wallet_mine", request.TrxReq{Amount: 300}, "")
+	assert.Equal(t, apperror.ErrRequestTimeout, res.Err)
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_mine"))
+}
+
+func TestTransferMoney_cancelledWhileLockingRollsBack(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	walletRepo := &cancellingWalletRepo{cancel: cancel}
+	walletService, db := newTimeoutTestService(t, walletRepo)
+	walletRepo.IWalletRepo = repo.NewWalletRepo(db)
+
+	res := walletService.TransferMoney(ctx, admin, "wallet_mine", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_other"}, "")
+	assert.Equal(t, apperror.ErrRequestTimeout, res.Err)
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_other"))
+	var trxs int64
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Count(&trxs).Error)
+	assert.Zero(t, trxs)
+
+	// the transaction and its connection were released
+	walletRepo.cancel = func() {}
+	res = walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_other"}, "")
+	require.Zero(t, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(300), walletBalance(t, db, "wallet_other"))
+}
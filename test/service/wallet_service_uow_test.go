@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"wallet-app/apperror"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/manager"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// panickingWalletRepo panics once the transfer has debited the caller, halfway through its
+// transaction.
+type panickingWalletRepo struct {
+	repo.IWalletRepo
+}
+
+func (r *panickingWalletRepo) SaveWalletsWithTx(wallets []entity.WalletEntity, tx *gorm.DB) error {
+	panic("wallet repo failed")
+}
+
+func newUnitOfWorkTestService(t *testing.T, name string, walletRepo func(db *gorm.DB) repo.IWalletRepo, txDb func(db *gorm.DB) *gorm.DB) (service.IWalletService, *gorm.DB) {
+	db := testdb.Open(t, name)
+	walletService := testdb.NewWalletService(db, &config.AppConfig{}, func(deps *testdb.WalletServiceDeps) {
+		deps.WalletRepo = walletRepo(db)
+		deps.DbTxManager = manager.NewDbTxManager(txDb(db))
+	})
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD", Balance: 1000}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_other", UserId: "omar", Currency: "SGD"}).Error)
+	return walletService, db
+}
+
+func TestTransferMoney_failedCommitIsAnInternalError(t *testing.T) {
+	walletService, db := newUnitOfWorkTestService(t, "uow_commit_failure_test",
+		func(db *gorm.DB) repo.IWalletRepo { return repo.NewWalletRepo(db) }, testdb.FailingCommits)
+
+	res := walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_other"}, "")
+	assert.Equal(t, apperror.ErrInternalServer, res.Err)
+	assert.Nil(t, res.Data)
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_mine"))
+	assert.Equal(t, uint(0), walletBalance(t, db, "wallet_other"))
+	var trxs int64
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Count(&trxs).Error)
+	assert.Zero(t, trxs)
+}
+
+func TestTransferMoney_panicRollsBackAndPropagates(t *testing.T) {
+	walletService, db := newUnitOfWorkTestService(t, "uow_panic_test",
+		func(db *gorm.DB) repo.IWalletRepo { return &panickingWalletRepo{IWalletRepo: repo.NewWalletRepo(db)} },
+		func(db *gorm.DB) *gorm.DB { return db })
+
+	assert.PanicsWithValue(t, "wallet repo failed", func() {
+		walletService.TransferMoney(context.Background(), admin, "wallet_mine", request.TransferReq{Amount: 300, CounterpartyWalletId: "wallet_other"}, "")
+	})
+	assert.Equal(t, uint(1000), walletBalance(t, db, "wallet_mine"))
+	var trxs int64
+	require.NoError(t, db.Model(&entity.TrxEntity{}).Count(&trxs).Error)
+	assert.Zero(t, trxs)
+
+	// the rolled back transaction no longer holds the write lock
+	res := walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 50}, "")
+	require.Zero(t, res.Err.Code, res.Err.Message)
+	assert.Equal(t, uint(1050), walletBalance(t, db, "wallet_mine"))
+}
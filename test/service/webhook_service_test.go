@@ -0,0 +1,234 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"wallet-app/apperror"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/config"
+	"wallet-app/entity"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+	"wallet-app/test/testdb"
+	"wallet-app/webhook"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newWebhookTestService(t *testing.T) (service.IWalletService, service.IWebhookService, *gorm.DB) {
+	db := testdb.Open(t, "webhook_test")
+
+	cfg := &config.AppConfig{
+		Webhooks: config.WebhooksConfig{LeaseTtl: time.Minute, Timeout: time.Second, MaxAttempts: 3, RetryBackoff: time.Minute, MaxRetryBackoff: 10 * time.Minute},
+	}
+	deps := testdb.Deps(db, cfg)
+	walletService := deps.NewWalletService()
+	webhookService := service.NewWebhookService(logrus.New(), cfg, deps.OutboxRepo, repo.NewWebhookRepo(db), webhook.NewHttpWebhookSender(cfg.Webhooks.Timeout), &mapper.AppMapper{}, deps.DbTxManager)
+
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_jana", UserId: "jana", Currency: "SGD"}).Error)
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_rathan", UserId: "rathan", Currency: "SGD"}).Error)
+	return walletService, webhookService, db
+}
+
+func outboxEvents(t *testing.T, db *gorm.DB) []entity.OutboxEventEntity {
+	var events []entity.OutboxEventEntity
+	require.NoError(t, db.Order("created_at, id").Find(&events).Error)
+	return events
+}
+
+func TestOutboxEvent_writtenWithMoneyMovement(t *testing.T) {
+	walletService, _, db := newWebhookTestService(t)
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 1000}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_jana", request.TransferReq{Amount: 400, CounterpartyWalletId: "wallet_rathan"}, "").Err.Code)
+	assert.Equal(t, apperror.ErrInsufficientAmount, walletService.WithdrawMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 5000}, "").Err)
+
+	events := outboxEvents(t, db)
+	require.Len(t, events, 2)
+	assert.Equal(t, common.EventTypeDeposit, events[0].EventType)
+	assert.Equal(t, common.EventTypeTransfer, events[1].EventType)
+
+	var payload response.WebhookEventPayload
+	require.NoError(t, json.Unmarshal([]byte(events[1].Payload), &payload))
+	assert.Equal(t, events[1].ID, payload.EventId)
+	assert.Equal(t, "wallet_jana", payload.WalletId)
+	require.Len(t, payload.Transactions, 2)
+	assert.Equal(t, common.TrxTypeTransferOut, payload.Transactions[0].TrxType)
+	assert.Equal(t, common.TrxTypeTransferIn, payload.Transactions[1].TrxType)
+}
+
+func TestDeliverWebhooks_signedPerSubscription(t *testing.T) {
+	walletService, webhookService, db := newWebhookTestService(t)
+
+	type received struct {
+		eventType string
+		body      []byte
+	}
+	receivedCh := make(chan received, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature := r.Header.Get(webhook.HeaderSignature)
+		var timestamp int64
+		fmt.Sscanf(signature, "t=%d,", &timestamp)
+		if time.Since(time.Unix(timestamp, 0)) > time.Minute || signature != webhook.Sign("shh", time.Unix(timestamp, 0), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		receivedCh <- received{eventType: r.Header.Get(webhook.HeaderEventType), body: body}
+	}))
+	defer server.Close()
+
+	deposits := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: server.URL, EventTypes: []string{"trx.deposit"}, Secret: "shh"})
+	require.Equal(t, 0, deposits.Err.Code)
+	everything := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: server.URL, Secret: "shh"})
+	require.Equal(t, 0, everything.Err.Code)
+
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 1000}, "").Err.Code)
+	require.Equal(t, 0, walletService.TransferMoney(context.Background(), admin, "wallet_jana", request.TransferReq{Amount: 400, CounterpartyWalletId: "wallet_rathan"}, "").Err.Code)
+
+	now := time.Now().UTC()
+	assert.Equal(t, 2, webhookService.DispatchOutboxEvents(now).Data)
+	assert.Equal(t, 0, webhookService.DispatchOutboxEvents(now).Data)
+	assert.Equal(t, 3, webhookService.DeliverWebhooks(now).Data)
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(now).Data)
+
+	close(receivedCh)
+	eventTypes := []string{}
+	for r := range receivedCh {
+		eventTypes = append(eventTypes, r.eventType)
+		var payload response.WebhookEventPayload
+		require.NoError(t, json.Unmarshal(r.body, &payload))
+		assert.Equal(t, r.eventType, string(payload.EventType))
+	}
+	assert.ElementsMatch(t, []string{"trx.deposit", "trx.deposit", "trx.transfer"}, eventTypes)
+
+	subscriptionId := everything.Data.(response.WebhookSubscriptionResponse).SubscriptionId
+	deliveries := webhookService.GetWebhookDeliveries(admin, subscriptionId, "delivered").Data.([]response.WebhookDeliveryResponse)
+	assert.Len(t, deliveries, 2)
+	var count int64
+	db.Model(&entity.OutboxEventEntity{}).Where("dispatched_at IS NULL").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestDeliverWebhooks_backoffDeadLetterAndRedeliver(t *testing.T) {
+	walletService, webhookService, _ := newWebhookTestService(t)
+
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	subscription := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: server.URL}).Data.(response.WebhookSubscriptionResponse)
+	assert.NotEmpty(t, subscription.Secret)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 1000}, "").Err.Code)
+
+	now := time.Now().UTC()
+	webhookService.DispatchOutboxEvents(now)
+	assert.Equal(t, 1, webhookService.DeliverWebhooks(now).Data)
+	delivery := webhookService.GetWebhookDeliveries(admin, subscription.SubscriptionId, "").Data.([]response.WebhookDeliveryResponse)[0]
+	assert.Equal(t, common.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.LastStatusCode)
+	assert.WithinDuration(t, now.Add(time.Minute), delivery.NextAttemptAt, time.Second)
+
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(now.Add(30*time.Second)).Data)
+	now = now.Add(61 * time.Second)
+	assert.Equal(t, 1, webhookService.DeliverWebhooks(now).Data)
+	now = now.Add(121 * time.Second)
+	assert.Equal(t, 1, webhookService.DeliverWebhooks(now).Data)
+
+	delivery = webhookService.GetWebhookDeliveries(admin, subscription.SubscriptionId, "").Data.([]response.WebhookDeliveryResponse)[0]
+	assert.Equal(t, common.WebhookDeliveryDead, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempt)
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(now.Add(time.Hour)).Data)
+
+	healthy.Store(true)
+	redelivered := webhookService.RedeliverWebhook(admin, delivery.DeliveryId)
+	require.Equal(t, 0, redelivered.Err.Code)
+	assert.Equal(t, common.WebhookDeliveryPending, redelivered.Data.(response.WebhookDeliveryResponse).Status)
+	assert.Equal(t, 1, webhookService.DeliverWebhooks(time.Now().UTC()).Data)
+	delivery = webhookService.GetWebhookDeliveries(admin, subscription.SubscriptionId, "").Data.([]response.WebhookDeliveryResponse)[0]
+	assert.Equal(t, common.WebhookDeliveryDelivered, delivery.Status)
+	assert.NotNil(t, delivery.DeliveredAt)
+}
+
+func TestDeliverWebhooks_dropsDeliveriesOfInactiveSubscription(t *testing.T) {
+	walletService, webhookService, _ := newWebhookTestService(t)
+
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { posts.Add(1) }))
+	defer server.Close()
+
+	subscription := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: server.URL}).Data.(response.WebhookSubscriptionResponse)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 1000}, "").Err.Code)
+	now := time.Now().UTC()
+	webhookService.DispatchOutboxEvents(now)
+	inactive := false
+	require.Equal(t, 0, webhookService.UpdateWebhookSubscription(admin, subscription.SubscriptionId, request.UpdateWebhookSubscriptionReq{Active: &inactive}).Err.Code)
+
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(now).Data)
+	assert.Zero(t, posts.Load())
+	deliveries := webhookService.GetWebhookDeliveries(admin, subscription.SubscriptionId, "dropped").Data.([]response.WebhookDeliveryResponse)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 0, deliveries[0].Attempt)
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(now.Add(time.Hour)).Data)
+}
+
+func TestDeliverWebhooks_stopsBeforeTheLeaseExpires(t *testing.T) {
+	walletService, webhookService, _ := newWebhookTestService(t)
+
+	var posts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { posts.Add(1) }))
+	defer server.Close()
+
+	subscription := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: server.URL}).Data.(response.WebhookSubscriptionResponse)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_jana", request.TrxReq{Amount: 1000}, "").Err.Code)
+	leasedAt := time.Now().UTC().Add(-time.Minute)
+	webhookService.DispatchOutboxEvents(leasedAt)
+
+	assert.Equal(t, 0, webhookService.DeliverWebhooks(leasedAt).Data)
+	assert.Zero(t, posts.Load())
+	deliveries := webhookService.GetWebhookDeliveries(admin, subscription.SubscriptionId, "pending").Data.([]response.WebhookDeliveryResponse)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, 0, deliveries[0].Attempt)
+
+	assert.Equal(t, 1, webhookService.DeliverWebhooks(time.Now().UTC()).Data)
+	assert.EqualValues(t, 1, posts.Load())
+}
+
+func TestWebhookSubscription_validation(t *testing.T) {
+	_, webhookService, _ := newWebhookTestService(t)
+
+	assert.Equal(t, apperror.ErrForbidden, webhookService.CreateWebhookSubscription(auth.Principal{UserId: "jana"}, request.CreateWebhookSubscriptionReq{Url: "https://example.com/hook"}).Err)
+	assert.Equal(t, apperror.ErrInvalidWebhookUrl, webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: "example.com/hook"}).Err)
+	assert.Equal(t, apperror.ErrInvalidWebhookEventType, webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: "https://example.com/hook", EventTypes: []string{"trx.hold"}}).Err)
+	assert.Equal(t, apperror.ErrWebhookSubscriptionNotFound, webhookService.GetWebhookSubscription(admin, "missing").Err)
+	assert.Equal(t, apperror.ErrWebhookDeliveryNotFound, webhookService.RedeliverWebhook(admin, "missing").Err)
+
+	created := webhookService.CreateWebhookSubscription(admin, request.CreateWebhookSubscriptionReq{Url: "https://example.com/hook"}).Data.(response.WebhookSubscriptionResponse)
+	inactive := false
+	updated := webhookService.UpdateWebhookSubscription(admin, created.SubscriptionId, request.UpdateWebhookSubscriptionReq{Active: &inactive})
+	require.Equal(t, 0, updated.Err.Code)
+	assert.False(t, updated.Data.(response.WebhookSubscriptionResponse).Active)
+	assert.Empty(t, updated.Data.(response.WebhookSubscriptionResponse).Secret)
+
+	require.Equal(t, 0, webhookService.DeleteWebhookSubscription(admin, created.SubscriptionId).Err.Code)
+	assert.Equal(t, apperror.ErrWebhookSubscriptionNotFound, webhookService.GetWebhookSubscription(admin, created.SubscriptionId).Err)
+}
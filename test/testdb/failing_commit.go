@@ -0,0 +1,42 @@
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var ErrCommitFailed = errors.New("commit failed")
+
+// FailingCommits returns db with a connection pool whose transactions run as usual but fail to
+// commit, rolling back instead, as a commit lost to a dropped connection would.
+func FailingCommits(db *gorm.DB) *gorm.DB {
+	db = db.WithContext(context.Background())
+	db.Statement.ConnPool = &failingCommitPool{ConnPool: db.Statement.ConnPool}
+	return db
+}
+
+type failingCommitPool struct {
+	gorm.ConnPool
+}
+
+func (p *failingCommitPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.ConnPool.(gorm.TxBeginner).BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &failingCommitTx{Tx: tx}, nil
+}
+
+type failingCommitTx struct {
+	*sql.Tx
+}
+
+func (t *failingCommitTx) Commit() error {
+	if err := t.Tx.Rollback(); err != nil {
+		return err
+	}
+	return ErrCommitFailed
+}
@@ -0,0 +1,48 @@
+// Package testdb opens the database the integration tests run against: an in-memory sqlite database
+// by default, or the backend named by TEST_DATABASE_DRIVER (postgres or sqlite) at TEST_DATABASE_DSN
+// (a postgres connection string or a sqlite file). Postgres runs need `go test -p 1 ./...`, since
+// every test drops and recreates the same tables.
+package testdb
+
+import (
+	"os"
+	"testing"
+	appdb "wallet-app/db"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// Open returns an empty, migrated database. name keeps in-memory sqlite databases of different
+// tests apart.
+func Open(t *testing.T, name string) *gorm.DB {
+	db := OpenUnmigrated(t, name)
+	require.NoError(t, appdb.Migrate(db))
+	return db
+}
+
+// OpenUnmigrated returns a database without any of the app's tables, for tests that build an older
+// schema before migrating.
+func OpenUnmigrated(t *testing.T, name string) *gorm.DB {
+	driver, dsn := os.Getenv("TEST_DATABASE_DRIVER"), os.Getenv("TEST_DATABASE_DSN")
+
+	var db *gorm.DB
+	var err error
+	switch driver {
+	case appdb.DriverPostgres:
+		require.NotEmpty(t, dsn, "TEST_DATABASE_DSN is required for postgres")
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	case appdb.DriverSqlite, "":
+		if dsn == "" {
+			dsn = "file:" + name + "?mode=memory&cache=shared"
+		}
+		db, err = appdb.OpenSqlite(dsn)
+	default:
+		t.Fatalf("unknown TEST_DATABASE_DRIVER %q", driver)
+	}
+	require.NoError(t, err)
+
+	require.NoError(t, db.Migrator().DropTable(appdb.Entities()...))
+	return db
+}
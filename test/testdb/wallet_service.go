@@ -0,0 +1,67 @@
+package testdb
+
+import (
+	"wallet-app/config"
+	"wallet-app/fx"
+	"wallet-app/manager"
+	"wallet-app/mapper"
+	"wallet-app/repo"
+	"wallet-app/service"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// WalletServiceDeps are the arguments of service.NewWalletService, so tests name the ones they change
+// instead of repeating the whole call.
+type WalletServiceDeps struct {
+	Log               *logrus.Logger
+	Cfg               *config.AppConfig
+	WalletRepo        repo.IWalletRepo
+	TrxRepo           repo.ITrxRepo
+	LedgerRepo        repo.ILedgerRepo
+	IdempotencyRepo   repo.IIdempotencyRepo
+	FxQuoteRepo       repo.IFxQuoteRepo
+	HoldRepo          repo.IHoldRepo
+	OutboxRepo        repo.IOutboxRepo
+	AdjustmentRepo    repo.IAdjustmentRepo
+	SpendingLimitRepo repo.ISpendingLimitRepo
+	FeeRuleRepo       repo.IFeeRuleRepo
+	FxProvider        fx.IFxProvider
+	Mapper            *mapper.AppMapper
+	DbTxManager       manager.IDbTxManager
+}
+
+// Deps returns the deps of a wallet service with cfg on the repos of db, without an fx provider.
+func Deps(db *gorm.DB, cfg *config.AppConfig) WalletServiceDeps {
+	return WalletServiceDeps{
+		Log:               logrus.New(),
+		Cfg:               cfg,
+		WalletRepo:        repo.NewWalletRepo(db),
+		TrxRepo:           repo.NewTransactionRepo(db),
+		LedgerRepo:        repo.NewLedgerRepo(db),
+		IdempotencyRepo:   repo.NewIdempotencyRepo(db),
+		FxQuoteRepo:       repo.NewFxQuoteRepo(db),
+		HoldRepo:          repo.NewHoldRepo(db),
+		OutboxRepo:        repo.NewOutboxRepo(db),
+		AdjustmentRepo:    repo.NewAdjustmentRepo(db),
+		SpendingLimitRepo: repo.NewSpendingLimitRepo(db),
+		FeeRuleRepo:       repo.NewFeeRuleRepo(db),
+		Mapper:            &mapper.AppMapper{},
+		DbTxManager:       manager.NewDbTxManager(db),
+	}
+}
+
+func (d WalletServiceDeps) NewWalletService() service.IWalletService {
+	return service.NewWalletService(d.Log, d.Cfg, d.WalletRepo, d.TrxRepo, d.LedgerRepo, d.IdempotencyRepo, d.FxQuoteRepo, d.HoldRepo,
+		d.OutboxRepo, d.AdjustmentRepo, d.SpendingLimitRepo, d.FeeRuleRepo, d.FxProvider, d.Mapper, d.DbTxManager)
+}
+
+// NewWalletService returns a wallet service with cfg on db, with the deps changed by overrides first.
+func NewWalletService(db *gorm.DB, cfg *config.AppConfig, overrides ...func(*WalletServiceDeps)) service.IWalletService {
+	deps := Deps(db, cfg)
+	for _, override := range overrides {
+		override(&deps)
+	}
+	return deps.NewWalletService()
+}
@@ -0,0 +1,176 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-app/auth"
+	"wallet-app/config"
+	"wallet-app/controller"
+	"wallet-app/entity"
+	"wallet-app/middleware"
+	"wallet-app/request"
+	"wallet-app/service"
+	mock_test "wallet-app/test/mock"
+	"wallet-app/test/testdb"
+	"wallet-app/tracing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+)
+
+const (
+	testSecret   = "tracing-test-secret"
+	callerTrace  = "4bf92f3577b34da6a3ce929d0e0e4736"
+	callerSpan   = "00f067aa0ba902b7"
+	traceparent  = "00-" + callerTrace + "-" + callerSpan + "-01"
+	withdrawPath = "/wallets/:walletId/withdraw"
+)
+
+var admin = auth.Principal{UserId: "ops", Roles: []string{auth.RoleAdmin}}
+
+// newTracedApp wires the wallet service and its repos through the tracing decorators, as main does,
+// and serves withdrawals through the controller.
+func newTracedApp(t *testing.T) (*gin.Engine, service.IWalletService, *tracetest.InMemoryExporter) {
+	gin.SetMode(gin.TestMode)
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	db := testdb.Open(t, "tracing_test")
+	require.NoError(t, tracing.InstrumentDb(db, provider))
+	require.NoError(t, db.Create(&entity.WalletEntity{ID: "wallet_mine", UserId: "jana", Currency: "SGD"}).Error)
+
+	walletService := tracing.NewWalletService(provider, testdb.NewWalletService(db, &config.AppConfig{}, func(deps *testdb.WalletServiceDeps) {
+		deps.WalletRepo = tracing.NewWalletRepo(provider, deps.WalletRepo)
+		deps.TrxRepo = tracing.NewTrxRepo(provider, deps.TrxRepo)
+		deps.DbTxManager = tracing.NewDbTxManager(provider, deps.DbTxManager)
+	}))
+
+	verifier, err := auth.NewJwtVerifier(&config.AuthConfig{HmacSecret: testSecret})
+	require.NoError(t, err)
+	r := gin.New()
+	r.Use(middleware.Tracing("wallet-app", provider))
+	r.POST(withdrawPath, middleware.Authenticate(logrus.New(), verifier), controller.NewWalletController(logrus.New(), walletService).WithdrawMoney)
+	return r, walletService, exporter
+}
+
+func withdraw(t *testing.T, r *gin.Engine, amount string) int {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "jana", "exp": time.Now().Add(time.Hour).Unix()}).
+		SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/wallets/wallet_mine/withdraw", strings.NewReader(`{"amount": `+amount+`}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("traceparent", traceparent)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	return res.Code
+}
+
+func findSpan(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("no span named %s", name)
+	return tracetest.SpanStub{}
+}
+
+func childrenOf(spans tracetest.SpanStubs, parent tracetest.SpanStub) []string {
+	var names []string
+	for _, span := range spans {
+		if span.Parent.SpanID() == parent.SpanContext.SpanID() {
+			names = append(names, span.Name)
+		}
+	}
+	return names
+}
+
+func attr(span tracetest.SpanStub, key attribute.Key) string {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracing_withdrawalSpansNestFromRequestToQueries(t *testing.T) {
+	r, walletService, exporter := newTracedApp(t)
+	require.Equal(t, 0, walletService.DepositMoney(context.Background(), admin, "wallet_mine", request.TrxReq{Amount: 1000}, "").Err.Code)
+	exporter.Reset()
+
+	require.Equal(t, http.StatusOK, withdraw(t, r, "300"))
+	spans := exporter.GetSpans()
+
+	server := findSpan(t, spans, withdrawPath)
+	assert.Equal(t, callerTrace, server.SpanContext.TraceID().String())
+	assert.Equal(t, callerSpan, server.Parent.SpanID().String())
+	assert.True(t, server.Parent.IsRemote())
+
+	withdrawal := findSpan(t, spans, "WalletService.WithdrawMoney")
+	assert.Equal(t, server.SpanContext.SpanID(), withdrawal.Parent.SpanID())
+	assert.Equal(t, "wallet_mine", attr(withdrawal, tracing.WalletIdKey))
+	assert.Equal(t, "withdrawal", attr(withdrawal, tracing.TrxTypeKey))
+	assert.Equal(t, "ok", attr(withdrawal, tracing.OutcomeKey))
+	assert.Subset(t, childrenOf(spans, withdrawal),
+		[]string{"db.transaction", "WalletRepo.FindWalletByIdWithTx", "WalletRepo.SaveWalletWithTx", "TrxRepo.SaveTrxWithDbTx"})
+
+	assert.Equal(t, []string{"gorm.Query"}, childrenOf(spans, findSpan(t, spans, "WalletRepo.FindWalletByIdWithTx")))
+	assert.Equal(t, []string{"gorm.Update"}, childrenOf(spans, findSpan(t, spans, "WalletRepo.SaveWalletWithTx")))
+
+	dbTx := findSpan(t, spans, "db.transaction")
+	assert.Equal(t, "commit", attr(dbTx, tracing.OutcomeKey))
+	assert.Equal(t, []string{"db.commit"}, childrenOf(spans, dbTx))
+
+	for _, span := range spans {
+		assert.Equal(t, callerTrace, span.SpanContext.TraceID().String(), span.Name)
+	}
+}
+
+func TestTracing_rejectedWithdrawalIsNotAnError(t *testing.T) {
+	r, _, exporter := newTracedApp(t)
+
+	require.Equal(t, http.StatusBadRequest, withdraw(t, r, "5000"))
+	spans := exporter.GetSpans()
+
+	withdrawal := findSpan(t, spans, "WalletService.WithdrawMoney")
+	assert.Equal(t, "rejected", attr(withdrawal, tracing.OutcomeKey))
+	assert.Equal(t, codes.Unset, withdrawal.Status.Code)
+	assert.Equal(t, "rollback", attr(findSpan(t, spans, "db.transaction"), tracing.OutcomeKey))
+}
+
+func TestTracing_repoSpanOutcomes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	mockWalletRepo := new(mock_test.MockWalletRepo)
+	mockWalletRepo.On("FindWalletByIdWithTx", "wallet_locked", mock.Anything).Return(entity.WalletEntity{}, &pgconn.PgError{Code: "55P03"})
+	mockWalletRepo.On("FindWalletByIdWithTx", "wallet_missing", mock.Anything).Return(entity.WalletEntity{}, gorm.ErrRecordNotFound)
+	walletRepo := tracing.NewWalletRepo(provider, mockWalletRepo)
+
+	_, err := walletRepo.FindWalletByIdWithTx("wallet_locked", nil)
+	assert.Error(t, err)
+	_, err = walletRepo.FindWalletByIdWithTx("wallet_missing", nil)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "lock_not_available", attr(spans[0], tracing.OutcomeKey))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "not_found", attr(spans[1], tracing.OutcomeKey))
+	assert.Equal(t, codes.Unset, spans[1].Status.Code)
+}
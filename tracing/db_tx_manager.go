@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"wallet-app/manager"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	outcomeCommit      = "commit"
+	outcomeCommitError = "commit_error"
+	outcomeRollback    = "rollback"
+)
+
+// NewDbTxManager starts a span for every transaction begun on the db next hands out, under the
+// context the transaction is begun with, and a child span for its commit. As in the metrics
+// decorator, the connection pool of that db is swapped for one that wraps each transaction.
+func NewDbTxManager(provider trace.TracerProvider, next manager.IDbTxManager) manager.IDbTxManager {
+	db := next.GetTx(context.Background()).Session(&gorm.Session{})
+	db.Statement.ConnPool = &tracedConnPool{ConnPool: db.Statement.ConnPool, tracer: provider.Tracer(instrumentationName)}
+	return manager.NewDbTxManager(db)
+}
+
+type tracedConnPool struct {
+	gorm.ConnPool
+	tracer trace.Tracer
+}
+
+func (p *tracedConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	ctx, span := p.tracer.Start(ctx, "db.transaction", trace.WithSpanKind(trace.SpanKindClient))
+	var tx gorm.ConnPool
+	var err error
+	switch beginner := p.ConnPool.(type) {
+	case gorm.TxBeginner:
+		tx, err = beginner.BeginTx(ctx, opts)
+	case gorm.ConnPoolBeginner:
+		tx, err = beginner.BeginTx(ctx, opts)
+	default:
+		err = gorm.ErrInvalidTransaction
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		return nil, err
+	}
+	committer, ok := tx.(gorm.TxCommitter)
+	if !ok {
+		span.End()
+		return nil, errors.New("transaction cannot commit")
+	}
+	return &tracedTx{ConnPool: tx, committer: committer, tracer: p.tracer, ctx: ctx, span: span}, nil
+}
+
+// GetDBConn keeps db.DB() working on the wrapped pool.
+func (p *tracedConnPool) GetDBConn() (*sql.DB, error) {
+	if connector, ok := p.ConnPool.(gorm.GetDBConnector); ok {
+		return connector.GetDBConn()
+	}
+	if sqlDb, ok := p.ConnPool.(*sql.DB); ok {
+		return sqlDb, nil
+	}
+	return nil, gorm.ErrInvalidDB
+}
+
+// tracedTx ends its span on the first commit or rollback only, should a caller roll back after a
+// failed commit.
+type tracedTx struct {
+	gorm.ConnPool
+	committer gorm.TxCommitter
+	tracer    trace.Tracer
+	ctx       context.Context
+	span      trace.Span
+	ended     sync.Once
+}
+
+func (t *tracedTx) Commit() error {
+	_, span := t.tracer.Start(t.ctx, "db.commit", trace.WithSpanKind(trace.SpanKindClient))
+	err := t.committer.Commit()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+		t.end(outcomeCommitError, err)
+		return err
+	}
+	span.End()
+	t.end(outcomeCommit, nil)
+	return nil
+}
+
+func (t *tracedTx) Rollback() error {
+	err := t.committer.Rollback()
+	t.end(outcomeRollback, nil)
+	return err
+}
+
+func (t *tracedTx) end(outcome string, err error) {
+	t.ended.Do(func() {
+		t.span.SetAttributes(OutcomeKey.String(outcome))
+		if err != nil {
+			t.span.SetStatus(codes.Error, err.Error())
+		}
+		t.span.End()
+	})
+}
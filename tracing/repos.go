@@ -0,0 +1,274 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"time"
+	"wallet-app/common"
+	appdb "wallet-app/db"
+	"wallet-app/entity"
+	"wallet-app/repo"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	outcomeNotFound         = "not_found"
+	outcomeLockNotAvailable = "lock_not_available"
+)
+
+// repoTracer starts the spans of the repo decorators. Calls taking a dbTx have no context of their own;
+// their span goes under the context the dbTx was begun with, and the dbTx handed down is bound to the
+// new span so the gorm query spans nest under it.
+type repoTracer struct {
+	tracer trace.Tracer
+}
+
+func (r repoTracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return r.tracer.Start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+func (r repoTracer) startTx(tx *gorm.DB, name string, attrs ...attribute.KeyValue) (*gorm.DB, trace.Span) {
+	if tx == nil {
+		_, span := r.start(context.Background(), name, attrs...)
+		return tx, span
+	}
+	ctx, span := r.start(tx.Statement.Context, name, attrs...)
+	return tx.WithContext(ctx), span
+}
+
+// endErr records the outcome of a repo call. A missing row is an answer, not a failure.
+func endErr(span trace.Span, err error) {
+	defer span.End()
+	switch {
+	case err == nil:
+		span.SetAttributes(OutcomeKey.String(outcomeOk))
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		span.SetAttributes(OutcomeKey.String(outcomeNotFound))
+	case appdb.IsLockNotAvailable(err):
+		span.SetAttributes(OutcomeKey.String(outcomeLockNotAvailable))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	default:
+		span.SetAttributes(OutcomeKey.String(outcomeFailed))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+type walletRepo struct {
+	next repo.IWalletRepo
+	repoTracer
+}
+
+func NewWalletRepo(provider trace.TracerProvider, next repo.IWalletRepo) repo.IWalletRepo {
+	return &walletRepo{next: next, repoTracer: repoTracer{tracer: provider.Tracer(instrumentationName)}}
+}
+
+func (r *walletRepo) BindTx(tx *gorm.DB) repo.IWalletRepo {
+	return &walletRepo{next: r.next.BindTx(tx), repoTracer: r.repoTracer}
+}
+
+func (r *walletRepo) FindWalletById(ctx context.Context, walletId string) (entity.WalletEntity, error) {
+	ctx, span := r.start(ctx, "WalletRepo.FindWalletById", WalletIdKey.String(walletId))
+	wallet, err := r.next.FindWalletById(ctx, walletId)
+	endErr(span, err)
+	return wallet, err
+}
+
+func (r *walletRepo) FindWalletsByUserId(ctx context.Context, userId string) []entity.WalletEntity {
+	ctx, span := r.start(ctx, "WalletRepo.FindWalletsByUserId")
+	wallets := r.next.FindWalletsByUserId(ctx, userId)
+	endErr(span, nil)
+	return wallets
+}
+
+// FindWalletByIdWithTx locks the wallet row, so its span includes any lock wait.
+func (r *walletRepo) FindWalletByIdWithTx(walletId string, tx *gorm.DB) (entity.WalletEntity, error) {
+	tx, span := r.startTx(tx, "WalletRepo.FindWalletByIdWithTx", WalletIdKey.String(walletId))
+	wallet, err := r.next.FindWalletByIdWithTx(walletId, tx)
+	endErr(span, err)
+	return wallet, err
+}
+
+func (r *walletRepo) FindAllWallets(ctx context.Context) []entity.WalletEntity {
+	ctx, span := r.start(ctx, "WalletRepo.FindAllWallets")
+	wallets := r.next.FindAllWallets(ctx)
+	endErr(span, nil)
+	return wallets
+}
+
+func (r *walletRepo) FindWalletIds(ctx context.Context, afterId string, limit int) ([]string, error) {
+	ctx, span := r.start(ctx, "WalletRepo.FindWalletIds")
+	ids, err := r.next.FindWalletIds(ctx, afterId, limit)
+	endErr(span, err)
+	return ids, err
+}
+
+func (r *walletRepo) SaveWallet(ctx context.Context, wallet entity.WalletEntity) error {
+	ctx, span := r.start(ctx, "WalletRepo.SaveWallet", WalletIdKey.String(wallet.ID))
+	err := r.next.SaveWallet(ctx, wallet)
+	endErr(span, err)
+	return err
+}
+
+func (r *walletRepo) SaveWalletWithTx(wallet entity.WalletEntity, tx *gorm.DB) error {
+	tx, span := r.startTx(tx, "WalletRepo.SaveWalletWithTx", WalletIdKey.String(wallet.ID))
+	err := r.next.SaveWalletWithTx(wallet, tx)
+	endErr(span, err)
+	return err
+}
+
+func (r *walletRepo) SaveWallets(ctx context.Context, wallets []entity.WalletEntity) error {
+	ctx, span := r.start(ctx, "WalletRepo.SaveWallets", walletIds(wallets))
+	err := r.next.SaveWallets(ctx, wallets)
+	endErr(span, err)
+	return err
+}
+
+func (r *walletRepo) SaveWalletsWithTx(wallets []entity.WalletEntity, tx *gorm.DB) error {
+	tx, span := r.startTx(tx, "WalletRepo.SaveWalletsWithTx", walletIds(wallets))
+	err := r.next.SaveWalletsWithTx(wallets, tx)
+	endErr(span, err)
+	return err
+}
+
+func (r *walletRepo) DeleteAll(ctx context.Context) error {
+	ctx, span := r.start(ctx, "WalletRepo.DeleteAll")
+	err := r.next.DeleteAll(ctx)
+	endErr(span, err)
+	return err
+}
+
+func (r *walletRepo) FindWalletStatusChanges(ctx context.Context, walletId string) []entity.WalletStatusChangeEntity {
+	ctx, span := r.start(ctx, "WalletRepo.FindWalletStatusChanges", WalletIdKey.String(walletId))
+	changes := r.next.FindWalletStatusChanges(ctx, walletId)
+	endErr(span, nil)
+	return changes
+}
+
+func (r *walletRepo) SaveWalletStatusChangeWithTx(change entity.WalletStatusChangeEntity, tx *gorm.DB) error {
+	tx, span := r.startTx(tx, "WalletRepo.SaveWalletStatusChangeWithTx", WalletIdKey.String(change.WalletId))
+	err := r.next.SaveWalletStatusChangeWithTx(change, tx)
+	endErr(span, err)
+	return err
+}
+
+// walletIds holds every wallet of a batch save, e.g. both sides of a transfer.
+func walletIds(wallets []entity.WalletEntity) attribute.KeyValue {
+	ids := make([]string, len(wallets))
+	for i, wallet := range wallets {
+		ids[i] = wallet.ID
+	}
+	return WalletIdKey.StringSlice(ids)
+}
+
+type trxRepo struct {
+	next repo.ITrxRepo
+	repoTracer
+}
+
+func NewTrxRepo(provider trace.TracerProvider, next repo.ITrxRepo) repo.ITrxRepo {
+	return &trxRepo{next: next, repoTracer: repoTracer{tracer: provider.Tracer(instrumentationName)}}
+}
+
+func (r *trxRepo) BindTx(tx *gorm.DB) repo.ITrxRepo {
+	return &trxRepo{next: r.next.BindTx(tx), repoTracer: r.repoTracer}
+}
+
+func (r *trxRepo) FindAllTrxs(ctx context.Context) []entity.TrxEntity {
+	ctx, span := r.start(ctx, "TrxRepo.FindAllTrxs")
+	trxs := r.next.FindAllTrxs(ctx)
+	endErr(span, nil)
+	return trxs
+}
+
+func (r *trxRepo) FindTransactionsByWalletId(ctx context.Context, walletId string) []entity.TrxEntity {
+	ctx, span := r.start(ctx, "TrxRepo.FindTransactionsByWalletId", WalletIdKey.String(walletId))
+	trxs := r.next.FindTransactionsByWalletId(ctx, walletId)
+	endErr(span, nil)
+	return trxs
+}
+
+func (r *trxRepo) FindTransactions(ctx context.Context, query repo.TrxQuery) ([]entity.TrxEntity, error) {
+	ctx, span := r.start(ctx, "TrxRepo.FindTransactions", WalletIdKey.String(query.WalletId))
+	trxs, err := r.next.FindTransactions(ctx, query)
+	endErr(span, err)
+	return trxs, err
+}
+
+func (r *trxRepo) FindTrxsByGroupId(ctx context.Context, groupId string) []entity.TrxEntity {
+	ctx, span := r.start(ctx, "TrxRepo.FindTrxsByGroupId", attribute.String("trx.group_id", groupId))
+	trxs := r.next.FindTrxsByGroupId(ctx, groupId)
+	endErr(span, nil)
+	return trxs
+}
+
+func (r *trxRepo) SumAmountsByTrxTypeWithTx(walletId string, tx *gorm.DB) ([]repo.TrxTypeTotal, error) {
+	tx, span := r.startTx(tx, "TrxRepo.SumAmountsByTrxTypeWithTx", WalletIdKey.String(walletId))
+	totals, err := r.next.SumAmountsByTrxTypeWithTx(walletId, tx)
+	endErr(span, err)
+	return totals, err
+}
+
+func (r *trxRepo) SumAmountsByTrxTypeBetween(ctx context.Context, walletId string, from time.Time, to time.Time) ([]repo.TrxTypeTotal, error) {
+	ctx, span := r.start(ctx, "TrxRepo.SumAmountsByTrxTypeBetween", WalletIdKey.String(walletId))
+	totals, err := r.next.SumAmountsByTrxTypeBetween(ctx, walletId, from, to)
+	endErr(span, err)
+	return totals, err
+}
+
+// StreamTrxs spans the whole stream, including the time fn takes to write each row.
+func (r *trxRepo) StreamTrxs(ctx context.Context, walletId string, from time.Time, to time.Time, fn func(trx entity.TrxEntity) error) error {
+	ctx, span := r.start(ctx, "TrxRepo.StreamTrxs", WalletIdKey.String(walletId))
+	err := r.next.StreamTrxs(ctx, walletId, from, to, fn)
+	endErr(span, err)
+	return err
+}
+
+func (r *trxRepo) FindTrxsByTypesSinceWithTx(walletId string, trxTypes []common.TrxType, since time.Time, tx *gorm.DB) ([]entity.TrxEntity, error) {
+	tx, span := r.startTx(tx, "TrxRepo.FindTrxsByTypesSinceWithTx", WalletIdKey.String(walletId))
+	trxs, err := r.next.FindTrxsByTypesSinceWithTx(walletId, trxTypes, since, tx)
+	endErr(span, err)
+	return trxs, err
+}
+
+func (r *trxRepo) SaveTrx(ctx context.Context, trx entity.TrxEntity) error {
+	ctx, span := r.start(ctx, "TrxRepo.SaveTrx", WalletIdKey.String(trx.WalletId), trxType(trx.TrxType))
+	err := r.next.SaveTrx(ctx, trx)
+	endErr(span, err)
+	return err
+}
+
+func (r *trxRepo) SaveTrxWithDbTx(trx entity.TrxEntity, tx *gorm.DB) error {
+	tx, span := r.startTx(tx, "TrxRepo.SaveTrxWithDbTx", WalletIdKey.String(trx.WalletId), trxType(trx.TrxType))
+	err := r.next.SaveTrxWithDbTx(trx, tx)
+	endErr(span, err)
+	return err
+}
+
+func (r *trxRepo) SaveTrxs(ctx context.Context, trxs []entity.TrxEntity) error {
+	ctx, span := r.start(ctx, "TrxRepo.SaveTrxs", trxTypes(trxs))
+	err := r.next.SaveTrxs(ctx, trxs)
+	endErr(span, err)
+	return err
+}
+
+func (r *trxRepo) SaveTrxsWithDbTx(trxs []entity.TrxEntity, tx *gorm.DB) error {
+	tx, span := r.startTx(tx, "TrxRepo.SaveTrxsWithDbTx", trxTypes(trxs))
+	err := r.next.SaveTrxsWithDbTx(trxs, tx)
+	endErr(span, err)
+	return err
+}
+
+// trxTypes holds the trx type of every row of a batch save, e.g. a transfer and its fees.
+func trxTypes(trxs []entity.TrxEntity) attribute.KeyValue {
+	types := make([]string, len(trxs))
+	for i, trx := range trxs {
+		types[i] = string(trx.TrxType)
+	}
+	return TrxTypeKey.StringSlice(types)
+}
@@ -0,0 +1,85 @@
+// Package tracing exports OpenTelemetry spans. As with the metrics package, business code is not
+// instrumented itself: the service, repos and db transaction manager are wrapped in decorators that
+// start a span for every call, under the span found in the context the call carries.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"wallet-app/config"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOtlp   = "otlp"
+)
+
+const instrumentationName = "wallet-app"
+
+// Span attributes set by the decorators.
+const (
+	WalletIdKey = attribute.Key("wallet.id")
+	TrxTypeKey  = attribute.Key("trx.type")
+	OutcomeKey  = attribute.Key("outcome")
+)
+
+// NewTracerProvider builds the provider for the configured exporter. Callers shut it down on exit so
+// batched spans are flushed.
+func NewTracerProvider(cfg *config.TracingConfig) (*sdktrace.TracerProvider, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(cfg.ServiceName))),
+	}
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		// the decorators keep working but no span is recorded
+		return sdktrace.NewTracerProvider(append(opts, sdktrace.WithSampler(sdktrace.NeverSample()))...), nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterOtlp:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.OtlpEndpoint)}
+		if cfg.OtlpInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(context.Background(), clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	opts = append(opts, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))))
+	return sdktrace.NewTracerProvider(opts...), nil
+}
+
+// Propagator reads and writes the W3C traceparent, tracestate and baggage headers.
+func Propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// InstrumentDb starts a span for every gorm query under the span in the query's context. Query
+// parameters are left out, as they hold wallet ids and amounts.
+func InstrumentDb(db *gorm.DB, provider trace.TracerProvider) error {
+	return db.Use(gormtracing.NewPlugin(
+		gormtracing.WithTracerProvider(provider),
+		gormtracing.WithoutMetrics(),
+		gormtracing.WithoutQueryVariables(),
+	))
+}
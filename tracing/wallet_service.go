@@ -0,0 +1,196 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"wallet-app/auth"
+	"wallet-app/common"
+	"wallet-app/request"
+	"wallet-app/response"
+	"wallet-app/service"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	outcomeOk       = "ok"
+	outcomeRejected = "rejected" // 4xx, e.g. insufficient funds or a frozen wallet
+	outcomeFailed   = "failed"   // 5xx
+)
+
+// walletService starts a span for every call of the wrapped service, with the wallet, the trx type of
+// money movements and the outcome.
+type walletService struct {
+	next   service.IWalletService
+	tracer trace.Tracer
+}
+
+func NewWalletService(provider trace.TracerProvider, next service.IWalletService) service.IWalletService {
+	return &walletService{next: next, tracer: provider.Tracer(instrumentationName)}
+}
+
+func (s *walletService) CreateWallet(ctx context.Context, principal auth.Principal, req request.CreateWalletReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.CreateWallet")
+	return end(span, s.next.CreateWallet(ctx, principal, req))
+}
+
+func (s *walletService) GetWalletsByUserId(ctx context.Context, principal auth.Principal, userId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetWalletsByUserId")
+	return end(span, s.next.GetWalletsByUserId(ctx, principal, userId))
+}
+
+func (s *walletService) DepositMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.DepositMoney", WalletIdKey.String(walletId), trxType(common.TrxTypeDeposit))
+	return end(span, s.next.DepositMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) WithdrawMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TrxReq, idempotencyKey string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.WithdrawMoney", WalletIdKey.String(walletId), trxType(common.TrxTypeWithdrawal))
+	return end(span, s.next.WithdrawMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) TransferMoney(ctx context.Context, principal auth.Principal, walletId string, req request.TransferReq, idempotencyKey string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.TransferMoney", WalletIdKey.String(walletId), trxType(common.TrxTypeTransferOut))
+	return end(span, s.next.TransferMoney(ctx, principal, walletId, req, idempotencyKey))
+}
+
+func (s *walletService) QuoteTransfer(ctx context.Context, principal auth.Principal, walletId string, req request.TransferQuoteReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.QuoteTransfer", WalletIdKey.String(walletId))
+	return end(span, s.next.QuoteTransfer(ctx, principal, walletId, req))
+}
+
+func (s *walletService) PreviewFees(ctx context.Context, principal auth.Principal, walletId string, req request.FeePreviewReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.PreviewFees", WalletIdKey.String(walletId))
+	return end(span, s.next.PreviewFees(ctx, principal, walletId, req))
+}
+
+// ReverseTransfer names a transfer group rather than a wallet.
+func (s *walletService) ReverseTransfer(ctx context.Context, principal auth.Principal, groupId string, req request.ReverseTransferReq, idempotencyKey string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ReverseTransfer", attribute.String("trx.group_id", groupId), trxType(common.TrxTypeReversalOut))
+	return end(span, s.next.ReverseTransfer(ctx, principal, groupId, req, idempotencyKey))
+}
+
+func (s *walletService) GetBalance(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetBalance", WalletIdKey.String(walletId))
+	return end(span, s.next.GetBalance(ctx, principal, walletId))
+}
+
+func (s *walletService) GetTransactions(ctx context.Context, principal auth.Principal, walletId string, req request.TrxHistoryReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetTransactions", WalletIdKey.String(walletId))
+	return end(span, s.next.GetTransactions(ctx, principal, walletId, req))
+}
+
+func (s *walletService) ExportStatement(ctx context.Context, principal auth.Principal, walletId string, req request.StatementReq, out io.Writer) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ExportStatement", WalletIdKey.String(walletId))
+	return end(span, s.next.ExportStatement(ctx, principal, walletId, req, out))
+}
+
+func (s *walletService) CreateHold(ctx context.Context, principal auth.Principal, walletId string, req request.CreateHoldReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.CreateHold", WalletIdKey.String(walletId), trxType(common.TrxTypeHold))
+	return end(span, s.next.CreateHold(ctx, principal, walletId, req))
+}
+
+// CaptureHold is a withdrawal, or a transfer when the capture names a counterparty.
+func (s *walletService) CaptureHold(ctx context.Context, principal auth.Principal, holdId string, req request.CaptureHoldReq) response.ResonseWrapper {
+	capturedAs := common.TrxTypeWithdrawal
+	if req.CounterpartyWalletId != "" {
+		capturedAs = common.TrxTypeTransferOut
+	}
+	ctx, span := s.start(ctx, "WalletService.CaptureHold", attribute.String("hold.id", holdId), trxType(capturedAs))
+	return end(span, s.next.CaptureHold(ctx, principal, holdId, req))
+}
+
+func (s *walletService) ReleaseHold(ctx context.Context, principal auth.Principal, holdId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ReleaseHold", attribute.String("hold.id", holdId))
+	return end(span, s.next.ReleaseHold(ctx, principal, holdId))
+}
+
+func (s *walletService) GetHolds(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetHolds", WalletIdKey.String(walletId))
+	return end(span, s.next.GetHolds(ctx, principal, walletId))
+}
+
+func (s *walletService) ExpireHolds(ctx context.Context) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ExpireHolds")
+	return end(span, s.next.ExpireHolds(ctx))
+}
+
+func (s *walletService) GetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetSpendingLimits", WalletIdKey.String(walletId))
+	return end(span, s.next.GetSpendingLimits(ctx, principal, walletId))
+}
+
+func (s *walletService) SetSpendingLimits(ctx context.Context, principal auth.Principal, walletId string, req request.SpendingLimitReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.SetSpendingLimits", WalletIdKey.String(walletId))
+	return end(span, s.next.SetSpendingLimits(ctx, principal, walletId, req))
+}
+
+func (s *walletService) DeleteSpendingLimits(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.DeleteSpendingLimits", WalletIdKey.String(walletId))
+	return end(span, s.next.DeleteSpendingLimits(ctx, principal, walletId))
+}
+
+func (s *walletService) ChangeWalletStatus(ctx context.Context, principal auth.Principal, walletId string, req request.WalletStatusReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ChangeWalletStatus", WalletIdKey.String(walletId))
+	return end(span, s.next.ChangeWalletStatus(ctx, principal, walletId, req))
+}
+
+func (s *walletService) GetWalletStatusChanges(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetWalletStatusChanges", WalletIdKey.String(walletId))
+	return end(span, s.next.GetWalletStatusChanges(ctx, principal, walletId))
+}
+
+func (s *walletService) ReconcileWallets(ctx context.Context, principal auth.Principal, req request.ReconcileReq) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.ReconcileWallets", attribute.Bool("reconcile.repair", req.Repair))
+	return end(span, s.next.ReconcileWallets(ctx, principal, req))
+}
+
+func (s *walletService) AdjustBalance(ctx context.Context, principal auth.Principal, walletId string, req request.BalanceAdjustmentReq) response.ResonseWrapper {
+	adjustment := common.TrxTypeAdjustmentIn
+	if req.Direction == string(common.EntryDirectionDebit) {
+		adjustment = common.TrxTypeAdjustmentOut
+	}
+	ctx, span := s.start(ctx, "WalletService.AdjustBalance", WalletIdKey.String(walletId), trxType(adjustment))
+	return end(span, s.next.AdjustBalance(ctx, principal, walletId, req))
+}
+
+func (s *walletService) GetBalanceAdjustments(ctx context.Context, principal auth.Principal, walletId string) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetBalanceAdjustments", WalletIdKey.String(walletId))
+	return end(span, s.next.GetBalanceAdjustments(ctx, principal, walletId))
+}
+
+func (s *walletService) DeleteAll(ctx context.Context, principal auth.Principal) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.DeleteAll")
+	return end(span, s.next.DeleteAll(ctx, principal))
+}
+
+func (s *walletService) GetAllTrxs(ctx context.Context) response.ResonseWrapper {
+	ctx, span := s.start(ctx, "WalletService.GetAllTrxs")
+	return end(span, s.next.GetAllTrxs(ctx))
+}
+
+func (s *walletService) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records the outcome of a service call. Rejections are answers to the caller, not failures, so
+// only 5xx errors mark the span as an error.
+func end(span trace.Span, res response.ResonseWrapper) response.ResonseWrapper {
+	defer span.End()
+	switch {
+	case res.Err.Code >= 500:
+		span.SetAttributes(OutcomeKey.String(outcomeFailed), attribute.Int("error.code", res.Err.Code))
+		span.SetStatus(codes.Error, res.Err.Message)
+	case res.Err.Code != 0:
+		span.SetAttributes(OutcomeKey.String(outcomeRejected), attribute.Int("error.code", res.Err.Code), attribute.String("error.message", res.Err.Message))
+	default:
+		span.SetAttributes(OutcomeKey.String(outcomeOk))
+	}
+	return res
+}
+
+func trxType(trxType common.TrxType) attribute.KeyValue {
+	return TrxTypeKey.String(string(trxType))
+}
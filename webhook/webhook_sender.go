@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEventId   = "X-Wallet-Event-Id"
+	HeaderEventType = "X-Wallet-Event-Type"
+	HeaderSignature = "X-Wallet-Signature"
+)
+
+type WebhookRequest struct {
+	Url       string
+	Secret    string
+	EventId   string
+	EventType string
+	Body      []byte
+	Timestamp time.Time
+}
+
+// IWebhookSender posts one event to one endpoint and returns the response status code.
+type IWebhookSender interface {
+	Send(req WebhookRequest) (int, error)
+}
+
+// Sign returns the signature header value "t=<unix seconds>,v1=<hex hmac>". The HMAC-SHA256 is taken
+// over "<unix seconds>.<body>", so receivers can reject stale timestamps as well as forged bodies.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", t, hex.EncodeToString(mac.Sum(nil)))
+}
+
+type HttpWebhookSender struct {
+	client *http.Client
+}
+
+func NewHttpWebhookSender(timeout time.Duration) IWebhookSender {
+	return &HttpWebhookSender{client: &http.Client{Timeout: timeout}}
+}
+
+func (h *HttpWebhookSender) Send(req WebhookRequest) (int, error) {
+	httpReq, err := http.NewRequest(http.MethodPost, req.Url, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(HeaderEventId, req.EventId)
+	httpReq.Header.Set(HeaderEventType, req.EventType)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, req.Timestamp, req.Body))
+
+	res, err := h.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	return res.StatusCode, nil
+}